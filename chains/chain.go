@@ -2,7 +2,9 @@ package chains
 
 import (
 	"context"
+	"fmt"
 	"math/big"
+	"strings"
 )
 
 // Chain defines the interface that all blockchain implementations must satisfy.
@@ -19,6 +21,10 @@ type Chain interface {
 	// WaitForTransaction waits for a transaction to be confirmed.
 	WaitForTransaction(ctx context.Context, txHash string) error
 
+	// GetTransactionReceipt returns the on-chain outcome of a transaction,
+	// or nil while it has not been included in a block.
+	GetTransactionReceipt(ctx context.Context, txHash string) (*Receipt, error)
+
 	// ValidateAddress validates if an address is valid for this chain.
 	ValidateAddress(address string) error
 
@@ -60,36 +66,62 @@ type ChainConfig struct {
 	DripAmounts map[string]string
 }
 
+// DefaultDecimals is the number of decimals of ETH, STRK and most ERC-20 tokens
+const DefaultDecimals = 18
+
 // AmountToWei converts a float amount to wei (10^18).
 // This is a common utility used by most chains.
 func AmountToWei(amount float64) *big.Int {
-	weiPerToken := new(big.Float).SetInt(new(big.Int).Exp(
-		big.NewInt(10),
-		big.NewInt(18),
-		nil,
-	))
+	return ToBaseUnits(amount, DefaultDecimals)
+}
+
+// WeiToAmount converts wei to a float amount.
+// This is a common utility used by most chains.
+func WeiToAmount(wei *big.Int) float64 {
+	return FromBaseUnits(wei, DefaultDecimals)
+}
+
+// ToBaseUnits converts a float amount to the smallest unit of a token with
+// the given number of decimals
+func ToBaseUnits(amount float64, decimals int) *big.Int {
+	unitsPerToken := new(big.Float).SetInt(unitScale(decimals))
 
 	amountFloat := new(big.Float).Mul(
 		big.NewFloat(amount),
-		weiPerToken,
+		unitsPerToken,
 	)
 
 	amountInt, _ := amountFloat.Int(nil)
 	return amountInt
 }
 
-// WeiToAmount converts wei to a float amount.
-// This is a common utility used by most chains.
-func WeiToAmount(wei *big.Int) float64 {
-	weiPerToken := new(big.Float).SetInt(new(big.Int).Exp(
-		big.NewInt(10),
-		big.NewInt(18),
-		nil,
-	))
-
-	weiFloat := new(big.Float).SetInt(wei)
-	amount := new(big.Float).Quo(weiFloat, weiPerToken)
+// FromBaseUnits converts an amount in a token's smallest unit to a float amount
+func FromBaseUnits(units *big.Int, decimals int) float64 {
+	unitsFloat := new(big.Float).SetInt(units)
+	amount := new(big.Float).Quo(unitsFloat, new(big.Float).SetInt(unitScale(decimals)))
 
 	result, _ := amount.Float64()
 	return result
 }
+
+// FormatUnits formats an amount in a token's smallest unit as an exact
+// decimal string without trailing zeros, e.g. 1500000000000000000 with 18
+// decimals is "1.5"
+func FormatUnits(units *big.Int, decimals int) string {
+	whole, frac := new(big.Int).QuoRem(new(big.Int).Abs(units), unitScale(decimals), new(big.Int))
+
+	s := whole.String()
+	if frac.Sign() != 0 {
+		fracStr := fmt.Sprintf("%0*s", decimals, frac.String())
+		s += "." + strings.TrimRight(fracStr, "0")
+	}
+	if units.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// unitScale returns 10^decimals
+func unitScale(decimals int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+}
@@ -0,0 +1,46 @@
+package chains
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatUnits(t *testing.T) {
+	tests := []struct {
+		units    string
+		decimals int
+		want     string
+	}{
+		{"0", 18, "0"},
+		{"1500000000000000000", 18, "1.5"},
+		{"1000000000000000000", 18, "1"},
+		{"1", 18, "0.000000000000000001"},
+		{"123456789", 6, "123.456789"},
+		{"-2500000", 6, "-2.5"},
+		{"42", 0, "42"},
+	}
+	for _, tt := range tests {
+		units, ok := new(big.Int).SetString(tt.units, 10)
+		assert.True(t, ok)
+		assert.Equal(t, tt.want, FormatUnits(units, tt.decimals), "%s with %d decimals", tt.units, tt.decimals)
+	}
+}
+
+func TestBaseUnitsRoundTrip(t *testing.T) {
+	assert.Equal(t, "2000000", ToBaseUnits(2, 6).String())
+	assert.Equal(t, AmountToWei(0.5), ToBaseUnits(0.5, DefaultDecimals))
+	assert.InDelta(t, 2.0, FromBaseUnits(ToBaseUnits(2, 6), 6), 1e-9)
+}
+
+func TestReportSigned(t *testing.T) {
+	// Without a hook, reporting is a no-op
+	ReportSigned(context.Background(), "0xabc")
+
+	var got string
+	ctx := WithSignedHook(context.Background(), func(txHash string) { got = txHash })
+	ReportSigned(ctx, "0xabc")
+	assert.Equal(t, "0xabc", got)
+}
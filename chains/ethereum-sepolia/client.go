@@ -3,30 +3,76 @@ package ethereum
 import (
 	"context"
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"math/big"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/Giri-Aayush/starknet-faucet/chains"
+	goethereum "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 // Client implements the chains.Chain interface for Ethereum.
 type Client struct {
-	client     *ethclient.Client
+	pool       *chains.EndpointPool[*ethclient.Client]
 	privateKey *ecdsa.PrivateKey
 	address    common.Address
 	config     *Config
+
+	// sendMu serializes transfers so concurrent requests never race for the same nonce
+	sendMu sync.Mutex
+	// lastNonce is the nonce of the last broadcast transfer, guarding against
+	// a lagging endpoint reporting a stale pending nonce after failover. It is
+	// dropped once the node catches up, after a failed send, or after
+	// nonceGuardWindow, so a transaction the node never kept leaves no gap.
+	lastNonce   *uint64
+	lastNonceAt time.Time
+}
+
+// nonceGuardWindow is how long lastNonce overrides the node's pending nonce
+const nonceGuardWindow = time.Minute
+
+// txRejections are the txpool errors a node returns for a transaction every
+// node would refuse, matched as substrings since nodes report them as text
+var txRejections = []string{
+	"nonce too low",
+	"nonce too high",
+	"insufficient funds",
+	"underpriced",
+	"intrinsic gas too low",
+	"exceeds block gas limit",
+	"less than block base fee",
 }
 
+// confirmationDepth is how many blocks, counting its own, must hold a
+// transaction before it is reported as confirmed
+const confirmationDepth = 3
+
 // NewClient creates a new Ethereum chain client.
 func NewClient(cfg *Config) (*Client, error) {
-	// Connect to Ethereum node
-	client, err := ethclient.Dial(cfg.RPCURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to Ethereum node: %w", err)
+	rpcURLs := cfg.RPCURLs
+	if len(rpcURLs) == 0 {
+		rpcURLs = []string{cfg.RPCURL}
+	}
+
+	// Connect to each Ethereum node (HTTP dials are lazy, so a down endpoint is not fatal here)
+	endpoints := make([]*chains.Endpoint[*ethclient.Client], 0, len(rpcURLs))
+	for _, rpcURL := range rpcURLs {
+		client, err := ethclient.Dial(rpcURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to Ethereum node %s: %w", chains.EndpointName(rpcURL), err)
+		}
+		endpoints = append(endpoints, &chains.Endpoint[*ethclient.Client]{
+			Name:   chains.EndpointName(rpcURL),
+			Client: client,
+		})
 	}
 
 	// Parse private key
@@ -51,7 +97,7 @@ func NewClient(cfg *Config) (*Client, error) {
 	}
 
 	return &Client{
-		client:     client,
+		pool:       chains.NewEndpointPool(endpoints, cfg.Failover),
 		privateKey: privateKey,
 		address:    address,
 		config:     cfg,
@@ -72,20 +118,45 @@ func (c *Client) TransferTokens(
 
 	toAddress := common.HexToAddress(recipient)
 
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+
 	// Get the nonce for the faucet account
-	nonce, err := c.client.PendingNonceAt(ctx, c.address)
+	var nonce uint64
+	err := c.pool.Do(ctx, func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		nonce, err = client.PendingNonceAt(ctx, c.address)
+		return err
+	})
 	if err != nil {
 		return "", fmt.Errorf("failed to get nonce: %w", err)
 	}
+	if c.lastNonce != nil {
+		if nonce > *c.lastNonce || time.Since(c.lastNonceAt) > nonceGuardWindow {
+			c.lastNonce = nil
+		} else {
+			nonce = *c.lastNonce + 1
+		}
+	}
 
 	// Get the latest block header to determine base fee
-	header, err := c.client.HeaderByNumber(ctx, nil)
+	var header *types.Header
+	err = c.pool.Do(ctx, func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		header, err = client.HeaderByNumber(ctx, nil)
+		return err
+	})
 	if err != nil {
 		return "", fmt.Errorf("failed to get latest block header: %w", err)
 	}
 
 	// Get suggested priority fee (tip)
-	gasTipCap, err := c.client.SuggestGasTipCap(ctx)
+	var gasTipCap *big.Int
+	err = c.pool.Do(ctx, func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		gasTipCap, err = client.SuggestGasTipCap(ctx)
+		return err
+	})
 	if err != nil {
 		return "", fmt.Errorf("failed to get gas tip cap: %w", err)
 	}
@@ -118,13 +189,32 @@ func (c *Client) TransferTokens(
 	if err != nil {
 		return "", fmt.Errorf("failed to sign transaction: %w", err)
 	}
-
-	// Send the transaction
-	err = c.client.SendTransaction(ctx, signedTx)
+	chains.ReportSigned(ctx, signedTx.Hash().Hex())
+
+	// Send the transaction. Failover re-broadcasts the same signed bytes, never a
+	// re-signed transaction, so the worst case is the same hash reaching two nodes.
+	err = c.pool.Do(ctx, func(ctx context.Context, client *ethclient.Client) error {
+		sendErr := client.SendTransaction(ctx, signedTx)
+		if sendErr == nil {
+			return nil
+		}
+		// A previous endpoint may have broadcast it before failing to respond
+		if _, _, err := client.TransactionByHash(ctx, signedTx.Hash()); err == nil {
+			return nil
+		}
+		if isTxRejection(sendErr) {
+			return chains.Rejected(sendErr)
+		}
+		return sendErr
+	})
 	if err != nil {
+		// Whether any node kept it is unknown, so trust the pending nonce next time
+		c.lastNonce = nil
 		return "", fmt.Errorf("failed to send transaction: %w", err)
 	}
 
+	c.lastNonce = &nonce
+	c.lastNonceAt = time.Now()
 	return signedTx.Hash().Hex(), nil
 }
 
@@ -136,7 +226,12 @@ func (c *Client) GetBalance(ctx context.Context, address string, token string) (
 	}
 
 	addr := common.HexToAddress(address)
-	balance, err := c.client.BalanceAt(ctx, addr, nil)
+	var balance *big.Int
+	err := c.pool.Do(ctx, func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		balance, err = client.BalanceAt(ctx, addr, nil)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get balance: %w", err)
 	}
@@ -157,8 +252,17 @@ func (c *Client) WaitForTransaction(ctx context.Context, txHash string) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-ticker.C:
-			receipt, err := c.client.TransactionReceipt(ctx, hash)
-			if err != nil {
+			var receipt *types.Receipt
+			err := c.pool.Do(ctx, func(ctx context.Context, client *ethclient.Client) error {
+				var err error
+				receipt, err = client.TransactionReceipt(ctx, hash)
+				if errors.Is(err, goethereum.NotFound) {
+					// Not yet mined is an answer, not an endpoint failure
+					return nil
+				}
+				return err
+			})
+			if err != nil || receipt == nil {
 				// Transaction not yet mined, continue waiting
 				continue
 			}
@@ -171,6 +275,46 @@ func (c *Client) WaitForTransaction(ctx context.Context, txHash string) error {
 	}
 }
 
+// GetTransactionReceipt returns the outcome of a transaction. It is confirmed
+// once confirmationDepth blocks, including its own, have been mined.
+func (c *Client) GetTransactionReceipt(ctx context.Context, txHash string) (*chains.Receipt, error) {
+	hash := common.HexToHash(txHash)
+
+	var receipt *types.Receipt
+	var head uint64
+	err := c.pool.Do(ctx, func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		receipt, err = client.TransactionReceipt(ctx, hash)
+		if errors.Is(err, goethereum.NotFound) {
+			// Not yet mined is an answer, not an endpoint failure
+			receipt = nil
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		head, err = client.BlockNumber(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipt: %w", err)
+	}
+	if receipt == nil {
+		return nil, nil
+	}
+
+	block := receipt.BlockNumber.Uint64()
+	result := &chains.Receipt{
+		BlockNumber: block,
+		Confirmed:   head+1 >= block+confirmationDepth,
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		result.Reverted = true
+		result.Reason = fmt.Sprintf("transaction failed with status %d", receipt.Status)
+	}
+	return result, nil
+}
+
 // ValidateAddress validates an Ethereum address format.
 func (c *Client) ValidateAddress(address string) error {
 	return ValidateAddress(address)
@@ -226,9 +370,32 @@ func (c *Client) GetConfig() *Config {
 	return c.config
 }
 
-// Close closes the Ethereum client connection.
+// GetEndpointStatus returns the health of each configured RPC endpoint.
+func (c *Client) GetEndpointStatus() []chains.EndpointStatus {
+	return c.pool.Status()
+}
+
+// Close closes all Ethereum client connections.
 func (c *Client) Close() {
-	c.client.Close()
+	for _, client := range c.pool.Clients() {
+		client.Close()
+	}
+}
+
+// isTxRejection reports whether err is a node refusing a transaction, as
+// opposed to the endpoint failing to answer
+func isTxRejection(err error) bool {
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+	msg := strings.ToLower(rpcErr.Error())
+	for _, reason := range txRejections {
+		if strings.Contains(msg, reason) {
+			return true
+		}
+	}
+	return false
 }
 
 // Helper function to strip 0x prefix from hex string
@@ -5,7 +5,9 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"time"
 
+	"github.com/Giri-Aayush/starknet-faucet/chains"
 	"github.com/Giri-Aayush/starknet-faucet/internal/config"
 	"github.com/joho/godotenv"
 )
@@ -16,9 +18,16 @@ type Config struct {
 	// Network is the Ethereum network (sepolia, mainnet)
 	Network string
 
-	// RPCURL is the Ethereum RPC endpoint URL (from .env)
+	// RPCURL is the primary Ethereum RPC endpoint URL (from .env)
 	RPCURL string
 
+	// RPCURLs is the ordered list of RPC endpoints, primary first (from .env).
+	// Set ETHEREUM_RPC_URL to a comma-separated list to enable failover.
+	RPCURLs []string
+
+	// Failover holds circuit breaker settings for the RPC endpoints (from local config.json)
+	Failover chains.FailoverOptions
+
 	// FaucetPrivateKey is the private key of the faucet wallet (from .env)
 	FaucetPrivateKey string
 
@@ -64,6 +73,21 @@ func getChainDir() string {
 	return "chains/ethereum-sepolia"
 }
 
+// ConfigDir returns the directory holding this chain's config.json
+func ConfigDir() string {
+	return getChainDir()
+}
+
+// WithSettings returns a copy of the config with the distribution settings of
+// a reloaded config.json: token drip amounts and limits, and balance protection.
+// The rest of config.json is only read at startup.
+func (c *Config) WithSettings(chainConfig *config.ChainConfig) *Config {
+	next := *c
+	next.Tokens = chainConfig.Tokens
+	next.MinBalanceProtectPct = chainConfig.MinBalanceProtectPct
+	return &next
+}
+
 // LoadConfig loads Ethereum configuration from local config.json and .env (secrets)
 func LoadConfig() (*Config, error) {
 	// Load .env for secrets
@@ -77,8 +101,8 @@ func LoadConfig() (*Config, error) {
 	}
 
 	// Load secrets from environment
-	rpcURL := os.Getenv("ETHEREUM_RPC_URL")
-	if rpcURL == "" {
+	rpcURLs := chains.ParseRPCURLs(os.Getenv("ETHEREUM_RPC_URL"))
+	if len(rpcURLs) == 0 {
 		return nil, fmt.Errorf("ETHEREUM_RPC_URL is required in .env")
 	}
 
@@ -119,8 +143,14 @@ func LoadConfig() (*Config, error) {
 	}
 
 	cfg := &Config{
-		Network:              network,
-		RPCURL:               rpcURL,
+		Network: network,
+		RPCURL:  rpcURLs[0],
+		RPCURLs: rpcURLs,
+		Failover: chains.FailoverOptions{
+			FailureThreshold: chainConfig.RPC.FailureThreshold,
+			Cooldown:         time.Duration(chainConfig.RPC.BreakerCooldownSec) * time.Second,
+			AttemptTimeout:   time.Duration(chainConfig.RPC.AttemptTimeoutSec) * time.Second,
+		},
 		FaucetPrivateKey:     privateKey,
 		FaucetAddress:        address,
 		ChainID:              chainID,
@@ -140,6 +170,22 @@ func (c *Config) GetDripAmount(token string) string {
 	return "0"
 }
 
+// GetTokenAddress returns the contract address for a token, empty for the native token
+func (c *Config) GetTokenAddress(token string) string {
+	if tc, ok := c.Tokens[token]; ok {
+		return tc.ContractAddress
+	}
+	return ""
+}
+
+// GetTokenDecimals returns the number of decimals of a token
+func (c *Config) GetTokenDecimals(token string) int {
+	if tc, ok := c.Tokens[token]; ok && tc.Decimals > 0 {
+		return tc.Decimals
+	}
+	return chains.DefaultDecimals
+}
+
 // GetMaxTokensPerHour returns the max hourly distribution limit for a token
 func (c *Config) GetMaxTokensPerHour(token string) float64 {
 	if tc, ok := c.Tokens[token]; ok {
@@ -156,6 +202,14 @@ func (c *Config) GetMaxTokensPerDay(token string) float64 {
 	return 0
 }
 
+// GetMaxRecipientBalance returns the balance above which a recipient is refused (0 = no cap)
+func (c *Config) GetMaxRecipientBalance(token string) float64 {
+	if tc, ok := c.Tokens[token]; ok {
+		return tc.MaxRecipientBalance
+	}
+	return 0
+}
+
 // GetMinBalanceProtectPct returns the minimum balance protection percentage
 func (c *Config) GetMinBalanceProtectPct() int {
 	return c.MinBalanceProtectPct
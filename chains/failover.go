@@ -0,0 +1,245 @@
+package chains
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default failover settings used when a chain's config.json leaves them unset.
+const (
+	DefaultFailureThreshold = 3
+	DefaultBreakerCooldown  = 30 * time.Second
+	DefaultAttemptTimeout   = 10 * time.Second
+)
+
+// ErrRejected marks an error that is the node's answer to a call, such as a
+// transaction refused for its nonce, fee or the sender's funds. Every endpoint
+// would give the same answer, so Do returns it without failing over and it
+// does not count against the endpoint's circuit breaker.
+var ErrRejected = errors.New("rejected by node")
+
+// Rejected wraps err as the node's answer rather than an endpoint failure.
+func Rejected(err error) error {
+	return fmt.Errorf("%w: %w", ErrRejected, err)
+}
+
+// healthDecay controls how quickly an endpoint's health score reacts to new results.
+// Each call moves the score 20% of the way towards 1 (success) or 0 (failure).
+const healthDecay = 0.2
+
+// FailoverOptions configures circuit breaking for an EndpointPool.
+type FailoverOptions struct {
+	// FailureThreshold is the number of consecutive failures that opens an endpoint's circuit
+	FailureThreshold int
+
+	// Cooldown is how long an open circuit stays open before the endpoint is retried
+	Cooldown time.Duration
+
+	// AttemptTimeout bounds a single call to one endpoint, so a hanging provider
+	// cannot consume the whole request deadline before failover kicks in
+	AttemptTimeout time.Duration
+}
+
+// Endpoint is a single RPC endpoint tracked by an EndpointPool.
+type Endpoint[T any] struct {
+	// Name is a log-safe label for the endpoint (host only, no path or API key)
+	Name string
+
+	// Client is the chain-specific RPC client connected to this endpoint
+	Client T
+
+	score               float64
+	consecutiveFailures int
+	openUntil           time.Time
+}
+
+// EndpointStatus is a point-in-time snapshot of an endpoint's health.
+type EndpointStatus struct {
+	Name        string  `json:"name"`
+	HealthScore float64 `json:"health_score"`
+	CircuitOpen bool    `json:"circuit_open"`
+}
+
+// EndpointPool holds an ordered list of RPC endpoints for one chain.
+// Calls go to the healthiest endpoint first and fail over to the next one on error.
+// Each endpoint has its own circuit breaker: after FailureThreshold consecutive
+// failures it is skipped for Cooldown, then given one trial call (half-open).
+type EndpointPool[T any] struct {
+	mu        sync.Mutex
+	endpoints []*Endpoint[T]
+	opts      FailoverOptions
+	now       func() time.Time
+}
+
+// NewEndpointPool creates a pool from endpoints given in priority order.
+func NewEndpointPool[T any](endpoints []*Endpoint[T], opts FailoverOptions) *EndpointPool[T] {
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = DefaultFailureThreshold
+	}
+	if opts.Cooldown <= 0 {
+		opts.Cooldown = DefaultBreakerCooldown
+	}
+	if opts.AttemptTimeout <= 0 {
+		opts.AttemptTimeout = DefaultAttemptTimeout
+	}
+
+	for _, e := range endpoints {
+		e.score = 1
+	}
+
+	return &EndpointPool[T]{
+		endpoints: endpoints,
+		opts:      opts,
+		now:       time.Now,
+	}
+}
+
+// Do runs fn against each available endpoint, best first, until one succeeds.
+// fn must be safe to repeat on another endpoint: reads are, and sends are only
+// if they re-broadcast the exact same signed transaction. An error wrapped with
+// Rejected is returned at once, since another endpoint would answer the same.
+func (p *EndpointPool[T]) Do(ctx context.Context, fn func(ctx context.Context, client T) error) error {
+	var errs []error
+
+	for _, e := range p.candidates() {
+		if ctx.Err() != nil {
+			break
+		}
+
+		attemptCtx, cancel := context.WithTimeout(ctx, p.opts.AttemptTimeout)
+		err := fn(attemptCtx, e.Client)
+		cancel()
+
+		if err == nil {
+			p.recordSuccess(e)
+			return nil
+		}
+
+		// The endpoint answered; the request itself was refused
+		if errors.Is(err, ErrRejected) {
+			p.recordSuccess(e)
+			return fmt.Errorf("%s: %w", e.Name, err)
+		}
+
+		// The caller gave up; that says nothing about the endpoint's health
+		if ctx.Err() != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", e.Name, err))
+			break
+		}
+
+		p.recordFailure(e)
+		errs = append(errs, fmt.Errorf("%s: %w", e.Name, err))
+	}
+
+	if len(errs) == 0 {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("no RPC endpoints configured")
+	}
+	return errors.Join(errs...)
+}
+
+// Clients returns every endpoint's client, in configured order.
+func (p *EndpointPool[T]) Clients() []T {
+	clients := make([]T, len(p.endpoints))
+	for i, e := range p.endpoints {
+		clients[i] = e.Client
+	}
+	return clients
+}
+
+// Status returns a health snapshot of every endpoint, in configured order.
+func (p *EndpointPool[T]) Status() []EndpointStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	status := make([]EndpointStatus, len(p.endpoints))
+	for i, e := range p.endpoints {
+		status[i] = EndpointStatus{
+			Name:        e.Name,
+			HealthScore: e.score,
+			CircuitOpen: now.Before(e.openUntil),
+		}
+	}
+	return status
+}
+
+// candidates returns the endpoints to try, in order. Endpoints with a closed (or
+// half-open) circuit come first, sorted by health score with configured order as
+// the tie-breaker. If every circuit is open, all endpoints are returned as a last
+// resort, soonest-to-recover first, rather than failing without trying.
+func (p *EndpointPool[T]) candidates() []*Endpoint[T] {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	var available []*Endpoint[T]
+	for _, e := range p.endpoints {
+		if !now.Before(e.openUntil) {
+			available = append(available, e)
+		}
+	}
+
+	if len(available) == 0 {
+		available = append(available, p.endpoints...)
+		sort.SliceStable(available, func(i, j int) bool {
+			return available[i].openUntil.Before(available[j].openUntil)
+		})
+		return available
+	}
+
+	// Compare scores at one-decimal precision so a single blip doesn't reorder endpoints
+	sort.SliceStable(available, func(i, j int) bool {
+		return int(available[i].score*10) > int(available[j].score*10)
+	})
+	return available
+}
+
+func (p *EndpointPool[T]) recordSuccess(e *Endpoint[T]) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e.score += (1 - e.score) * healthDecay
+	e.consecutiveFailures = 0
+	e.openUntil = time.Time{}
+}
+
+func (p *EndpointPool[T]) recordFailure(e *Endpoint[T]) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e.score -= e.score * healthDecay
+	e.consecutiveFailures++
+	if e.consecutiveFailures >= p.opts.FailureThreshold {
+		e.openUntil = p.now().Add(p.opts.Cooldown)
+	}
+}
+
+// EndpointName returns a log-safe label for an RPC URL. Provider URLs often
+// embed API keys in the path or query string, so only the host is kept.
+func EndpointName(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "rpc"
+	}
+	return u.Host
+}
+
+// ParseRPCURLs splits a comma-separated list of RPC URLs, dropping blanks.
+func ParseRPCURLs(value string) []string {
+	var urls []string
+	for _, u := range strings.Split(value, ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
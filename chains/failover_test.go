@@ -0,0 +1,125 @@
+package chains
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPool(names ...string) *EndpointPool[string] {
+	endpoints := make([]*Endpoint[string], len(names))
+	for i, name := range names {
+		endpoints[i] = &Endpoint[string]{Name: name, Client: name}
+	}
+	return NewEndpointPool(endpoints, FailoverOptions{FailureThreshold: 2, Cooldown: time.Minute})
+}
+
+func TestEndpointPoolFailover(t *testing.T) {
+	pool := newTestPool("primary", "backup")
+
+	var tried []string
+	err := pool.Do(context.Background(), func(ctx context.Context, client string) error {
+		tried = append(tried, client)
+		if client == "primary" {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"primary", "backup"}, tried)
+}
+
+func TestEndpointPoolCircuitOpens(t *testing.T) {
+	pool := newTestPool("primary", "backup")
+	now := time.Now()
+	pool.now = func() time.Time { return now }
+
+	// Two consecutive failures open the primary's circuit
+	pool.recordFailure(pool.endpoints[0])
+	pool.recordFailure(pool.endpoints[0])
+
+	var tried []string
+	require.NoError(t, pool.Do(context.Background(), func(ctx context.Context, client string) error {
+		tried = append(tried, client)
+		return nil
+	}))
+	assert.Equal(t, []string{"backup"}, tried)
+	assert.True(t, pool.Status()[0].CircuitOpen)
+
+	// After the cooldown the primary is tried again (half-open)
+	now = now.Add(2 * time.Minute)
+	tried = nil
+	require.NoError(t, pool.Do(context.Background(), func(ctx context.Context, client string) error {
+		tried = append(tried, client)
+		if client == "backup" {
+			return errors.New("timeout")
+		}
+		return nil
+	}))
+	assert.Equal(t, []string{"backup", "primary"}, tried)
+	assert.False(t, pool.Status()[0].CircuitOpen)
+}
+
+func TestEndpointPoolAllFail(t *testing.T) {
+	pool := newTestPool("a", "b")
+
+	err := pool.Do(context.Background(), func(ctx context.Context, client string) error {
+		return errors.New("down")
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "a: down")
+	assert.Contains(t, err.Error(), "b: down")
+}
+
+func TestEndpointPoolCallerCancelDoesNotPenalize(t *testing.T) {
+	pool := newTestPool("primary", "backup")
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var tried []string
+	err := pool.Do(ctx, func(ctx context.Context, client string) error {
+		tried = append(tried, client)
+		cancel()
+		return ctx.Err()
+	})
+
+	require.Error(t, err)
+	assert.Equal(t, []string{"primary"}, tried)
+	assert.Equal(t, 1.0, pool.Status()[0].HealthScore)
+}
+
+func TestEndpointPoolRejectionIsAnAnswer(t *testing.T) {
+	pool := newTestPool("primary", "backup")
+	insufficientFunds := errors.New("insufficient funds for gas * price + value")
+
+	// A refused transaction is returned as is, without trying the backup
+	// or counting against the primary's breaker
+	for range 3 {
+		var tried []string
+		err := pool.Do(context.Background(), func(ctx context.Context, client string) error {
+			tried = append(tried, client)
+			return Rejected(insufficientFunds)
+		})
+
+		require.ErrorIs(t, err, ErrRejected)
+		require.ErrorIs(t, err, insufficientFunds)
+		assert.Equal(t, []string{"primary"}, tried)
+	}
+	assert.False(t, pool.Status()[0].CircuitOpen)
+	assert.Equal(t, 1.0, pool.Status()[0].HealthScore)
+}
+
+func TestEndpointName(t *testing.T) {
+	assert.Equal(t, "eth-sepolia.g.alchemy.com", EndpointName("https://eth-sepolia.g.alchemy.com/v2/SECRETKEY"))
+	assert.Equal(t, "rpc", EndpointName("not a url"))
+}
+
+func TestParseRPCURLs(t *testing.T) {
+	assert.Equal(t, []string{"https://a", "https://b"}, ParseRPCURLs(" https://a, ,https://b "))
+	assert.Empty(t, ParseRPCURLs(""))
+}
@@ -0,0 +1,33 @@
+package chains
+
+import "context"
+
+// Receipt is the on-chain outcome of a transaction
+type Receipt struct {
+	// BlockNumber is the block the transaction was included in
+	BlockNumber uint64
+
+	// Confirmed is set once the block is final enough that the transfer will
+	// not be undone
+	Confirmed bool
+
+	// Reverted is set when the transaction was included but failed; Reason says why
+	Reverted bool
+	Reason   string
+}
+
+type signedHookKey struct{}
+
+// WithSignedHook returns a context under which TransferTokens calls fn with the
+// transaction hash once the transaction is signed, before it is broadcast
+func WithSignedHook(ctx context.Context, fn func(txHash string)) context.Context {
+	return context.WithValue(ctx, signedHookKey{}, fn)
+}
+
+// ReportSigned calls the hook set by WithSignedHook, if any. Chains call it
+// from TransferTokens between signing and broadcasting.
+func ReportSigned(ctx context.Context, txHash string) {
+	if fn, ok := ctx.Value(signedHookKey{}).(func(string)); ok {
+		fn(txHash)
+	}
+}
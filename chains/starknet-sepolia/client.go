@@ -2,10 +2,13 @@ package starknet
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
+	"github.com/Giri-Aayush/starknet-faucet/chains"
 	"github.com/NethermindEth/juno/core/felt"
 	"github.com/NethermindEth/starknet.go/account"
 	"github.com/NethermindEth/starknet.go/rpc"
@@ -14,21 +17,46 @@ import (
 
 // Client implements the chains.Chain interface for Starknet.
 type Client struct {
-	account     *account.Account
-	provider    *rpc.Provider
-	config      *Config
-	tokenAddrs  map[string]*felt.Felt
+	account    *account.Account
+	pool       *chains.EndpointPool[*rpc.Provider]
+	config     *Config
+	tokenAddrs map[string]*felt.Felt
+
+	// sendMu serializes transfers so concurrent requests never race for the same nonce
+	sendMu sync.Mutex
 }
 
+// feeMultiplier is the safety margin applied to estimated resource bounds
+const feeMultiplier = 1.5
+
 // NewClient creates a new Starknet chain client.
 func NewClient(cfg *Config) (*Client, error) {
 	ctx := context.Background()
 
-	// Initialize RPC provider
-	provider, err := rpc.NewProvider(ctx, cfg.RPCURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create provider: %w", err)
+	rpcURLs := cfg.RPCURLs
+	if len(rpcURLs) == 0 {
+		rpcURLs = []string{cfg.RPCURL}
+	}
+
+	// Initialize RPC providers. NewProvider performs a version check over the
+	// network, so endpoints that are unreachable at startup are left out.
+	var endpoints []*chains.Endpoint[*rpc.Provider]
+	var providerErrs []error
+	for _, rpcURL := range rpcURLs {
+		provider, err := rpc.NewProvider(ctx, rpcURL)
+		if err != nil {
+			providerErrs = append(providerErrs, fmt.Errorf("%s: %w", chains.EndpointName(rpcURL), err))
+			continue
+		}
+		endpoints = append(endpoints, &chains.Endpoint[*rpc.Provider]{
+			Name:   chains.EndpointName(rpcURL),
+			Client: provider,
+		})
+	}
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("failed to create provider: %w", errors.Join(providerErrs...))
 	}
+	provider := endpoints[0].Client
 
 	// Parse private key
 	privKeyBI, ok := new(big.Int).SetString(cfg.FaucetPrivateKey, 0)
@@ -46,7 +74,8 @@ func NewClient(cfg *Config) (*Client, error) {
 		return nil, fmt.Errorf("invalid account address: %w", err)
 	}
 
-	// Create account (Cairo 2 - latest version)
+	// Create account (Cairo 2 - latest version). The account is only used for
+	// calldata formatting and signing; all RPC calls go through the endpoint pool.
 	accnt, err := account.NewAccount(provider, accAddress, cfg.FaucetAddress, ks, 2)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create account: %w", err)
@@ -75,7 +104,7 @@ func NewClient(cfg *Config) (*Client, error) {
 
 	return &Client{
 		account:    accnt,
-		provider:   provider,
+		pool:       chains.NewEndpointPool(endpoints, cfg.Failover),
 		config:     cfg,
 		tokenAddrs: tokenAddrs,
 	}, nil
@@ -118,13 +147,140 @@ func (c *Client) TransferTokens(
 		},
 	}
 
-	// Build and send invoke transaction
-	tx, err := c.account.BuildAndSendInvokeTxn(ctx, []rpc.InvokeFunctionCall{call}, nil)
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+
+	// Build and sign once, then broadcast
+	tx, err := c.buildInvokeTxn(ctx, []rpc.InvokeFunctionCall{call})
 	if err != nil {
 		return "", fmt.Errorf("transaction failed: %w", err)
 	}
 
-	return tx.Hash.String(), nil
+	txHash, err := c.account.TransactionHashInvoke(tx)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute transaction hash: %w", err)
+	}
+	chains.ReportSigned(ctx, txHash.String())
+
+	// Failover re-broadcasts the same signed transaction, never a rebuilt one,
+	// so a retry can't produce a second transfer under a different nonce.
+	err = c.pool.Do(ctx, func(ctx context.Context, provider *rpc.Provider) error {
+		_, err := provider.AddInvokeTransaction(ctx, tx)
+		if isDuplicateTx(err) {
+			// A previous endpoint accepted it before failing to respond
+			return nil
+		}
+		if isTxRejection(err) {
+			return chains.Rejected(err)
+		}
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("transaction failed: %w", err)
+	}
+
+	return txHash.String(), nil
+}
+
+// buildInvokeTxn builds and signs a v3 invoke transaction, mirroring
+// account.BuildAndSendInvokeTxn but reading nonce, tip and fee estimates
+// through the endpoint pool. Nothing is broadcast here, so failover is safe.
+func (c *Client) buildInvokeTxn(ctx context.Context, calls []rpc.InvokeFunctionCall) (*rpc.BroadcastInvokeTxnV3, error) {
+	var nonce *felt.Felt
+	err := c.pool.Do(ctx, func(ctx context.Context, provider *rpc.Provider) error {
+		var err error
+		nonce, err = provider.Nonce(ctx, rpc.WithBlockTag(rpc.BlockTagPreConfirmed), c.account.Address)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %w", err)
+	}
+
+	callData, err := c.account.FmtCalldata(utils.InvokeFuncCallsToFunctionCalls(calls))
+	if err != nil {
+		return nil, err
+	}
+
+	var tip rpc.U64
+	err = c.pool.Do(ctx, func(ctx context.Context, provider *rpc.Provider) error {
+		var err error
+		tip, err = rpc.EstimateTip(ctx, provider, 1.0)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate tip: %w", err)
+	}
+
+	// The transaction must be signed before its fee can be estimated
+	tx := utils.BuildInvokeTxn(c.account.Address, nonce, callData, zeroResourceBounds(), &utils.TxnOptions{Tip: tip})
+	if err := c.account.SignInvokeTransaction(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	var estimate []rpc.FeeEstimation
+	err = c.pool.Do(ctx, func(ctx context.Context, provider *rpc.Provider) error {
+		var err error
+		estimate, err = provider.EstimateFee(ctx, []rpc.BroadcastTxn{tx}, []rpc.SimulationFlag{}, rpc.WithBlockTag(rpc.BlockTagPreConfirmed))
+		if isRPCError(err, rpc.ErrTxnExec) {
+			// The transfer itself fails, e.g. the faucet is out of funds
+			return chains.Rejected(err)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate fee: %w", err)
+	}
+	if len(estimate) == 0 {
+		return nil, fmt.Errorf("empty fee estimate")
+	}
+
+	// Re-sign with the final resource bounds, which are part of the hash
+	tx.ResourceBounds = utils.FeeEstToResBoundsMap(estimate[0], feeMultiplier)
+	tx.Version = rpc.TransactionV3
+	if err := c.account.SignInvokeTransaction(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// zeroResourceBounds returns empty resource bounds used while estimating fees
+func zeroResourceBounds() *rpc.ResourceBoundsMapping {
+	zero := rpc.ResourceBounds{MaxAmount: "0x0", MaxPricePerUnit: "0x0"}
+	return &rpc.ResourceBoundsMapping{
+		L1Gas:     zero,
+		L1DataGas: zero,
+		L2Gas:     zero,
+	}
+}
+
+// isDuplicateTx reports whether the node rejected a transaction because it already has it
+func isDuplicateTx(err error) bool {
+	return isRPCError(err, rpc.ErrDuplicateTx)
+}
+
+// isTxRejection reports whether err is the node refusing a transaction, as
+// opposed to the endpoint failing to answer
+func isTxRejection(err error) bool {
+	for _, target := range []*rpc.RPCError{
+		rpc.ErrInvalidTransactionNonce,
+		rpc.ErrInsufficientAccountBalance,
+		rpc.ErrInsufficientResourcesForValidate,
+		rpc.ErrValidationFailure,
+		rpc.ErrFeeBelowMinimum,
+		rpc.ErrReplacementTransactionUnderpriced,
+	} {
+		if isRPCError(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRPCError reports whether err is the given Starknet RPC error
+func isRPCError(err error, target *rpc.RPCError) bool {
+	var rpcErr *rpc.RPCError
+	return errors.As(err, &rpcErr) && rpcErr.Code == target.Code
 }
 
 // GetBalance gets the token balance of an address.
@@ -144,11 +300,16 @@ func (c *Client) GetBalance(ctx context.Context, address string, token string) (
 	// Call balanceOf
 	balanceSelector := utils.GetSelectorFromNameFelt("balanceOf")
 
-	result, err := c.provider.Call(ctx, rpc.FunctionCall{
-		ContractAddress:    tokenAddress,
-		EntryPointSelector: balanceSelector,
-		Calldata:           []*felt.Felt{addrFelt},
-	}, rpc.BlockID{Tag: "latest"})
+	var result []*felt.Felt
+	err = c.pool.Do(ctx, func(ctx context.Context, provider *rpc.Provider) error {
+		var err error
+		result, err = provider.Call(ctx, rpc.FunctionCall{
+			ContractAddress:    tokenAddress,
+			EntryPointSelector: balanceSelector,
+			Calldata:           []*felt.Felt{addrFelt},
+		}, rpc.BlockID{Tag: "latest"})
+		return err
+	})
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to get balance: %w", err)
@@ -187,7 +348,17 @@ func (c *Client) WaitForTransaction(ctx context.Context, txHash string) error {
 			return ctx.Err()
 		case <-ticker.C:
 			// Check transaction receipt
-			receipt, err := c.provider.TransactionReceipt(ctx, txHashFelt)
+			var receipt *rpc.TransactionReceiptWithBlockInfo
+			err := c.pool.Do(ctx, func(ctx context.Context, provider *rpc.Provider) error {
+				var err error
+				receipt, err = provider.TransactionReceipt(ctx, txHashFelt)
+				if isRPCError(err, rpc.ErrHashNotFound) {
+					// Not yet accepted is an answer, not an endpoint failure
+					receipt = nil
+					return nil
+				}
+				return err
+			})
 			if err != nil {
 				continue
 			}
@@ -200,6 +371,43 @@ func (c *Client) WaitForTransaction(ctx context.Context, txHash string) error {
 	}
 }
 
+// GetTransactionReceipt returns the outcome of a transaction. It is confirmed
+// once its block is accepted on L2; pre-confirmed blocks may still change.
+func (c *Client) GetTransactionReceipt(ctx context.Context, txHash string) (*chains.Receipt, error) {
+	txHashFelt, err := utils.HexToFelt(txHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tx hash: %w", err)
+	}
+
+	var receipt *rpc.TransactionReceiptWithBlockInfo
+	err = c.pool.Do(ctx, func(ctx context.Context, provider *rpc.Provider) error {
+		var err error
+		receipt, err = provider.TransactionReceipt(ctx, txHashFelt)
+		if isRPCError(err, rpc.ErrHashNotFound) {
+			// Not yet accepted is an answer, not an endpoint failure
+			receipt = nil
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipt: %w", err)
+	}
+	if receipt == nil {
+		return nil, nil
+	}
+
+	result := &chains.Receipt{
+		BlockNumber: uint64(receipt.BlockNumber),
+		Confirmed:   receipt.FinalityStatus != rpc.TxnFinalityStatusPreConfirmed,
+	}
+	if receipt.ExecutionStatus == rpc.TxnExecutionStatusREVERTED {
+		result.Reverted = true
+		result.Reason = receipt.RevertReason
+	}
+	return result, nil
+}
+
 // ValidateAddress validates a Starknet address format.
 func (c *Client) ValidateAddress(address string) error {
 	return ValidateAddress(address)
@@ -242,7 +450,46 @@ func (c *Client) GetNetworkName() string {
 	return c.config.Network
 }
 
+// GetEndpointStatus returns the health of each configured RPC endpoint.
+func (c *Client) GetEndpointStatus() []chains.EndpointStatus {
+	return c.pool.Status()
+}
+
 // GetConfig returns the chain configuration.
 func (c *Client) GetConfig() *Config {
 	return c.config
 }
+
+// IsValidSignature asks the account contract at address whether it accepts
+// signature over hash, through its SRC-6 is_valid_signature entry point, and
+// returns the raw result. A missing or reverting account is returned as an
+// error wrapping chains.ErrRejected.
+func (c *Client) IsValidSignature(ctx context.Context, address string, hash *felt.Felt, signature []*felt.Felt) ([]*felt.Felt, error) {
+	addrFelt, err := utils.HexToFelt(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %w", err)
+	}
+
+	calldata := []*felt.Felt{hash, new(felt.Felt).SetUint64(uint64(len(signature)))}
+	calldata = append(calldata, signature...)
+
+	var result []*felt.Felt
+	err = c.pool.Do(ctx, func(ctx context.Context, provider *rpc.Provider) error {
+		var err error
+		result, err = provider.Call(ctx, rpc.FunctionCall{
+			ContractAddress:    addrFelt,
+			EntryPointSelector: utils.GetSelectorFromNameFelt("is_valid_signature"),
+			Calldata:           calldata,
+		}, rpc.BlockID{Tag: "latest"})
+		// A missing or reverting account is an answer, not an endpoint failure
+		if isRPCError(err, rpc.ErrContractNotFound) || isRPCError(err, rpc.ErrEntrypointNotFound) || isRPCError(err, rpc.ErrContractError) {
+			return chains.Rejected(err)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call is_valid_signature: %w", err)
+	}
+
+	return result, nil
+}
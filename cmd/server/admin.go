@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/apikey"
+	"github.com/Giri-Aayush/starknet-faucet/internal/config"
+	"github.com/Giri-Aayush/starknet-faucet/internal/identity"
+	"github.com/Giri-Aayush/starknet-faucet/internal/testtoken"
+)
+
+// runIssueTestToken implements `server issue-test-token`, which prints a signed
+// test token that lets automated tests skip PoW on one network for a short time.
+// It must run with the same FAUCET_TEST_TOKEN_SECRET as the server.
+func runIssueTestToken(args []string) int {
+	fs := flag.NewFlagSet("issue-test-token", flag.ContinueOnError)
+	network := fs.String("network", "", "network the token is valid for (starknet, ethereum)")
+	ttl := fs.Duration("ttl", 15*time.Minute, fmt.Sprintf("token lifetime (max %s)", testtoken.MaxTTL))
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		return 1
+	}
+	if cfg.TestTokenSecret == "" {
+		fmt.Fprintln(os.Stderr, "FAUCET_TEST_TOKEN_SECRET is not set; test tokens are disabled")
+		return 1
+	}
+
+	token, claims, err := testtoken.Issue([]byte(cfg.TestTokenSecret), *network, *ttl)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to issue test token: %v\n", err)
+		return 1
+	}
+
+	fmt.Fprintf(os.Stderr, "Issued test token %s for %s, expires %s\n",
+		claims.ID, claims.Network, time.Unix(claims.ExpiresAt, 0).UTC().Format(time.RFC3339))
+	fmt.Println(token)
+	return 0
+}
+
+// runIssueAPIKey implements `server issue-api-key`, which generates an API key.
+// The key is printed once for its holder; the api_keys entry printed alongside
+// holds only its hash and goes in the server's config.
+func runIssueAPIKey(args []string) int {
+	fs := flag.NewFlagSet("issue-api-key", flag.ContinueOnError)
+	name := fs.String("name", "", "name of the key's holder, such as a team")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *name == "" {
+		fmt.Fprintln(os.Stderr, "-name is required")
+		return 2
+	}
+
+	key, hash, err := apikey.Generate()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to generate API key: %v\n", err)
+		return 1
+	}
+
+	entry, _ := json.MarshalIndent(config.APIKeyConfig{
+		Name:         *name,
+		KeySHA256:    hash,
+		DailyBudget:  map[string]map[string]float64{},
+		MaxBatchSize: 100,
+	}, "", "  ")
+	fmt.Fprintf(os.Stderr, "Add this entry to api_keys in the config and set its daily_budget:\n%s\n", entry)
+	fmt.Println(key)
+	return 0
+}
+
+// runMockGitHub implements `server mock-github`, which serves a local stand-in
+// for GitHub's device flow that approves every sign-in as an established
+// account. Point github.oauth_url and github.api_url at it for local testing.
+func runMockGitHub(args []string) int {
+	fs := flag.NewFlagSet("mock-github", flag.ContinueOnError)
+	addr := fs.String("addr", "127.0.0.1:9090", "address to listen on")
+	login := fs.String("login", "octocat", "login of the approved account")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	mock := identity.NewMockGitHub()
+	mock.AutoApprove(&identity.User{
+		ID:          583231,
+		Login:       *login,
+		CreatedAt:   time.Now().AddDate(-5, 0, 0),
+		PublicRepos: 10,
+		Followers:   10,
+	})
+
+	fmt.Fprintf(os.Stderr, "Mock GitHub listening on http://%s, approving sign-ins as %s\n", *addr, *login)
+	if err := http.ListenAndServe(*addr, mock); err != nil {
+		fmt.Fprintf(os.Stderr, "Mock GitHub failed: %v\n", err)
+		return 1
+	}
+	return 0
+}
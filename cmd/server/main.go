@@ -1,11 +1,14 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/Giri-Aayush/starknet-faucet/chains"
@@ -13,13 +16,30 @@ import (
 	starknet "github.com/Giri-Aayush/starknet-faucet/chains/starknet-sepolia"
 	"github.com/Giri-Aayush/starknet-faucet/internal/api"
 	"github.com/Giri-Aayush/starknet-faucet/internal/cache"
+	"github.com/Giri-Aayush/starknet-faucet/internal/clientip"
 	"github.com/Giri-Aayush/starknet-faucet/internal/config"
+	"github.com/Giri-Aayush/starknet-faucet/internal/human"
+	"github.com/Giri-Aayush/starknet-faucet/internal/identity"
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/Giri-Aayush/starknet-faucet/internal/ownership"
 	"github.com/Giri-Aayush/starknet-faucet/internal/pow"
 	"github.com/Giri-Aayush/starknet-faucet/pkg/utils"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 )
 
 func main() {
+	// Admin subcommands
+	if len(os.Args) > 1 && os.Args[1] == "issue-test-token" {
+		os.Exit(runIssueTestToken(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "issue-api-key" {
+		os.Exit(runIssueAPIKey(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "mock-github" {
+		os.Exit(runMockGitHub(os.Args[2:]))
+	}
+
 	// Load common configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -91,7 +111,6 @@ func main() {
 	logger.Info("Connecting to Redis...")
 	redis, err := cache.NewRedisClient(
 		cfg.RedisURL,
-		cfg.MaxRequestsPerDayIP(),
 		cfg.MaxChallengesPerHour(),
 	)
 	if err != nil {
@@ -105,26 +124,124 @@ func main() {
 
 	// Initialize PoW generator
 	powGenerator := pow.NewGenerator(cfg.PoWDifficulty(), cfg.ChallengeTTL())
+	if err := powGenerator.SetDifficultyFormat(cfg.PoWDifficultyFormat()); err != nil {
+		logger.Fatal("Invalid PoW difficulty format", zap.Error(err))
+	}
+	if err := powGenerator.SetDifficulty(cfg.PoWDifficulty(), adaptivePoW(cfg)); err != nil {
+		logger.Fatal("Invalid PoW difficulty", zap.Error(err))
+	}
+	var powParams models.PoWParams
+	switch cfg.PoWAlgorithm() {
+	case pow.AlgorithmArgon2id:
+		powParams = models.PoWParams{Time: cfg.PoW.Argon2.Time, MemoryKiB: cfg.PoW.Argon2.MemoryKiB, Threads: cfg.PoW.Argon2.Threads}
+	case pow.AlgorithmScrypt:
+		powParams = models.PoWParams{N: cfg.PoW.Scrypt.N, R: cfg.PoW.Scrypt.R, P: cfg.PoW.Scrypt.P}
+	}
+	if err := powGenerator.SetAlgorithm(cfg.PoWAlgorithm(), powParams); err != nil {
+		logger.Fatal("Invalid PoW algorithm configuration", zap.Error(err))
+	}
+	minDifficulty, maxDifficulty := powGenerator.DifficultyRange()
+	if cfg.PoW.Stateless {
+		if err := powGenerator.EnableStateless([]byte(cfg.ChallengeSecret)); err != nil {
+			logger.Fatal("Invalid stateless challenge configuration", zap.Error(err))
+		}
+	}
+	if cfg.TestTokenSecret != "" {
+		logger.Info("Signed test tokens enabled")
+	}
 	logger.Info("PoW generator initialized",
+		zap.String("algorithm", powGenerator.Algorithm()),
+		zap.String("difficulty_format", powGenerator.DifficultyFormat()),
+		zap.Bool("stateless", powGenerator.Stateless()),
 		zap.Int("difficulty", cfg.PoWDifficulty()),
+		zap.Int("min_difficulty", minDifficulty),
+		zap.Int("max_difficulty", maxDifficulty),
 	)
 
 	// Create API handler with chain registries
 	handler := api.NewMultiChainHandler(cfg, logger, redis, chainRegistry, providerRegistry, powGenerator)
 
+	ipResolver, err := clientip.New(cfg.Server.TrustedProxies, cfg.Server.ClientIPHeader)
+	if err != nil {
+		logger.Fatal("Invalid client IP configuration", zap.Error(err))
+	}
+	handler.SetClientIPResolver(ipResolver)
+	if len(cfg.Server.TrustedProxies) > 0 {
+		logger.Info("Resolving client IPs behind trusted proxies",
+			zap.Strings("trusted_proxies", cfg.Server.TrustedProxies),
+			zap.String("header", ipResolver.Header()),
+		)
+	}
+
+	// Ownership proofs: EVM signers are recovered locally, Starknet accounts check their own signatures
+	if _, ok := chainRegistry["ethereum"]; ok {
+		handler.SetOwnershipVerifier("ethereum", ownership.NewEVMVerifier("ethereum"))
+	}
+	if checker, ok := chainRegistry["starknet"].(ownership.AccountChecker); ok {
+		handler.SetOwnershipVerifier("starknet", ownership.NewStarknetVerifier(checker, ownership.StarknetChainID(starknetCfg.Network)))
+	}
+	if len(cfg.OwnershipProof.Networks) > 0 {
+		logger.Info("Ownership proofs required", zap.Strings("networks", cfg.OwnershipProof.Networks))
+	}
+
+	if hv := cfg.HumanVerification; hv.Provider != "" {
+		verifier, err := human.New(hv.Provider, cfg.HumanVerificationSecret, hv.SiteKey)
+		if err != nil {
+			logger.Fatal("Invalid human verification configuration", zap.Error(err))
+		}
+		handler.SetHumanVerifier(verifier)
+		logger.Info("Human verification enabled",
+			zap.String("provider", verifier.Provider()),
+			zap.Strings("networks", hv.Networks),
+			zap.Strings("tiers", hv.Tiers),
+		)
+	}
+
+	if cfg.GitHub.Enabled {
+		sessions, err := identity.NewSessionSigner([]byte(cfg.SessionSecret), cfg.SessionTTL())
+		if err != nil {
+			logger.Fatal("Invalid session configuration", zap.Error(err))
+		}
+		github := identity.NewGitHubClient(cfg.GitHub.ClientID, cfg.GitHub.OAuthURL, cfg.GitHub.APIURL)
+		handler.EnableGitHubSignIn(github, sessions)
+		logger.Info("GitHub sign-in enabled",
+			zap.Int("max_requests_per_day", cfg.GitHub.MaxRequestsPerDay),
+			zap.Duration("session_ttl", cfg.SessionTTL()),
+		)
+	}
+
+	// Keep faucet balances warm so /info and balance protection rarely hit RPC
+	refreshCtx, stopRefresh := context.WithCancel(context.Background())
+	defer stopRefresh()
+	handler.StartBalanceRefresher(refreshCtx, cfg.BalanceRefreshInterval())
+
+	// Apply config changes without a restart, on file changes and SIGHUP
+	reloader := &configReloader{logger: logger, handler: handler, powGenerator: powGenerator, cfg: cfg}
+	if _, ok := chainRegistry["starknet"]; ok {
+		if err := reloader.addChain("starknet", starknet.ConfigDir(), func(file *config.ChainConfig) api.ChainProvider {
+			return starknetCfg.WithSettings(file)
+		}); err != nil {
+			logger.Warn("Starknet config will not be reloaded", zap.Error(err))
+		}
+	}
+	if _, ok := chainRegistry["ethereum"]; ok {
+		if err := reloader.addChain("ethereum", ethereum.ConfigDir(), func(file *config.ChainConfig) api.ChainProvider {
+			return ethereumCfg.WithSettings(file)
+		}); err != nil {
+			logger.Warn("Ethereum config will not be reloaded", zap.Error(err))
+		}
+	}
+	reloader.run(refreshCtx)
+
+	// Recurring drips for API key holders; keys may be added by a reload
+	handler.StartSubscriptionScheduler(refreshCtx, cfg.SubscriptionCheckInterval())
+	logger.Info("Subscription scheduler started", zap.Duration("check_interval", cfg.SubscriptionCheckInterval()))
+
 	// Create Fiber app
 	app := fiber.New(fiber.Config{
 		AppName:               "Multi-Chain Faucet API",
 		DisableStartupMessage: false,
-		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			code := fiber.StatusInternalServerError
-			if e, ok := err.(*fiber.Error); ok {
-				code = e.Code
-			}
-			return c.Status(code).JSON(fiber.Map{
-				"error": err.Error(),
-			})
-		},
+		ErrorHandler:          api.ErrorHandler,
 	})
 
 	// Setup routes
@@ -139,15 +256,61 @@ func main() {
 		}
 	}()
 
+	// gRPC API on its own port, served by the same handlers and limiters
+	grpcServer := api.NewGRPCServer(handler, app)
+	if port := cfg.GRPCPort(); port != "" {
+		go func() {
+			addr := fmt.Sprintf(":%s", port)
+			listener, err := net.Listen("tcp", addr)
+			if err != nil {
+				logger.Fatal("gRPC server failed to start", zap.Error(err))
+			}
+			logger.Info("gRPC server starting", zap.String("addr", addr))
+			if err := grpcServer.Serve(listener); err != nil {
+				logger.Fatal("gRPC server failed", zap.Error(err))
+			}
+		}()
+	}
+
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 	<-quit
 
-	logger.Info("Shutting down server...")
-	if err := app.Shutdown(); err != nil {
+	// Stop background work first, so no drip starts while transfers drain, and
+	// end event streams, which would otherwise hold the HTTP shutdown open
+	stopRefresh()
+	handler.CloseEventStreams()
+
+	// Stop accepting new requests, then give in-flight transfers time to finish
+	// so that nothing is broadcast without its quota being recorded
+	shutdownTimeout := cfg.ShutdownTimeout()
+	deadline := time.Now().Add(shutdownTimeout)
+	logger.Info("Shutting down server...", zap.Duration("drain_timeout", shutdownTimeout))
+	if err := app.ShutdownWithTimeout(shutdownTimeout); err != nil {
 		logger.Error("Server shutdown error", zap.Error(err))
 	}
+	stopGRPC(grpcServer, time.Until(deadline))
+
+	if !handler.WaitForTransfers(time.Until(deadline)) {
+		logger.Warn("Timed out waiting for in-flight transfers to finish")
+	}
 
 	logger.Info("Server stopped")
 }
+
+// stopGRPC stops the gRPC server, letting in-flight calls finish until the
+// timeout and then cancelling those that remain, such as long FollowRequest streams
+func stopGRPC(server *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		server.Stop()
+	}
+}
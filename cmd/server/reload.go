@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/api"
+	"github.com/Giri-Aayush/starknet-faucet/internal/config"
+	"github.com/Giri-Aayush/starknet-faucet/internal/pow"
+	"go.uber.org/zap"
+)
+
+// reloadableChain is a chain whose config.json is watched for changes
+type reloadableChain struct {
+	network string
+	dir     string
+	current *config.ChainConfig
+
+	// provider returns the chain's provider with the settings of a reloaded config.json
+	provider func(*config.ChainConfig) api.ChainProvider
+}
+
+// configReloader applies changes to the global and chain configuration
+// while the server runs, when the files change or on SIGHUP. A reload is
+// applied completely or not at all.
+type configReloader struct {
+	logger       *zap.Logger
+	handler      *api.Handler
+	powGenerator *pow.Generator
+
+	mu     sync.Mutex
+	cfg    *config.Config
+	chains []*reloadableChain
+}
+
+// addChain watches a chain's config.json. Its current settings are read now,
+// to compare reloads against.
+func (r *configReloader) addChain(network, dir string, provider func(*config.ChainConfig) api.ChainProvider) error {
+	current, err := config.LoadChainConfig(dir)
+	if err != nil {
+		return err
+	}
+	r.chains = append(r.chains, &reloadableChain{network: network, dir: dir, current: current, provider: provider})
+	return nil
+}
+
+// run reloads on SIGHUP and whenever a watched file changes, until ctx is cancelled
+func (r *configReloader) run(ctx context.Context) {
+	files := []string{r.cfg.Path()}
+	if _, err := os.Stat(".env"); err == nil {
+		files = append(files, ".env")
+	}
+	for _, chain := range r.chains {
+		files = append(files, filepath.Join(chain.dir, "config.json"))
+	}
+	if err := config.Watch(ctx, files, func() { r.reload("file change") }); err != nil {
+		r.logger.Warn("Config files not watched; reload with SIGHUP", zap.Error(err))
+	} else {
+		r.logger.Info("Watching config files", zap.Strings("files", files))
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(hup)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hup:
+				r.reload("SIGHUP")
+			}
+		}
+	}()
+}
+
+// reload reads every config file again and, if all of them are valid and
+// only change settings that apply live, swaps them in
+func (r *configReloader) reload(trigger string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	logger := r.logger.With(zap.String("trigger", trigger))
+
+	next, err := r.cfg.Reload()
+	if err != nil {
+		logger.Error("Config reload rejected", zap.Error(err))
+		return
+	}
+	changes, err := config.CheckReload(r.cfg, next)
+	if err != nil {
+		logger.Error("Config reload rejected", zap.Error(err))
+		return
+	}
+
+	// Chains whose config is not watched keep the provider they started with
+	providers := r.handler.Providers()
+	reloaded := make([]*config.ChainConfig, len(r.chains))
+	for i, chain := range r.chains {
+		file, err := config.LoadChainConfig(chain.dir)
+		if err != nil {
+			logger.Error("Config reload rejected", zap.Error(err))
+			return
+		}
+		chainChanges, err := config.CheckChainReload(filepath.Join(chain.dir, "config.json"), chain.current, file)
+		if err != nil {
+			logger.Error("Config reload rejected", zap.Error(err))
+			return
+		}
+		for _, change := range chainChanges {
+			changes = append(changes, chain.network+" "+change)
+		}
+		providers[chain.network] = chain.provider(file)
+		reloaded[i] = file
+	}
+
+	if len(changes) == 0 {
+		logger.Info("Config reloaded with no changes")
+		return
+	}
+
+	if err := r.powGenerator.SetDifficulty(next.PoWDifficulty(), adaptivePoW(next)); err != nil {
+		logger.Error("Config reload rejected", zap.Error(err))
+		return
+	}
+	r.handler.ApplyConfig(next, providers)
+	r.cfg = next
+	for i, chain := range r.chains {
+		chain.current = reloaded[i]
+	}
+
+	logger.Info("Config reloaded", zap.Strings("changes", changes))
+}
+
+// adaptivePoW returns the adaptive difficulty settings, or nil when adaptive difficulty is off
+func adaptivePoW(cfg *config.Config) *pow.AdaptiveConfig {
+	adaptive := cfg.AdaptivePoW()
+	if !adaptive.Enabled {
+		return nil
+	}
+	return &pow.AdaptiveConfig{
+		MinDifficulty:     adaptive.MinDifficulty,
+		MaxDifficulty:     adaptive.MaxDifficulty,
+		HighLoadPerMinute: adaptive.HighLoadPerMinute,
+		LowLoadPerMinute:  adaptive.LowLoadPerMinute,
+		PenaltyStep:       adaptive.PenaltyStep,
+	}
+}
@@ -0,0 +1,164 @@
+package api
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/identity"
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// tierGitHub is the tier of clients signed in with GitHub
+const tierGitHub = "github"
+
+// errSignInDisabled is returned when a session is sent but sign-in is not enabled
+var errSignInDisabled = errors.New("sign-in is not enabled on this faucet")
+
+// EnableGitHubSignIn turns on GitHub device flow sign-in and per-account quotas
+func (h *Handler) EnableGitHubSignIn(client *identity.GitHubClient, sessions *identity.SessionSigner) {
+	h.github = client
+	h.sessions = sessions
+}
+
+// StartGitHubLogin begins a GitHub device flow sign-in
+func (h *Handler) StartGitHubLogin(c *fiber.Ctx) error {
+	if h.github == nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeSignInDisabled,
+			Error: "GitHub sign-in is not enabled on this faucet",
+		})
+	}
+
+	code, err := h.github.StartDeviceFlow(c.UserContext())
+	if err != nil {
+		h.logger.Error("Failed to start GitHub device flow", zap.Error(err))
+		return c.Status(fiber.StatusBadGateway).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeUpstreamFailure,
+			Error: "Failed to start GitHub sign-in",
+		})
+	}
+
+	return c.JSON(models.DeviceLoginResponse{
+		DeviceCode:      code.DeviceCode,
+		UserCode:        code.UserCode,
+		VerificationURI: code.VerificationURI,
+		ExpiresIn:       code.ExpiresIn,
+		Interval:        code.Interval,
+	})
+}
+
+// CompleteGitHubLogin polls a device flow sign-in. Once the user has
+// authorized, it checks the account's eligibility and issues a session token.
+func (h *Handler) CompleteGitHubLogin(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	if h.github == nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeSignInDisabled,
+			Error: "GitHub sign-in is not enabled on this faucet",
+		})
+	}
+
+	var req models.DeviceTokenRequest
+	if err := c.BodyParser(&req); err != nil || req.DeviceCode == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeInvalidRequest,
+			Error: "Invalid request body: device_code is required",
+		})
+	}
+
+	accessToken, err := h.github.PollToken(ctx, req.DeviceCode)
+	switch {
+	case errors.Is(err, identity.ErrAuthorizationPending):
+		return c.Status(fiber.StatusAccepted).JSON(models.LoginResponse{Status: "pending"})
+	case errors.Is(err, identity.ErrSlowDown):
+		return c.Status(fiber.StatusAccepted).JSON(models.LoginResponse{Status: "pending", Interval: 10})
+	case errors.Is(err, identity.ErrDeviceCodeExpired):
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeSignInExpired,
+			Error: "Sign-in code expired. Start again.",
+		})
+	case errors.Is(err, identity.ErrAccessDenied):
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeSignInDenied,
+			Error: "GitHub authorization was denied",
+		})
+	case err != nil:
+		h.logger.Error("Failed to poll GitHub device flow", zap.Error(err))
+		return c.Status(fiber.StatusBadGateway).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeUpstreamFailure,
+			Error: "Failed to complete GitHub sign-in",
+		})
+	}
+
+	user, err := h.github.GetUser(ctx, accessToken)
+	if err != nil {
+		h.logger.Error("Failed to fetch GitHub user", zap.Error(err))
+		return c.Status(fiber.StatusBadGateway).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeUpstreamFailure,
+			Error: "Failed to complete GitHub sign-in",
+		})
+	}
+
+	if err := h.config().GitHubEligibility().Check(user, time.Now()); err != nil {
+		h.logger.Info("GitHub account not eligible",
+			zap.String("login", user.Login),
+			zap.Error(err),
+		)
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeNotEligible,
+			Error: err.Error(),
+		})
+	}
+
+	token, session, err := h.sessions.Issue(user, time.Now())
+	if err != nil {
+		h.logger.Error("Failed to issue session", zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeUpstreamFailure,
+			Error: "Failed to complete GitHub sign-in",
+		})
+	}
+
+	h.logger.Info("GitHub sign-in", zap.String("login", user.Login), zap.Int64("account_id", user.ID))
+
+	return c.JSON(models.LoginResponse{
+		Status:       "complete",
+		SessionToken: token,
+		Login:        session.Login,
+		ExpiresAt:    session.ExpiresAt,
+	})
+}
+
+// session returns the signed-in session sent with a request, or nil for
+// anonymous requests. A session that is sent but invalid is an error, so an
+// expired sign-in is reported rather than silently falling back to IP quotas.
+func (h *Handler) session(c *fiber.Ctx) (*identity.Session, error) {
+	header := c.Get(fiber.HeaderAuthorization)
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || token == "" {
+		return nil, nil
+	}
+	if h.sessions == nil {
+		return nil, errSignInDisabled
+	}
+	return h.sessions.Verify(token, time.Now())
+}
+
+// sessionError responds to a request carrying an unusable session token
+func sessionError(c *fiber.Ctx, err error) error {
+	body := models.ErrorResponse{
+		Code:  models.ErrCodeInvalidSession,
+		Error: "Session is invalid or expired. Run 'faucet-terminal login' again.",
+	}
+	if errors.Is(err, errSignInDisabled) {
+		body = models.ErrorResponse{
+			Code:  models.ErrCodeSignInDisabled,
+			Error: "Sign-in is not enabled on this faucet. Run 'faucet-terminal logout'.",
+		}
+	}
+	return c.Status(fiber.StatusUnauthorized).JSON(body)
+}
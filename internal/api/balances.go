@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/chains"
+	"go.uber.org/zap"
+)
+
+// faucetBalance returns the faucet's balance of a token, served from the
+// balance cache when fresh and read from chain (and cached) otherwise
+func (h *Handler) faucetBalance(ctx context.Context, network string, chain chains.Chain, chainProvider ChainProvider, token string) (*big.Int, error) {
+	if balance, ok := h.balances.Get(network, token); ok {
+		return balance, nil
+	}
+
+	balanceCtx, cancel := h.chainContext(ctx)
+	defer cancel()
+
+	debited := h.balances.Debited(network, token)
+	balance, err := chain.GetBalance(balanceCtx, chainProvider.GetFaucetAddress(), token)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.balances.SetRead(network, token, balance, debited), nil
+}
+
+// RefreshBalances re-reads every faucet balance on every network into the cache
+func (h *Handler) RefreshBalances(ctx context.Context) {
+	for network, chain := range h.chains {
+		chainProvider := h.providers()[network]
+		for _, token := range chain.GetSupportedTokens() {
+			debited := h.balances.Debited(network, token)
+			balanceCtx, cancel := h.chainContext(ctx)
+			balance, err := chain.GetBalance(balanceCtx, chainProvider.GetFaucetAddress(), token)
+			cancel()
+			if err != nil {
+				h.logger.Warn("Failed to refresh faucet balance",
+					zap.Error(err),
+					zap.String("network", network),
+					zap.String("token", token),
+				)
+				continue
+			}
+			h.balances.SetRead(network, token, balance, debited)
+		}
+	}
+}
+
+// StartBalanceRefresher refreshes cached faucet balances immediately and then
+// every interval, until ctx is cancelled
+func (h *Handler) StartBalanceRefresher(ctx context.Context, interval time.Duration) {
+	go func() {
+		h.RefreshBalances(ctx)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				h.RefreshBalances(ctx)
+			}
+		}
+	}()
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/apikey"
+	"github.com/Giri-Aayush/starknet-faucet/internal/cache"
+	"github.com/Giri-Aayush/starknet-faucet/internal/config"
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// apiKeyHeader carries the API key of requests made on a key's budget
+const apiKeyHeader = "X-API-Key"
+
+// apiKey returns the settings of the API key sent with a request. Like the
+// other handler helpers it returns a status of 0 when the key is valid.
+func (h *Handler) apiKey(c *fiber.Ctx) (*config.APIKeyConfig, int, models.ErrorResponse) {
+	key, err := h.apiKeys().Lookup(c.Get(apiKeyHeader))
+	if errors.Is(err, apikey.ErrMissing) {
+		return nil, fiber.StatusUnauthorized, models.ErrorResponse{
+			Code:  models.ErrCodeAPIKeyRequired,
+			Error: "This endpoint requires an API key in the " + apiKeyHeader + " header",
+		}
+	}
+	var settings *config.APIKeyConfig
+	if err == nil {
+		settings = h.config().APIKey(key.Name)
+	}
+	if settings == nil {
+		return nil, fiber.StatusUnauthorized, models.ErrorResponse{
+			Code:  models.ErrCodeInvalidAPIKey,
+			Error: "Invalid API key",
+		}
+	}
+	return settings, 0, models.ErrorResponse{}
+}
+
+// batchItem is a validated batch item, ready to be sent
+type batchItem struct {
+	disbursement
+	index int
+}
+
+// RequestBatch funds a list of addresses for an API key holder. The whole
+// batch is charged to the key's daily budget up front and refused if it does
+// not fit; each item then goes through the same faucet-wide protections as
+// other requests, and gets its own result. Amounts not sent are refunded.
+func (h *Handler) RequestBatch(c *fiber.Ctx) error {
+	key, status, body := h.apiKey(c)
+	if status != 0 {
+		return c.Status(status).JSON(body)
+	}
+
+	var req models.BatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeInvalidRequest,
+			Error: "Invalid request body",
+		})
+	}
+	if len(req.Items) == 0 || len(req.Items) > key.MaxBatchSize {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Code:    models.ErrCodeInvalidRequest,
+			Error:   fmt.Sprintf("A batch must have between 1 and %d items", key.MaxBatchSize),
+			Details: &models.ErrorDetails{Limit: key.MaxBatchSize, Used: len(req.Items)},
+		})
+	}
+
+	// Validate every item; invalid ones fail on their own and cost nothing
+	results := make([]models.BatchResult, len(req.Items))
+	var items []batchItem
+	for i, item := range req.Items {
+		network := item.Network
+		if network == "" {
+			network = h.defaultNetwork
+		}
+		token := strings.ToUpper(item.Token)
+		results[i] = models.BatchResult{Index: i, Address: item.Address, Network: network, Token: token}
+
+		chain, provider, err := h.getChain(network)
+		if err != nil {
+			results[i].Code, results[i].Error = models.ErrCodeUnsupportedNetwork, err.Error()
+			continue
+		}
+		if err := chain.ValidateAddress(item.Address); err != nil {
+			results[i].Code, results[i].Error = models.ErrCodeInvalidAddress, "Invalid address: "+err.Error()
+			continue
+		}
+		if err := chain.ValidateToken(token); err != nil {
+			results[i].Code, results[i].Error = models.ErrCodeUnsupportedToken, err.Error()
+			continue
+		}
+
+		items = append(items, batchItem{index: i, disbursement: disbursement{
+			Network:   network,
+			Chain:     chain,
+			Provider:  provider,
+			Recipient: item.Address,
+			Token:     token,
+			Amount:    provider.GetDripAmount(token),
+		}})
+	}
+
+	// Charge the whole batch to the key's budget, or refuse it
+	lines := batchBudget(key, items)
+	reservedAt := time.Now()
+	over, err := h.redis.ReserveBudget(c.UserContext(), key.Name, lines, reservedAt)
+	if err != nil {
+		h.logger.Error("Failed to reserve API key budget", zap.Error(err), zap.String("api_key", key.Name))
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeInternal,
+			Error: "Failed to check API key budget",
+		})
+	}
+	if over >= 0 {
+		line := lines[over]
+		spent, _ := h.redis.BudgetSpent(c.UserContext(), key.Name, line.Network, line.Token)
+		left := max(line.Limit-spent, 0)
+		return rateLimited(c, int(line.Limit), int(left), cache.BudgetResetAt(reservedAt), models.ErrorResponse{
+			Code: models.ErrCodeBudgetExceeded,
+			Error: fmt.Sprintf("Batch needs %g %s on %s but the API key has %g of its daily %g left",
+				line.Amount, line.Token, line.Network, left, line.Limit),
+			Details: &models.ErrorDetails{Network: line.Network, Token: line.Token},
+		})
+	}
+
+	h.logger.Info("Funding batch",
+		zap.String("api_key", key.Name),
+		zap.Int("items", len(req.Items)),
+		zap.Int("valid", len(items)),
+	)
+
+	// A started batch is finished even if the client goes away
+	transferCtx, endTransfer := h.beginTransfer(c.UserContext())
+	defer endTransfer()
+
+	for _, item := range items {
+		tx, status, body := h.disburse(transferCtx, item.disbursement)
+		result := &results[item.index]
+		if status != 0 {
+			result.Code, result.Error = body.Code, body.Error
+			amount, _ := strconv.ParseFloat(item.Amount, 64)
+			if err := h.redis.ReleaseBudget(transferCtx, key.Name, item.Network, item.Token, amount, reservedAt); err != nil {
+				h.logger.Error("Failed to refund API key budget", zap.Error(err), zap.String("api_key", key.Name))
+			}
+			continue
+		}
+		result.Success = true
+		result.Amount, result.TxHash, result.ExplorerURL = tx.Amount, tx.TxHash, tx.ExplorerURL
+	}
+
+	response := models.BatchResponse{Results: results}
+	for _, result := range results {
+		if result.Success {
+			response.Succeeded++
+		} else {
+			response.Failed++
+		}
+	}
+
+	h.logger.Info("Batch funded",
+		zap.String("api_key", key.Name),
+		zap.Int("succeeded", response.Succeeded),
+		zap.Int("failed", response.Failed),
+	)
+
+	return c.JSON(response)
+}
+
+// batchBudget totals the items of a batch per network and token, against the
+// key's daily budget for each. Tokens missing from the budget have a limit of 0.
+func batchBudget(key *config.APIKeyConfig, items []batchItem) []cache.BudgetLine {
+	totals := map[[2]string]float64{}
+	for _, item := range items {
+		amount, _ := strconv.ParseFloat(item.Amount, 64)
+		totals[[2]string{item.Network, item.Token}] += amount
+	}
+
+	lines := make([]cache.BudgetLine, 0, len(totals))
+	for k, amount := range totals {
+		lines = append(lines, cache.BudgetLine{
+			Network: k[0],
+			Token:   k[1],
+			Amount:  amount,
+			Limit:   key.DailyBudget[k[0]][k[1]],
+		})
+	}
+	// Stable order, so the first line over budget is reported consistently
+	sort.Slice(lines, func(i, j int) bool {
+		if lines[i].Network != lines[j].Network {
+			return lines[i].Network < lines[j].Network
+		}
+		return lines[i].Token < lines[j].Token
+	})
+	return lines
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/apikey"
+	"github.com/Giri-Aayush/starknet-faucet/internal/config"
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyEndpoints(t *testing.T) {
+	cfg := &config.Config{APIKeys: []config.APIKeyConfig{{Name: "qa", KeySHA256: apikey.Hash("fk_qa"), MaxBatchSize: 2}}}
+	app := fiber.New()
+	SetupRoutes(app, newTestHandler(cfg))
+
+	subscription := `{"mode":"top_up","address":"0x1","network":"nowhere","token":"ETH","min_balance":1}`
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		key    string
+		body   string
+		status int
+		code   models.ErrorCode
+	}{
+		{"batch without key", fiber.MethodPost, "/api/v1/batch", "", `{"items":[]}`, fiber.StatusUnauthorized, models.ErrCodeAPIKeyRequired},
+		{"batch with unknown key", fiber.MethodPost, "/api/v1/batch", "fk_other", `{"items":[]}`, fiber.StatusUnauthorized, models.ErrCodeInvalidAPIKey},
+		{"empty batch", fiber.MethodPost, "/api/v1/batch", "fk_qa", `{"items":[]}`, fiber.StatusBadRequest, models.ErrCodeInvalidRequest},
+		{"batch too large", fiber.MethodPost, "/api/v1/batch", "fk_qa", `{"items":[{},{},{}]}`, fiber.StatusBadRequest, models.ErrCodeInvalidRequest},
+		{"create subscription without key", fiber.MethodPost, "/api/v1/subscriptions", "", subscription, fiber.StatusUnauthorized, models.ErrCodeAPIKeyRequired},
+		{"list subscriptions with unknown key", fiber.MethodGet, "/api/v1/subscriptions", "fk_other", "", fiber.StatusUnauthorized, models.ErrCodeInvalidAPIKey},
+		{"delete subscription without key", fiber.MethodDelete, "/api/v1/subscriptions/sub_1", "", "", fiber.StatusUnauthorized, models.ErrCodeAPIKeyRequired},
+		{"subscription on unsupported network", fiber.MethodPost, "/api/v1/subscriptions", "fk_qa", subscription, fiber.StatusBadRequest, models.ErrCodeUnsupportedNetwork},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		if tt.key != "" {
+			req.Header.Set(apiKeyHeader, tt.key)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err, tt.name)
+		assert.Equal(t, tt.status, resp.StatusCode, tt.name)
+
+		var body models.ErrorResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body), tt.name)
+		assert.Equal(t, tt.code, body.Code, tt.name)
+	}
+}
+
+func TestBatchBudget(t *testing.T) {
+	key := &config.APIKeyConfig{DailyBudget: map[string]map[string]float64{
+		"starknet": {"STRK": 100},
+	}}
+	item := func(network, token, amount string) batchItem {
+		return batchItem{disbursement: disbursement{Network: network, Token: token, Amount: amount}}
+	}
+
+	lines := batchBudget(key, []batchItem{
+		item("starknet", "STRK", "10"),
+		item("starknet", "STRK", "10"),
+		item("ethereum", "ETH", "0.01"),
+	})
+	require.Len(t, lines, 2)
+	assert.Equal(t, "ethereum", lines[0].Network)
+	assert.Equal(t, 0.0, lines[0].Limit, "tokens missing from the budget cannot be sent")
+	assert.Equal(t, 20.0, lines[1].Amount)
+	assert.Equal(t, 100.0, lines[1].Limit)
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/cache"
+	"github.com/Giri-Aayush/starknet-faucet/internal/pow"
+)
+
+// errChallengeSpent is returned when a stateless challenge has already been used
+var errChallengeSpent = errors.New("challenge already used")
+
+// spentClockSkew extends the spent-set entry past expiry so instances with
+// slightly different clocks cannot accept a replay after one has forgotten it
+const spentClockSkew = 30 * time.Second
+
+// maxChallengeAttempts is how many solutions may be submitted for one
+// challenge. Every wrong one costs the server a hash, which for argon2id and
+// scrypt is a full memory-hard derivation, and a stateless challenge could
+// otherwise be replayed from any number of IPs.
+const maxChallengeAttempts = 3
+
+// saveChallenge makes a new challenge verifiable later and returns the ID the
+// client must send back. Stored challenges are written to Redis; stateless ones
+// are sealed into the ID itself.
+func (h *Handler) saveChallenge(ctx context.Context, challenge *pow.Challenge, record cache.ChallengeRecord) (string, error) {
+	if !h.powGenerator.Stateless() {
+		ttl := time.Duration(h.config().ChallengeTTL()) * time.Second
+		if err := h.redis.StoreChallenge(ctx, challenge.ID, record, ttl); err != nil {
+			return "", err
+		}
+		return challenge.ID, nil
+	}
+
+	return h.powGenerator.Seal(pow.Envelope{
+		Seed:       record.Challenge,
+		Difficulty: record.Difficulty,
+		Bits:       record.Bits,
+		Algorithm:  record.Algorithm,
+		Params:     record.Params,
+		Address:    record.Address,
+		Network:    record.Network,
+		Token:      record.Token,
+		ExpiresAt:  record.ExpiresAt,
+	})
+}
+
+// loadChallenge returns the challenge a client is answering
+func (h *Handler) loadChallenge(ctx context.Context, challengeID string) (*cache.ChallengeRecord, error) {
+	if !h.powGenerator.Stateless() {
+		return h.redis.GetChallenge(ctx, challengeID)
+	}
+
+	envelope, err := h.powGenerator.Open(challengeID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &cache.ChallengeRecord{
+		Challenge:  envelope.Seed,
+		Difficulty: envelope.Difficulty,
+		Bits:       envelope.Bits,
+		Algorithm:  envelope.Algorithm,
+		Params:     envelope.Params,
+		Address:    envelope.Address,
+		Network:    envelope.Network,
+		Token:      envelope.Token,
+		ExpiresAt:  envelope.ExpiresAt,
+	}, nil
+}
+
+// challengeMatches reports whether a challenge was issued for this address,
+// network and token; a solution may only be spent on that request
+func challengeMatches(record *cache.ChallengeRecord, address, network, token string) bool {
+	return record.Address == normalizeAddress(address) &&
+		record.Network == network &&
+		record.Token == token
+}
+
+// countAttempt records a submitted solution for a challenge before it is
+// verified. It returns false once the challenge has had maxChallengeAttempts,
+// so it must be requested again.
+func (h *Handler) countAttempt(ctx context.Context, record *cache.ChallengeRecord) (bool, error) {
+	ttl := time.Until(time.Unix(record.ExpiresAt, 0)) + spentClockSkew
+	if record.ExpiresAt == 0 {
+		// Stored before challenges carried their expiry
+		ttl = time.Duration(h.config().ChallengeTTL())*time.Second + spentClockSkew
+	}
+	attempts, err := h.redis.CountChallengeAttempt(ctx, record.Challenge, ttl)
+	if err != nil {
+		return false, err
+	}
+	return attempts <= maxChallengeAttempts, nil
+}
+
+// consumeChallenge marks a solved challenge as used so it cannot be replayed.
+// Stored challenges are deleted; stateless ones go into a spent-set that only
+// lives until the challenge would have expired anyway. Either way only the
+// first of concurrent submissions succeeds; the others get errChallengeSpent.
+func (h *Handler) consumeChallenge(ctx context.Context, challengeID string, record *cache.ChallengeRecord) error {
+	var first bool
+	var err error
+	if h.powGenerator.Stateless() {
+		ttl := time.Until(time.Unix(record.ExpiresAt, 0)) + spentClockSkew
+		first, err = h.redis.MarkChallengeSpent(ctx, record.Challenge, ttl)
+	} else {
+		first, err = h.redis.DeleteChallenge(ctx, challengeID)
+	}
+	if err != nil {
+		return err
+	}
+	if !first {
+		return errChallengeSpent
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChallengeMatches(t *testing.T) {
+	record := &cache.ChallengeRecord{Address: normalizeAddress("0x0ABC"), Network: "starknet", Token: "STRK"}
+
+	tests := []struct {
+		name    string
+		address string
+		network string
+		token   string
+		matches bool
+	}{
+		{"same request", "0x0ABC", "starknet", "STRK", true},
+		{"address written differently", "0xabc", "starknet", "STRK", true},
+		{"different address", "0xabd", "starknet", "STRK", false},
+		{"different network", "0xabc", "ethereum", "STRK", false},
+		{"different token", "0xabc", "starknet", "ETH", false},
+		{"BOTH instead of one token", "0xabc", "starknet", "BOTH", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.matches, challengeMatches(record, tt.address, tt.network, tt.token), tt.name)
+	}
+}
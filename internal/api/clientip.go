@@ -0,0 +1,21 @@
+package api
+
+import (
+	"github.com/Giri-Aayush/starknet-faucet/internal/clientip"
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetClientIPResolver sets how client addresses are resolved behind proxies.
+// Without one, the connection address is used.
+func (h *Handler) SetClientIPResolver(resolver *clientip.Resolver) {
+	h.ipResolver = resolver
+}
+
+// clientIP returns the address every limiter charges a request to
+func (h *Handler) clientIP(c *fiber.Ctx) string {
+	remote := c.Context().RemoteIP().String()
+	if h.ipResolver == nil {
+		return remote
+	}
+	return h.ipResolver.ClientIP(remote, c.Get(h.ipResolver.Header()))
+}
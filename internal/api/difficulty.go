@@ -0,0 +1,37 @@
+package api
+
+import (
+	"context"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/pow"
+	"go.uber.org/zap"
+)
+
+// challengeDifficulty picks the PoW difficulty for a new challenge from the
+// global challenge rate and the requesting IP's recent penalties. Signal
+// lookups are best effort: on error the corresponding signal counts as zero.
+func (h *Handler) challengeDifficulty(ctx context.Context, ip string) int {
+	var signals pow.Signals
+
+	load, err := h.redis.IncrementChallengeLoad(ctx)
+	if err != nil {
+		h.logger.Error("Failed to track challenge load", zap.Error(err))
+	}
+	signals.ChallengesThisMinute = load
+
+	penalties, err := h.redis.GetPoWPenalty(ctx, h.ipKey(ip))
+	if err != nil {
+		h.logger.Error("Failed to get PoW penalty", zap.Error(err))
+	}
+	signals.IPPenalties = penalties
+
+	return h.powGenerator.DifficultyFor(signals)
+}
+
+// recordPoWPenalty counts an invalid solution or rejected request against an
+// IP, raising the difficulty of its next challenges
+func (h *Handler) recordPoWPenalty(ctx context.Context, ip string) {
+	if err := h.redis.RecordPoWPenalty(ctx, h.ipKey(ip), h.config().PoWPenaltyWindow()); err != nil {
+		h.logger.Error("Failed to record PoW penalty", zap.Error(err))
+	}
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/chains"
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// disbursement is a transfer from the faucet that callers have already
+// authorized, such as a faucet request or an item of an API key's batch
+type disbursement struct {
+	Network   string
+	Chain     chains.Chain
+	Provider  ChainProvider
+	Recipient string
+	Token     string
+	Amount    string          // Whole tokens
+	Tracker   *requestTracker // Records the transfer's stages, if set
+}
+
+// disburse sends a transfer through the faucet-wide protections that apply to
+// every request: the global distribution limits and the minimum balance.
+// Like the handler helpers it returns a status of 0 on success; a refusal at
+// the distribution limit carries the time it lifts. The context must not be
+// tied to a client connection: a started transfer is always finished.
+func (h *Handler) disburse(ctx context.Context, d disbursement) (models.TransactionInfo, int, models.ErrorResponse) {
+	amountFloat, err := strconv.ParseFloat(d.Amount, 64)
+	if err != nil || amountFloat <= 0 {
+		return models.TransactionInfo{}, fiber.StatusInternalServerError, models.ErrorResponse{
+			Code:  models.ErrCodeInternal,
+			Error: fmt.Sprintf("Invalid amount %q", d.Amount),
+		}
+	}
+
+	// Global distribution limits (anti-drain protection)
+	canDistribute, err := h.redis.TrackGlobalDistribution(ctx, d.Token, amountFloat,
+		d.Provider.GetMaxTokensPerHour(d.Token), d.Provider.GetMaxTokensPerDay(d.Token))
+	if err != nil {
+		h.logger.Error("Failed to check global distribution limits", zap.Error(err), zap.String("token", d.Token))
+		return models.TransactionInfo{}, fiber.StatusInternalServerError, models.ErrorResponse{
+			Code:  models.ErrCodeInternal,
+			Error: "Failed to process request",
+		}
+	}
+	if !canDistribute {
+		h.logger.Warn("Global distribution limit reached", zap.String("network", d.Network), zap.String("token", d.Token))
+		return models.TransactionInfo{}, fiber.StatusServiceUnavailable, retryHints(time.Now().Add(time.Hour), models.ErrorResponse{
+			Code:    models.ErrCodeFaucetLimit,
+			Error:   "[FAUCET LIMIT] Faucet has temporarily reached its distribution limit. Please try again in an hour.",
+			Details: &models.ErrorDetails{Network: d.Network, Token: d.Token},
+		})
+	}
+
+	// Minimum balance protection
+	currentBalance, err := h.faucetBalance(ctx, d.Network, d.Chain, d.Provider, d.Token)
+	if err != nil {
+		h.logger.Error("Failed to check faucet balance", zap.Error(err), zap.String("token", d.Token))
+		return models.TransactionInfo{}, fiber.StatusInternalServerError, models.ErrorResponse{
+			Code:  models.ErrCodeInternal,
+			Error: "Failed to check faucet balance",
+		}
+	}
+	decimals := d.Provider.GetTokenDecimals(d.Token)
+	amountWei := chains.ToBaseUnits(amountFloat, decimals)
+	if !h.aboveMinBalance(d.Provider, currentBalance, amountWei) {
+		balance := chains.FromBaseUnits(currentBalance, decimals)
+		h.logger.Warn("Balance protection triggered",
+			zap.String("network", d.Network),
+			zap.String("token", d.Token),
+			zap.Float64("current_balance", balance),
+		)
+		return models.TransactionInfo{}, fiber.StatusServiceUnavailable, models.ErrorResponse{
+			Code:    models.ErrCodeLowBalance,
+			Error:   fmt.Sprintf("[LOW BALANCE] Faucet %s balance too low (%.4f). Please try again later.", d.Token, balance),
+			Details: &models.ErrorDetails{Network: d.Network, Token: d.Token, Balance: balance},
+		}
+	}
+
+	sendCtx, cancelSend := h.chainContext(ctx)
+	var txHash string
+	if d.Tracker != nil {
+		txHash, err = d.Tracker.transfer(sendCtx, d.Chain, d.Recipient, d.Token, amountWei)
+	} else {
+		txHash, err = d.Chain.TransferTokens(sendCtx, d.Recipient, d.Token, amountWei)
+	}
+	cancelSend()
+	if err != nil {
+		h.logger.Error("Failed to transfer tokens",
+			zap.Error(err),
+			zap.String("recipient", d.Recipient),
+			zap.String("token", d.Token),
+		)
+		return models.TransactionInfo{}, fiber.StatusInternalServerError, models.ErrorResponse{
+			Code:  models.ErrCodeInternal,
+			Error: fmt.Sprintf("Failed to send %s tokens. Please try again later.", d.Token),
+		}
+	}
+	h.balances.Debit(d.Network, d.Token, amountWei)
+	h.recordTransfer(d.Network, d.Token, d.Recipient, d.Amount)
+
+	return models.TransactionInfo{
+		Token:       d.Token,
+		Amount:      d.Amount,
+		TxHash:      txHash,
+		ExplorerURL: d.Chain.GetExplorerURL(txHash),
+	}, 0, models.ErrorResponse{}
+}
+
+// aboveMinBalance reports whether the faucet keeps the protected share of its
+// balance after sending amount
+func (h *Handler) aboveMinBalance(provider ChainProvider, balance, amount *big.Int) bool {
+	minBalancePct := big.NewInt(int64(provider.GetMinBalanceProtectPct()))
+	after := new(big.Int).Sub(balance, amount)
+	minimum := new(big.Int).Div(new(big.Int).Mul(balance, minBalancePct), big.NewInt(100))
+	return after.Cmp(minimum) >= 0
+}
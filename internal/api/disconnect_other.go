@@ -0,0 +1,10 @@
+//go:build !linux && !darwin
+
+package api
+
+import "net"
+
+// peerClosed is not supported on this platform; requests rely on their timeout
+func peerClosed(conn net.Conn) bool {
+	return false
+}
@@ -0,0 +1,31 @@
+//go:build linux || darwin
+
+package api
+
+import (
+	"net"
+	"syscall"
+)
+
+// peerClosed reports whether the remote side has closed the connection.
+// It peeks at the socket without consuming data, so a pipelined request
+// waiting in the buffer is left for the server to read.
+func peerClosed(conn net.Conn) bool {
+	sc, ok := conn.(syscall.Conn)
+	if !ok {
+		return false
+	}
+	raw, err := sc.SyscallConn()
+	if err != nil {
+		return false
+	}
+
+	closed := false
+	buf := make([]byte, 1)
+	_ = raw.Read(func(fd uintptr) bool {
+		n, _, err := syscall.Recvfrom(int(fd), buf, syscall.MSG_PEEK|syscall.MSG_DONTWAIT)
+		closed = n == 0 && err == nil
+		return true
+	})
+	return closed
+}
@@ -0,0 +1,393 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/chains"
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const (
+	// receiptPollInterval is how often a sent transaction's receipt is read
+	receiptPollInterval = 3 * time.Second
+
+	// requestEventTimeout bounds storing a single request event
+	requestEventTimeout = 5 * time.Second
+
+	// eventStreamKeepAlive is how often an idle event stream gets a comment, so
+	// proxies keep it open and a departed client is noticed
+	eventStreamKeepAlive = 15 * time.Second
+
+	// maxEventStreamsPerIP is how many event streams a client network may hold
+	// open on one instance
+	maxEventStreamsPerIP = 4
+)
+
+// requestIDPattern matches request IDs: 32 lowercase hex digits
+var requestIDPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// requestIDFor returns the ID of the faucet request that spends a challenge.
+// Clients receive it with the challenge, so they can follow a request before making it.
+func requestIDFor(challengeID string) string {
+	sum := sha256.Sum256([]byte(challengeID))
+	return hex.EncodeToString(sum[:16])
+}
+
+// newRequestID returns a random request ID, for requests made without a challenge
+func newRequestID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// requestTracker records the stages of one faucet request on its event stream.
+// Storing events is best effort: a failure is logged and never fails the request.
+type requestTracker struct {
+	h  *Handler
+	id string
+
+	// spent is set once the request cannot be retried under the same ID: its
+	// challenge was consumed or used up, or it was made with a test token
+	spent bool
+
+	// pending counts transactions that have not yet confirmed or failed
+	pending atomic.Int32
+}
+
+// trackRequest starts recording the events of the request with the given ID
+func (h *Handler) trackRequest(id string) *requestTracker {
+	return &requestTracker{h: h, id: id}
+}
+
+// emit appends an event to the request's stream
+func (t *requestTracker) emit(event models.RequestEvent) {
+	event.Time = time.Now().UTC()
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestEventTimeout)
+	defer cancel()
+	if err := t.h.redis.AppendRequestEvent(ctx, t.id, event); err != nil {
+		t.h.logger.Warn("Failed to record request event",
+			zap.Error(err),
+			zap.String("request_id", t.id),
+			zap.String("stage", string(event.Stage)),
+		)
+	}
+}
+
+// settle records the error response of a request that the handler refused
+// or failed. Successful requests are settled by follow.
+func (t *requestTracker) settle(c *fiber.Ctx) {
+	if c.Response().StatusCode() < fiber.StatusBadRequest {
+		return
+	}
+	t.emit(refusalEvent(errorBody(c), t.spent))
+}
+
+// refusalEvent returns the event for an error response. It closes the stream
+// only once the request is spent; until then the client may retry with the
+// same challenge, and the retry is reported on the same stream.
+func refusalEvent(body models.ErrorResponse, spent bool) models.RequestEvent {
+	stage := models.StageRefused
+	if spent {
+		stage = models.StageFailed
+	}
+	return models.RequestEvent{
+		Stage: stage,
+		Code:  body.Code,
+		Error: body.Error,
+		Final: spent,
+	}
+}
+
+// errorBody returns the error response a handler has written
+func errorBody(c *fiber.Ctx) models.ErrorResponse {
+	var body models.ErrorResponse
+	if err := json.Unmarshal(c.Response().Body(), &body); err != nil {
+		body = models.ErrorResponse{Code: models.ErrCodeInternal, Error: "Request failed"}
+	}
+	return body
+}
+
+// transfer sends tokens like chain.TransferTokens, recording the queued,
+// signed and broadcast stages of the transaction
+func (t *requestTracker) transfer(ctx context.Context, chain chains.Chain, recipient, token string, amount *big.Int) (string, error) {
+	t.emit(models.RequestEvent{Stage: models.StageQueued, Token: token})
+
+	ctx = chains.WithSignedHook(ctx, func(txHash string) {
+		t.emit(models.RequestEvent{Stage: models.StageSigned, Token: token, TxHash: txHash})
+	})
+	txHash, err := chain.TransferTokens(ctx, recipient, token, amount)
+	if err != nil {
+		return "", err
+	}
+
+	t.emit(models.RequestEvent{
+		Stage:       models.StageBroadcast,
+		Token:       token,
+		TxHash:      txHash,
+		ExplorerURL: chain.GetExplorerURL(txHash),
+	})
+	return txHash, nil
+}
+
+// follow watches each sent transaction in the background until it confirms,
+// reverts or the confirmation timeout elapses. The last of them closes the stream.
+func (t *requestTracker) follow(chain chains.Chain, transactions []models.TransactionInfo) {
+	t.pending.Store(int32(len(transactions)))
+	for _, tx := range transactions {
+		go t.watch(chain, tx)
+	}
+}
+
+// watch records the included and confirmed stages of one transaction
+func (t *requestTracker) watch(chain chains.Chain, tx models.TransactionInfo) {
+	timeout := t.h.config().ConfirmTimeout()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(receiptPollInterval)
+	defer ticker.Stop()
+
+	event := models.RequestEvent{Token: tx.Token, TxHash: tx.TxHash, ExplorerURL: tx.ExplorerURL}
+	for {
+		select {
+		case <-ctx.Done():
+			event.Stage = models.StageFailed
+			event.Code = models.ErrCodeTransactionUnconfirmed
+			event.Error = fmt.Sprintf("Transaction not confirmed within %s", timeout)
+			t.done(event)
+			return
+		case <-ticker.C:
+		}
+
+		receipt, err := chain.GetTransactionReceipt(ctx, tx.TxHash)
+		if err != nil || receipt == nil {
+			// Not yet in a block, or the endpoints are struggling: try again
+			continue
+		}
+
+		if receipt.Reverted {
+			event.Stage = models.StageFailed
+			event.BlockNumber = receipt.BlockNumber
+			event.Code = models.ErrCodeTransactionReverted
+			event.Error = "Transaction reverted: " + receipt.Reason
+			t.done(event)
+			return
+		}
+
+		if event.BlockNumber == 0 || event.BlockNumber != receipt.BlockNumber {
+			// A pre-confirmed block may be replaced, so report the block it lands in
+			event.Stage = models.StageIncluded
+			event.BlockNumber = receipt.BlockNumber
+			t.emit(event)
+		}
+
+		if receipt.Confirmed {
+			event.Stage = models.StageConfirmed
+			t.done(event)
+			return
+		}
+	}
+}
+
+// done records the last event of a transaction, closing the stream after the
+// last pending transaction
+func (t *requestTracker) done(event models.RequestEvent) {
+	event.Final = t.pending.Add(-1) == 0
+	t.emit(event)
+}
+
+// RequestEvents streams the stages of a faucet request as Server-Sent Events.
+// Recorded events are replayed first, so the stream may be opened before the
+// request is made, after it finished, or again with Last-Event-ID after a drop.
+// The stream ends after the event marked final.
+func (h *Handler) RequestEvents(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if !requestIDPattern.MatchString(id) {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeInvalidRequest,
+			Error: "Invalid request ID",
+		})
+	}
+
+	// Only requests with a live challenge or recorded events can be followed
+	known, err := h.redis.RequestKnown(c.UserContext(), id)
+	if err != nil {
+		h.logger.Error("Failed to look up request", zap.Error(err), zap.String("request_id", id))
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeInternal,
+			Error: "Failed to look up request",
+		})
+	}
+	if !known {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeNotFound,
+			Error: "Unknown or expired request ID",
+		})
+	}
+
+	ipKey := h.ipKey(h.clientIP(c))
+	if !h.eventStreams.acquire(ipKey, maxEventStreamsPerIP) {
+		return rateLimited(c, maxEventStreamsPerIP, 0, time.Now().Add(eventStreamKeepAlive), models.ErrorResponse{
+			Code:  models.ErrCodeStreamLimit,
+			Error: fmt.Sprintf("At most %d event streams may be open at once; close one first", maxEventStreamsPerIP),
+		})
+	}
+
+	// Resume after the last event the client saw
+	skip := 0
+	if last, err := strconv.Atoi(c.Get("Last-Event-ID")); err == nil && last > 0 {
+		skip = last
+	}
+
+	// Long enough for the challenge to be solved and spent and the transfer to confirm
+	lifetime := time.Duration(h.config().ChallengeTTL())*time.Second + h.config().RequestTimeout() + h.config().ConfirmTimeout()
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer h.eventStreams.release(ipKey)
+
+		// The request context ends when the handler returns; the stream
+		// outlives it, until its lifetime is up or the server shuts down
+		ctx, cancel := context.WithTimeout(h.eventStreams.context(), lifetime)
+		defer cancel()
+		h.streamRequestEvents(ctx, w, id, skip)
+	})
+	return nil
+}
+
+// CloseEventStreams ends every open request event stream, so that shutdown
+// does not wait for them. Clients resume on another instance with Last-Event-ID.
+func (h *Handler) CloseEventStreams() {
+	h.eventStreams.closeAll()
+}
+
+// streamCounter counts the open event streams of each client network, and
+// holds the context they run under
+type streamCounter struct {
+	mu     sync.Mutex
+	open   map[string]int
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// context returns the context streams run under, cancelled by closeAll
+func (s *streamCounter) context() context.Context {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.init()
+	return s.ctx
+}
+
+// closeAll cancels the context of every stream, open or still to come
+func (s *streamCounter) closeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.init()
+	s.cancel()
+}
+
+func (s *streamCounter) init() {
+	if s.ctx == nil {
+		s.ctx, s.cancel = context.WithCancel(context.Background())
+	}
+}
+
+// acquire opens a stream for key unless it already has max open
+func (s *streamCounter) acquire(key string, max int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.open[key] >= max {
+		return false
+	}
+	if s.open == nil {
+		s.open = make(map[string]int)
+	}
+	s.open[key]++
+	return true
+}
+
+// release closes a stream opened with acquire
+func (s *streamCounter) release(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.open[key] <= 1 {
+		delete(s.open, key)
+		return
+	}
+	s.open[key]--
+}
+
+// streamRequestEvents writes the events of a request after the first skip
+// until the final one, the context ends or the client goes away
+func (h *Handler) streamRequestEvents(ctx context.Context, w *bufio.Writer, id string, skip int) {
+	appended, stop, err := h.redis.WatchRequestEvents(ctx, id)
+	if err != nil {
+		h.logger.Error("Failed to watch request events", zap.Error(err), zap.String("request_id", id))
+		return
+	}
+	defer stop()
+
+	keepAlive := time.NewTicker(eventStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		events, err := h.redis.RequestEventsSince(ctx, id, skip)
+		if err != nil {
+			h.logger.Error("Failed to read request events", zap.Error(err), zap.String("request_id", id))
+			return
+		}
+		for _, event := range events {
+			if err := writeEvent(w, event); err != nil {
+				return
+			}
+			skip = event.Seq
+			if event.Final {
+				w.Flush()
+				return
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		// Events are read again on every keep-alive, in case a wake-up was lost
+		select {
+		case <-ctx.Done():
+			return
+		case <-appended:
+		case <-keepAlive.C:
+			if _, err := w.WriteString(": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// writeEvent writes one request event in Server-Sent Events format, named
+// after its stage and identified by its sequence number
+func writeEvent(w *bufio.Writer, event models.RequestEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Seq, event.Stage, data)
+	return err
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/config"
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestIDs(t *testing.T) {
+	id := requestIDFor("challenge-1")
+	assert.Regexp(t, requestIDPattern, id)
+	assert.Equal(t, id, requestIDFor("challenge-1"), "a challenge always maps to the same request")
+	assert.NotEqual(t, id, requestIDFor("challenge-2"))
+
+	assert.Regexp(t, requestIDPattern, newRequestID())
+	assert.NotEqual(t, newRequestID(), newRequestID())
+}
+
+func TestWriteEvent(t *testing.T) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	require.NoError(t, writeEvent(w, models.RequestEvent{
+		Seq:         3,
+		Stage:       models.StageIncluded,
+		Time:        time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+		Token:       "ETH",
+		TxHash:      "0xabc",
+		BlockNumber: 42,
+	}))
+	require.NoError(t, w.Flush())
+
+	assert.Equal(t, "id: 3\nevent: included\n"+
+		`data: {"seq":3,"stage":"included","time":"2030-01-02T03:04:05Z","token":"ETH","tx_hash":"0xabc","block_number":42}`+
+		"\n\n", buf.String())
+}
+
+func TestRequestEventsRejectsInvalidID(t *testing.T) {
+	app := fiber.New()
+	SetupRoutes(app, newTestHandler(&config.Config{}))
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/api/v1/requests/not-an-id/events", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestRefusalEvent(t *testing.T) {
+	body := models.ErrorResponse{Code: models.ErrCodeInvalidProofOfWork, Error: "Invalid proof of work solution"}
+
+	event := refusalEvent(body, false)
+	assert.Equal(t, models.StageRefused, event.Stage)
+	assert.False(t, event.Final, "the challenge may be retried on the same stream")
+	assert.Equal(t, body.Code, event.Code)
+
+	event = refusalEvent(body, true)
+	assert.Equal(t, models.StageFailed, event.Stage)
+	assert.True(t, event.Final)
+	assert.Equal(t, body.Error, event.Error)
+}
+
+func TestStreamCounter(t *testing.T) {
+	var s streamCounter
+	assert.True(t, s.acquire("10.0.0.0/24", 2))
+	assert.True(t, s.acquire("10.0.0.0/24", 2))
+	assert.False(t, s.acquire("10.0.0.0/24", 2))
+	assert.True(t, s.acquire("10.0.1.0/24", 2), "networks are counted apart")
+
+	s.release("10.0.0.0/24")
+	assert.True(t, s.acquire("10.0.0.0/24", 2))
+
+	s.release("10.0.1.0/24")
+	assert.NotContains(t, s.open, "10.0.1.0/24", "closed networks are forgotten")
+}
+
+func TestStreamCounterCloseAll(t *testing.T) {
+	var s streamCounter
+	open := s.context()
+	assert.NoError(t, open.Err())
+
+	s.closeAll()
+	assert.ErrorIs(t, open.Err(), context.Canceled, "open streams end at shutdown")
+	assert.ErrorIs(t, s.context().Err(), context.Canceled, "and so do streams opened after it")
+}
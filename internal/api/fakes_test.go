@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/Giri-Aayush/starknet-faucet/chains"
+)
+
+// fakeChain is a chain whose balances are set by the test. Transfers are refused.
+type fakeChain struct {
+	name     string
+	tokens   []string
+	balances map[string]*big.Int // by token
+	err      error               // returned by GetBalance when set
+}
+
+func (f *fakeChain) TransferTokens(ctx context.Context, recipient, token string, amount *big.Int) (string, error) {
+	return "", fmt.Errorf("transfers are not supported")
+}
+
+func (f *fakeChain) GetBalance(ctx context.Context, address, token string) (*big.Int, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if balance, ok := f.balances[token]; ok {
+		return balance, nil
+	}
+	return big.NewInt(0), nil
+}
+
+func (f *fakeChain) WaitForTransaction(ctx context.Context, txHash string) error { return nil }
+
+func (f *fakeChain) GetTransactionReceipt(ctx context.Context, txHash string) (*chains.Receipt, error) {
+	return nil, nil
+}
+
+func (f *fakeChain) ValidateAddress(address string) error {
+	if !strings.HasPrefix(address, "0x") {
+		return fmt.Errorf("address must start with 0x")
+	}
+	return nil
+}
+
+func (f *fakeChain) NormalizeAddress(address string) string { return strings.ToLower(address) }
+
+func (f *fakeChain) GetSupportedTokens() []string { return f.tokens }
+
+func (f *fakeChain) ValidateToken(token string) error {
+	for _, t := range f.tokens {
+		if t == token {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported token: %s", token)
+}
+
+func (f *fakeChain) GetExplorerURL(txHash string) string { return "" }
+func (f *fakeChain) GetChainName() string                { return f.name }
+func (f *fakeChain) GetNetworkName() string              { return "sepolia" }
+
+// fakeProvider serves the same settings for every token
+type fakeProvider struct {
+	maxRecipientBalance float64
+}
+
+func (p fakeProvider) GetDripAmount(token string) string           { return "1" }
+func (p fakeProvider) GetTokenAddress(token string) string         { return "0x1" }
+func (p fakeProvider) GetTokenDecimals(token string) int           { return chains.DefaultDecimals }
+func (p fakeProvider) GetMaxTokensPerHour(token string) float64    { return 100 }
+func (p fakeProvider) GetMaxTokensPerDay(token string) float64     { return 1000 }
+func (p fakeProvider) GetMaxRecipientBalance(token string) float64 { return p.maxRecipientBalance }
+func (p fakeProvider) GetMinBalanceProtectPct() int                { return 0 }
+func (p fakeProvider) GetFaucetAddress() string                    { return "0xfaucet" }
@@ -0,0 +1,453 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/chains"
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/Giri-Aayush/starknet-faucet/pkg/faucetpb"
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// grpcErrorDomain is the domain of the ErrorInfo attached to failed gRPC calls
+const grpcErrorDomain = "faucet"
+
+// grpcResponseHeaders are the REST response headers passed on as gRPC header metadata
+var grpcResponseHeaders = []string{
+	fiber.HeaderRetryAfter,
+	headerRateLimitLimit,
+	headerRateLimitRemaining,
+	headerRateLimitReset,
+}
+
+// GRPCServer implements FaucetService by dispatching each call in-process to
+// the REST route that serves it. Both APIs therefore share validation, error
+// codes and every limiter, and a call is charged to the gRPC peer's address
+// exactly as a REST request from that address would be.
+type GRPCServer struct {
+	faucetpb.UnimplementedFaucetServiceServer
+
+	handler *Handler
+	routes  fasthttp.RequestHandler
+}
+
+// NewGRPCServer returns a gRPC server offering FaucetService on top of app,
+// which must have been set up with SetupRoutes for handler
+func NewGRPCServer(handler *Handler, app *fiber.App) *grpc.Server {
+	server := grpc.NewServer()
+	faucetpb.RegisterFaucetServiceServer(server, &GRPCServer{handler: handler, routes: app.Handler()})
+	reflection.Register(server)
+	return server
+}
+
+// GetChallenge issues a proof-of-work challenge
+func (s *GRPCServer) GetChallenge(ctx context.Context, req *faucetpb.GetChallengeRequest) (*faucetpb.GetChallengeResponse, error) {
+	var resp models.ChallengeResponse
+	err := s.call(ctx, fiber.MethodPost, "/api/v1/challenge", models.ChallengeRequest{
+		Address: req.GetAddress(),
+		Token:   req.GetToken(),
+		Network: req.GetNetwork(),
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &faucetpb.GetChallengeResponse{
+		ChallengeId:      resp.ChallengeID,
+		Challenge:        resp.Challenge,
+		Difficulty:       int32(resp.Difficulty),
+		DifficultyBits:   int32(resp.DifficultyBits),
+		DifficultyFormat: resp.DifficultyFormat,
+		Target:           resp.Target,
+		Algorithm:        resp.Algorithm,
+	}
+	if p := resp.Params; p != nil {
+		out.Params = &faucetpb.PoWParams{
+			Time:      p.Time,
+			MemoryKib: p.MemoryKiB,
+			Threads:   uint32(p.Threads),
+			N:         int32(p.N),
+			R:         int32(p.R),
+			P:         int32(p.P),
+		}
+	}
+	return out, nil
+}
+
+// RequestTokens spends a solved challenge on a drip
+func (s *GRPCServer) RequestTokens(ctx context.Context, req *faucetpb.RequestTokensRequest) (*faucetpb.RequestTokensResponse, error) {
+	var resp models.FaucetResponse
+	err := s.call(ctx, fiber.MethodPost, "/api/v1/faucet", models.FaucetRequest{
+		Address:      req.GetAddress(),
+		Token:        req.GetToken(),
+		Network:      req.GetNetwork(),
+		ChallengeID:  req.GetChallengeId(),
+		Nonce:        req.GetNonce(),
+		TestToken:    req.GetTestToken(),
+		CaptchaToken: req.GetCaptchaToken(),
+		Signature:    req.GetSignature(),
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	// REST reports a single token inline and BOTH as a list; gRPC always lists
+	out := &faucetpb.RequestTokensResponse{Message: resp.Message}
+	if resp.TxHash != "" {
+		out.Transactions = append(out.Transactions, &faucetpb.Transaction{
+			Token:       resp.Token,
+			Amount:      resp.Amount,
+			TxHash:      resp.TxHash,
+			ExplorerUrl: resp.ExplorerURL,
+		})
+	}
+	for _, tx := range resp.Transactions {
+		out.Transactions = append(out.Transactions, &faucetpb.Transaction{
+			Token:       tx.Token,
+			Amount:      tx.Amount,
+			TxHash:      tx.TxHash,
+			ExplorerUrl: tx.ExplorerURL,
+		})
+	}
+	return out, nil
+}
+
+// GetStatus reports whether an address may request now
+func (s *GRPCServer) GetStatus(ctx context.Context, req *faucetpb.GetStatusRequest) (*faucetpb.GetStatusResponse, error) {
+	path := "/api/v1/status/" + url.PathEscape(req.GetAddress())
+	if req.GetNetwork() != "" {
+		path += "?" + url.Values{"network": {req.GetNetwork()}}.Encode()
+	}
+
+	var resp models.StatusResponse
+	if err := s.call(ctx, fiber.MethodGet, path, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &faucetpb.GetStatusResponse{
+		Address:         resp.Address,
+		CanRequest:      resp.CanRequest,
+		LastRequest:     timestamp(resp.LastRequest),
+		NextRequestTime: timestamp(resp.NextRequestTime),
+		RemainingHours:  resp.RemainingHours,
+	}, nil
+}
+
+// GetInfo describes every network and token the faucet serves
+func (s *GRPCServer) GetInfo(ctx context.Context, req *faucetpb.GetInfoRequest) (*faucetpb.GetInfoResponse, error) {
+	path := "/api/v2/info"
+	if req.GetNetwork() != "" {
+		path += "?" + url.Values{"network": {req.GetNetwork()}}.Encode()
+	}
+
+	var resp models.InfoResponseV2
+	if err := s.call(ctx, fiber.MethodGet, path, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	out := &faucetpb.GetInfoResponse{
+		DefaultNetwork:     resp.DefaultNetwork,
+		DailyRequestsPerIp: int32(resp.DailyRequestsPerIP),
+		Pow: &faucetpb.PoWInfo{
+			Enabled:          resp.PoW.Enabled,
+			Algorithm:        resp.PoW.Algorithm,
+			DifficultyFormat: resp.PoW.Format,
+			Difficulty:       int32(resp.PoW.Difficulty),
+			MinDifficulty:    int32(resp.PoW.MinDifficulty),
+			MaxDifficulty:    int32(resp.PoW.MaxDifficulty),
+		},
+	}
+	for _, n := range resp.Networks {
+		network := &faucetpb.NetworkInfo{
+			Network:                n.Network,
+			Chain:                  n.Chain,
+			Name:                   n.Name,
+			Default:                n.Default,
+			FaucetAddress:          n.FaucetAddress,
+			OwnershipProofRequired: n.OwnershipProof,
+		}
+		if hv := n.HumanVerification; hv != nil {
+			network.HumanVerification = &faucetpb.HumanVerificationInfo{
+				Provider: hv.Provider,
+				SiteKey:  hv.SiteKey,
+				Required: hv.Required,
+			}
+		}
+		for _, t := range n.Tokens {
+			network.Tokens = append(network.Tokens, &faucetpb.TokenInfo{
+				Symbol:                t.Symbol,
+				Decimals:              int32(t.Decimals),
+				ContractAddress:       t.ContractAddress,
+				DripAmount:            t.DripAmount,
+				MaxPerHour:            t.MaxPerHour,
+				MaxPerDay:             t.MaxPerDay,
+				MaxRecipientBalance:   t.MaxRecipientBalance,
+				ThrottleWindowSeconds: int32(t.ThrottleWindowSeconds),
+				Balance:               t.Balance,
+			})
+		}
+		out.Networks = append(out.Networks, network)
+	}
+	return out, nil
+}
+
+// GetQuota reports the caller's remaining quota
+func (s *GRPCServer) GetQuota(ctx context.Context, _ *faucetpb.GetQuotaRequest) (*faucetpb.GetQuotaResponse, error) {
+	var resp models.QuotaResponse
+	if err := s.call(ctx, fiber.MethodGet, "/api/v1/quota", nil, &resp); err != nil {
+		return nil, err
+	}
+
+	out := &faucetpb.GetQuotaResponse{
+		DailyLimit: &faucetpb.DailyQuota{
+			Scope:       resp.DailyLimit.Scope,
+			Total:       int32(resp.DailyLimit.Total),
+			Used:        int32(resp.DailyLimit.Used),
+			Remaining:   int32(resp.DailyLimit.Remaining),
+			CooldownEnd: timestamp(resp.DailyLimit.CooldownEnd),
+			InCooldown:  resp.DailyLimit.InCooldown,
+		},
+		HourlyThrottleByNetwork: make(map[string]*faucetpb.NetworkThrottle, len(resp.HourlyThrottle)),
+	}
+	for network, tokens := range resp.HourlyThrottle {
+		throttle := &faucetpb.NetworkThrottle{Tokens: make(map[string]*faucetpb.TokenThrottle, len(tokens))}
+		for token, t := range tokens {
+			throttle.Tokens[token] = &faucetpb.TokenThrottle{
+				Available:     t.Available,
+				NextRequestAt: timestamp(t.NextRequestAt),
+			}
+		}
+		out.HourlyThrottleByNetwork[network] = throttle
+	}
+	return out, nil
+}
+
+// FollowRequest requests tokens, then reports each transaction as it settles
+func (s *GRPCServer) FollowRequest(req *faucetpb.FollowRequestRequest, stream faucetpb.FaucetService_FollowRequestServer) error {
+	ctx := stream.Context()
+
+	result, err := s.RequestTokens(ctx, req.GetRequest())
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&faucetpb.FollowRequestResponse{
+		Stage:  faucetpb.FollowRequestResponse_STAGE_SUBMITTED,
+		Result: result,
+	}); err != nil {
+		return err
+	}
+
+	network := req.GetRequest().GetNetwork()
+	if network == "" {
+		network = s.handler.defaultNetwork
+	}
+	chain, _, err := s.handler.getChain(network)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	// Buffered so that waiters never block once the client has gone away
+	updates := make(chan *faucetpb.FollowRequestResponse, len(result.Transactions))
+	for _, tx := range result.Transactions {
+		go func() {
+			updates <- s.confirm(ctx, chain, tx)
+		}()
+	}
+	for range result.Transactions {
+		if err := stream.Send(<-updates); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// confirm waits for tx to be accepted on chain, up to the confirmation timeout
+func (s *GRPCServer) confirm(ctx context.Context, chain chains.Chain, tx *faucetpb.Transaction) *faucetpb.FollowRequestResponse {
+	timeout := s.handler.config().ConfirmTimeout()
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := chain.WaitForTransaction(waitCtx, tx.GetTxHash())
+	if err == nil {
+		return &faucetpb.FollowRequestResponse{
+			Stage:       faucetpb.FollowRequestResponse_STAGE_CONFIRMED,
+			Transaction: tx,
+		}
+	}
+
+	message := err.Error()
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		message = fmt.Sprintf("not confirmed within %s", timeout)
+	}
+	return &faucetpb.FollowRequestResponse{
+		Stage:       faucetpb.FollowRequestResponse_STAGE_FAILED,
+		Transaction: tx,
+		Error:       message,
+	}
+}
+
+// call serves a gRPC call with the REST route at method and path. The caller's
+// sign-in session and forwarding header are passed on from the call metadata,
+// and rate-limit headers come back as header metadata. A REST error becomes a
+// gRPC status carrying its error code; otherwise the JSON body is decoded into out.
+func (s *GRPCServer) call(ctx context.Context, method, path string, body, out any) error {
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	req.Header.SetMethod(method)
+	req.SetRequestURI(path)
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		req.Header.SetContentType(fiber.MIMEApplicationJSON)
+		req.SetBody(data)
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	forwarded := []string{fiber.HeaderAuthorization}
+	if s.handler.ipResolver != nil {
+		forwarded = append(forwarded, s.handler.ipResolver.Header())
+	}
+	for _, name := range forwarded {
+		if values := md.Get(name); len(values) > 0 {
+			req.Header.Set(name, values[0])
+		}
+	}
+
+	var fctx fasthttp.RequestCtx
+	if p, ok := peer.FromContext(ctx); ok {
+		fctx.Init(req, p.Addr, nil)
+	} else {
+		fctx.Init(req, nil, nil)
+	}
+	fctx.SetUserValue(parentContextKey{}, ctx)
+	s.routes(&fctx)
+	resp := &fctx.Response
+
+	header := metadata.MD{}
+	for _, name := range grpcResponseHeaders {
+		if value := resp.Header.Peek(name); len(value) > 0 {
+			header.Set(name, string(value))
+		}
+	}
+	if header.Len() > 0 {
+		_ = grpc.SetHeader(ctx, header)
+	}
+
+	if resp.StatusCode() >= fiber.StatusBadRequest {
+		return grpcError(resp)
+	}
+	if err := json.Unmarshal(resp.Body(), out); err != nil {
+		return status.Error(codes.Internal, "invalid response: "+err.Error())
+	}
+	return nil
+}
+
+// grpcError converts a REST error response into a gRPC status. The error code
+// is the reason of an ErrorInfo detail, whose metadata holds the error details;
+// rate-limited calls also carry a RetryInfo.
+func grpcError(resp *fasthttp.Response) error {
+	code := grpcCode(resp.StatusCode())
+
+	var body models.ErrorResponse
+	if err := json.Unmarshal(resp.Body(), &body); err != nil || body.Code == "" {
+		return status.Error(code, strings.TrimSpace(string(resp.Body())))
+	}
+
+	st := status.New(code, body.Error)
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason:   string(body.Code),
+		Domain:   grpcErrorDomain,
+		Metadata: errorMetadata(body),
+	}}
+	if seconds, err := strconv.Atoi(string(resp.Header.Peek(fiber.HeaderRetryAfter))); err == nil {
+		details = append(details, &errdetails.RetryInfo{
+			RetryDelay: durationpb.New(time.Duration(seconds) * time.Second),
+		})
+	}
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// errorMetadata flattens the details of an error response into ErrorInfo metadata
+func errorMetadata(body models.ErrorResponse) map[string]string {
+	md := map[string]string{}
+	if d := body.Details; d != nil {
+		if d.Limit != 0 {
+			md["limit"] = strconv.Itoa(d.Limit)
+		}
+		if d.Used != 0 {
+			md["used"] = strconv.Itoa(d.Used)
+		}
+		if d.Network != "" {
+			md["network"] = d.Network
+		}
+		if d.Token != "" {
+			md["token"] = d.Token
+		}
+		if d.Balance != 0 {
+			md["balance"] = strconv.FormatFloat(d.Balance, 'f', -1, 64)
+		}
+		if d.MaxBalance != 0 {
+			md["max_balance"] = strconv.FormatFloat(d.MaxBalance, 'f', -1, 64)
+		}
+	}
+	if body.NextRequestTime != nil {
+		md["next_request_time"] = body.NextRequestTime.UTC().Format(time.RFC3339)
+	}
+	if body.RemainingHours != nil {
+		md["remaining_hours"] = strconv.FormatFloat(*body.RemainingHours, 'f', -1, 64)
+	}
+	return md
+}
+
+// grpcCode maps an HTTP status onto the closest gRPC status code
+func grpcCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case fiber.StatusBadRequest:
+		return codes.InvalidArgument
+	case fiber.StatusUnauthorized:
+		return codes.Unauthenticated
+	case fiber.StatusForbidden:
+		return codes.PermissionDenied
+	case fiber.StatusNotFound, fiber.StatusMethodNotAllowed:
+		return codes.NotFound
+	case fiber.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case fiber.StatusBadGateway, fiber.StatusServiceUnavailable:
+		return codes.Unavailable
+	case fiber.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
+
+// timestamp converts an optional time into an optional protobuf timestamp
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
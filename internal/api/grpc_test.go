@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/config"
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/Giri-Aayush/starknet-faucet/pkg/faucetpb"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newGRPCClient(t *testing.T, h *Handler) faucetpb.FaucetServiceClient {
+	t.Helper()
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	SetupRoutes(app, h)
+
+	listener := bufconn.Listen(1 << 20)
+	server := NewGRPCServer(h, app)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return faucetpb.NewFaucetServiceClient(conn)
+}
+
+func TestGRPCSharesRESTErrors(t *testing.T) {
+	cfg := &config.Config{Server: config.ServerConfig{RequestTimeoutSec: 5}}
+	client := newGRPCClient(t, newTestHandler(cfg))
+
+	_, err := client.GetStatus(context.Background(), &faucetpb.GetStatusRequest{Address: "0x1", Network: "nope"})
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, string(models.ErrCodeUnsupportedNetwork), info.Reason)
+	assert.Equal(t, grpcErrorDomain, info.Domain)
+}
+
+func TestGRPCErrorRateLimited(t *testing.T) {
+	next := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	body, err := json.Marshal(models.ErrorResponse{
+		Code:            models.ErrCodeDailyLimit,
+		Error:           "Daily limit reached",
+		Details:         &models.ErrorDetails{Limit: 5, Used: 5},
+		NextRequestTime: &next,
+	})
+	require.NoError(t, err)
+
+	var resp fasthttp.Response
+	resp.SetStatusCode(fiber.StatusTooManyRequests)
+	resp.Header.Set(fiber.HeaderRetryAfter, "30")
+	resp.SetBody(body)
+
+	st := status.Convert(grpcError(&resp))
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	assert.Equal(t, "Daily limit reached", st.Message())
+
+	require.Len(t, st.Details(), 2)
+	info := st.Details()[0].(*errdetails.ErrorInfo)
+	assert.Equal(t, string(models.ErrCodeDailyLimit), info.Reason)
+	assert.Equal(t, map[string]string{
+		"limit":             "5",
+		"used":              "5",
+		"next_request_time": "2030-01-02T03:04:05Z",
+	}, info.Metadata)
+	retry := st.Details()[1].(*errdetails.RetryInfo)
+	assert.Equal(t, 30*time.Second, retry.RetryDelay.AsDuration())
+}
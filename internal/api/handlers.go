@@ -2,38 +2,99 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strconv"
+	"maps"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/gofiber/fiber/v2"
 	"github.com/Giri-Aayush/starknet-faucet/chains"
+	"github.com/Giri-Aayush/starknet-faucet/internal/apikey"
 	"github.com/Giri-Aayush/starknet-faucet/internal/cache"
+	"github.com/Giri-Aayush/starknet-faucet/internal/clientip"
 	"github.com/Giri-Aayush/starknet-faucet/internal/config"
+	"github.com/Giri-Aayush/starknet-faucet/internal/human"
+	"github.com/Giri-Aayush/starknet-faucet/internal/identity"
 	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/Giri-Aayush/starknet-faucet/internal/ownership"
 	"github.com/Giri-Aayush/starknet-faucet/internal/pow"
+	"github.com/Giri-Aayush/starknet-faucet/internal/testtoken"
+	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 )
 
 // ChainProvider provides chain-specific configuration.
 type ChainProvider interface {
 	GetDripAmount(token string) string
+	GetTokenAddress(token string) string
+	GetTokenDecimals(token string) int
 	GetMaxTokensPerHour(token string) float64
 	GetMaxTokensPerDay(token string) float64
+	GetMaxRecipientBalance(token string) float64
 	GetMinBalanceProtectPct() int
 	GetFaucetAddress() string
 }
 
 // Handler contains dependencies for API handlers
 type Handler struct {
-	config            *config.Config
-	logger            *zap.Logger
-	redis             *cache.RedisClient
-	chains            map[string]chains.Chain
-	providers         map[string]ChainProvider
-	powGenerator      *pow.Generator
-	defaultNetwork    string
+	settings       atomic.Pointer[handlerSettings]
+	logger         *zap.Logger
+	redis          *cache.RedisClient
+	chains         map[string]chains.Chain
+	powGenerator   *pow.Generator
+	balances       *cache.BalanceCache
+	humanVerifier  human.HumanVerifier
+	github         *identity.GitHubClient
+	sessions       *identity.SessionSigner
+	ipResolver     *clientip.Resolver
+	ownership      map[string]ownership.Verifier // by network
+	defaultNetwork string
+	transfers      sync.WaitGroup // in-flight transfers, drained on shutdown
+	eventStreams   streamCounter  // open request event streams, by client network
+	stats          statsCache     // last /stats response
+}
+
+// handlerSettings is the configuration a Handler serves with. It is replaced
+// as a whole when the configuration is reloaded.
+type handlerSettings struct {
+	config    *config.Config
+	providers map[string]ChainProvider
+	apiKeys   *apikey.Registry
+}
+
+// ApplyConfig makes the handler serve with a new global configuration and
+// chain providers. Requests already running finish with the settings they
+// read; the caller is responsible for rejecting changes that cannot apply live.
+func (h *Handler) ApplyConfig(cfg *config.Config, providers map[string]ChainProvider) {
+	h.settings.Store(&handlerSettings{
+		config:    cfg,
+		providers: providers,
+		apiKeys:   cfg.APIKeyRegistry(),
+	})
+}
+
+// config returns the global configuration currently served with
+func (h *Handler) config() *config.Config {
+	return h.settings.Load().config
+}
+
+// providers returns the chain providers currently served with, by network
+func (h *Handler) providers() map[string]ChainProvider {
+	return h.settings.Load().providers
+}
+
+// Providers returns a copy of the chain providers currently served with, by
+// network, for building the next set passed to ApplyConfig
+func (h *Handler) Providers() map[string]ChainProvider {
+	return maps.Clone(h.providers())
+}
+
+// apiKeys returns the registered API keys
+func (h *Handler) apiKeys() *apikey.Registry {
+	return h.settings.Load().apiKeys
 }
 
 // NewMultiChainHandler creates a new multi-chain API handler
@@ -59,15 +120,16 @@ func NewMultiChainHandler(
 		}
 	}
 
-	return &Handler{
-		config:         cfg,
+	h := &Handler{
 		logger:         logger,
 		redis:          redis,
 		chains:         chainRegistry,
-		providers:      providerRegistry,
 		powGenerator:   powGenerator,
+		balances:       cache.NewBalanceCache(cfg.BalanceMaxAge()),
 		defaultNetwork: defaultNetwork,
 	}
+	h.ApplyConfig(cfg, providerRegistry)
+	return h
 }
 
 // NewHandler creates a new API handler (backward compatible - single chain)
@@ -80,15 +142,16 @@ func NewHandler(
 	powGenerator *pow.Generator,
 ) *Handler {
 	chainName := chain.GetChainName()
-	return &Handler{
-		config:         cfg,
+	h := &Handler{
 		logger:         logger,
 		redis:          redis,
 		chains:         map[string]chains.Chain{chainName: chain},
-		providers:      map[string]ChainProvider{chainName: chainProvider},
 		powGenerator:   powGenerator,
+		balances:       cache.NewBalanceCache(cfg.BalanceMaxAge()),
 		defaultNetwork: chainName,
 	}
+	h.ApplyConfig(cfg, map[string]ChainProvider{chainName: chainProvider})
+	return h
 }
 
 // getChain returns the chain and provider for the given network
@@ -106,7 +169,7 @@ func (h *Handler) getChain(network string) (chains.Chain, ChainProvider, error)
 		return nil, nil, fmt.Errorf("unsupported network: %s (available: %s)", network, strings.Join(available, ", "))
 	}
 
-	provider, ok := h.providers[network]
+	provider, ok := h.providers()[network]
 	if !ok {
 		return nil, nil, fmt.Errorf("provider not found for network: %s", network)
 	}
@@ -114,50 +177,160 @@ func (h *Handler) getChain(network string) (chains.Chain, ChainProvider, error)
 	return chain, provider, nil
 }
 
+// normalizeAddress returns a canonical form of a hex address for comparison.
+// Checksummed EVM addresses differ only in case, and Starknet addresses may be
+// written with or without leading zero padding.
+func normalizeAddress(address string) string {
+	address = strings.ToLower(strings.TrimSpace(address))
+	address = strings.TrimPrefix(address, "0x")
+	return "0x" + strings.TrimLeft(address, "0")
+}
+
+// chainContext bounds a single chain RPC operation by the configured chain timeout
+func (h *Handler) chainContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, h.config().ChainTimeout())
+}
+
+// beginTransfer registers an in-flight transfer and returns a context detached
+// from the request's cancellation. Once tokens are being sent, neither a client
+// disconnect nor the request deadline may interrupt the broadcast or the quota
+// bookkeeping that follows it. The returned func must be called when done.
+func (h *Handler) beginTransfer(ctx context.Context) (context.Context, func()) {
+	h.transfers.Add(1)
+	return context.WithoutCancel(ctx), h.transfers.Done
+}
+
+// WaitForTransfers blocks until all in-flight transfers have finished or the
+// timeout elapses. Returns false if transfers were still running at the deadline.
+func (h *Handler) WaitForTransfers(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		h.transfers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
 // GetChallenge generates a new PoW challenge
 func (h *Handler) GetChallenge(c *fiber.Ctx) error {
-	ctx := context.Background()
+	ctx := c.UserContext()
+
+	// Parse request: the challenge is bound to the address, network and token it is for
+	var req models.ChallengeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeInvalidRequest,
+			Error: "Invalid request body: address, token and network are required",
+		})
+	}
+
+	network := req.Network
+	if network == "" {
+		network = h.defaultNetwork
+	}
+
+	chain, _, err := h.getChain(network)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeUnsupportedNetwork,
+			Error: err.Error(),
+		})
+	}
+
+	if err := chain.ValidateAddress(req.Address); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeInvalidAddress,
+			Error: fmt.Sprintf("Invalid address: %s", err.Error()),
+		})
+	}
+
+	req.Token = strings.ToUpper(req.Token)
+	if req.Token != "BOTH" {
+		if err := chain.ValidateToken(req.Token); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Code:    models.ErrCodeUnsupportedToken,
+				Error:   err.Error(),
+				Details: &models.ErrorDetails{Token: req.Token},
+			})
+		}
+	}
 
-	// Check challenge rate limit for this IP
-	ip := c.IP()
-	canRequest, err := h.redis.CheckChallengeRateLimit(ctx, ip)
+	// Check challenge rate limit for this IP's network
+	ip := h.clientIP(c)
+	canRequest, resetAt, err := h.redis.CheckChallengeRateLimit(ctx, h.ipKey(ip))
 	if err != nil {
 		h.logger.Error("Failed to check challenge rate limit", zap.Error(err))
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeInternal,
 			Error: "Failed to check rate limit",
 		})
 	}
 	if !canRequest {
-		return c.Status(fiber.StatusTooManyRequests).JSON(models.ErrorResponse{
-			Error: "[CHALLENGE LIMIT] Too many PoW challenge requests this hour. Try again later.",
+		h.recordPoWPenalty(ctx, ip)
+		return rateLimited(c, h.config().MaxChallengesPerHour(), 0, *resetAt, models.ErrorResponse{
+			Code:    models.ErrCodeChallengeLimit,
+			Error:   "[CHALLENGE LIMIT] Too many PoW challenge requests this hour. Try again later.",
+			Details: &models.ErrorDetails{Limit: h.config().MaxChallengesPerHour()},
 		})
 	}
 
-	// Generate challenge
-	response, challenge, err := h.powGenerator.GenerateChallenge()
+	// Generate challenge at a difficulty suited to current load and this IP's history
+	difficulty := h.challengeDifficulty(ctx, ip)
+	response, challenge, err := h.powGenerator.GenerateChallengeWithDifficulty(difficulty)
 	if err != nil {
 		h.logger.Error("Failed to generate challenge", zap.Error(err))
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeInternal,
 			Error: "Failed to generate challenge",
 		})
 	}
 
-	// Store challenge in Redis
-	ttl := time.Duration(h.config.ChallengeTTL()) * time.Second
-	if err := h.redis.StoreChallenge(ctx, challenge.ID, challenge.Challenge, ttl); err != nil {
+	// Store the challenge, or seal it into the ID in stateless mode
+	record := cache.ChallengeRecord{
+		Challenge:  challenge.Challenge,
+		Difficulty: challenge.Difficulty,
+		Bits:       challenge.Bits,
+		Algorithm:  challenge.Algorithm,
+		Params:     challenge.Params,
+		Address:    normalizeAddress(req.Address),
+		Network:    network,
+		Token:      req.Token,
+		ExpiresAt:  challenge.CreatedAt.Add(time.Duration(h.config().ChallengeTTL()) * time.Second).Unix(),
+	}
+	challengeID, err := h.saveChallenge(ctx, challenge, record)
+	if err != nil {
 		h.logger.Error("Failed to store challenge", zap.Error(err))
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeInternal,
 			Error: "Failed to store challenge",
 		})
 	}
+	response.ChallengeID = challengeID
+	response.RequestID = requestIDFor(challengeID)
+	issuedTTL := time.Duration(h.config().ChallengeTTL())*time.Second + h.config().RequestTimeout()
+	if err := h.redis.MarkRequestIssued(ctx, response.RequestID, issuedTTL); err != nil {
+		h.logger.Warn("Failed to record issued request", zap.Error(err))
+	}
+	if scoped, ok := h.ownership[network].(ownership.ChainScoped); ok {
+		response.SigningChainID = scoped.ChainID()
+	}
 
 	// Increment challenge rate limit counter
-	if err := h.redis.IncrementChallengeRateLimit(ctx, ip); err != nil {
+	if err := h.redis.IncrementChallengeRateLimit(ctx, h.ipKey(ip)); err != nil {
 		h.logger.Error("Failed to increment challenge rate limit", zap.Error(err))
 	}
 
 	h.logger.Info("Challenge generated",
 		zap.String("challenge_id", challenge.ID),
+		zap.Int("difficulty", challenge.Difficulty),
+		zap.String("network", network),
+		zap.String("token", req.Token),
 		zap.String("ip", ip),
 	)
 
@@ -166,20 +339,34 @@ func (h *Handler) GetChallenge(c *fiber.Ctx) error {
 
 // RequestTokens handles faucet requests
 func (h *Handler) RequestTokens(c *fiber.Ctx) error {
-	ctx := context.Background()
+	ctx := c.UserContext()
 
 	// Parse request
 	var req models.FaucetRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeInvalidRequest,
 			Error: "Invalid request body",
 		})
 	}
 
+	// Progress is recorded for /requests/:id/events once the request has a
+	// loaded challenge or a valid test token, so arbitrary IDs are never
+	// written. A refusal closes the stream once the challenge is spent;
+	// test-token requests are never retried.
+	var tracker *requestTracker
+	defer func() {
+		if tracker != nil {
+			tracker.settle(c)
+		}
+	}()
+	defer h.recordOutcome(c)
+
 	// Get the chain for the specified network
 	chain, chainProvider, err := h.getChain(req.Network)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeUnsupportedNetwork,
 			Error: err.Error(),
 		})
 	}
@@ -187,6 +374,7 @@ func (h *Handler) RequestTokens(c *fiber.Ctx) error {
 	// Validate address using chain-specific validation
 	if err := chain.ValidateAddress(req.Address); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeInvalidAddress,
 			Error: fmt.Sprintf("Invalid address: %s", err.Error()),
 		})
 	}
@@ -196,39 +384,22 @@ func (h *Handler) RequestTokens(c *fiber.Ctx) error {
 	if req.Token != "BOTH" {
 		if err := chain.ValidateToken(req.Token); err != nil {
 			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-				Error: err.Error(),
+				Code:    models.ErrCodeUnsupportedToken,
+				Error:   err.Error(),
+				Details: &models.ErrorDetails{Token: req.Token},
 			})
 		}
 	}
 
 	// NEW SIMPLIFIED RATE LIMITING
-	ip := c.IP()
+	ip := h.clientIP(c)
 
-	// 1. Check IP daily limit (5 requests/day) and 24h cooldown
-	canRequest, currentCount, cooldownEnd, err := h.redis.CheckIPDailyLimit(ctx, ip)
+	// Quotas follow the signed-in account if there is one, otherwise the IP
+	quotas, err := h.requestQuotas(c)
 	if err != nil {
-		h.logger.Error("Failed to check IP daily limit", zap.Error(err))
-		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error: "Failed to check rate limit",
-		})
-	}
-
-	// If in 24h cooldown after hitting limit
-	if !canRequest && cooldownEnd != nil {
-		remaining := time.Until(*cooldownEnd)
-		hours := int(remaining.Hours())
-		minutes := int(remaining.Minutes()) % 60
-		var timeStr string
-		if hours > 0 {
-			timeStr = fmt.Sprintf("%dh %dm", hours, minutes)
-		} else {
-			timeStr = fmt.Sprintf("%dm", minutes)
-		}
-		errorMsg := fmt.Sprintf("[DAILY LIMIT] You've used all 5 daily requests. 24-hour cooldown: %s remaining.", timeStr)
-		return c.Status(fiber.StatusTooManyRequests).JSON(models.ErrorResponse{
-			Error: errorMsg,
-		})
+		return sessionError(c, err)
 	}
+	subject := quotas[0]
 
 	// Calculate how many requests this will consume (1 for single token, 2 for BOTH)
 	requestCost := 1
@@ -236,30 +407,73 @@ func (h *Handler) RequestTokens(c *fiber.Ctx) error {
 		requestCost = 2
 	}
 
-	// Check if there's enough quota
-	if !canRequest || (currentCount+requestCost) > h.config.MaxRequestsPerDayIP() {
-		used, _, _, _ := h.redis.GetIPDailyQuota(ctx, ip)
-		errorMsg := fmt.Sprintf("[DAILY LIMIT] Request would exceed daily limit (%d/%d used). Wait for quota reset.",
-			used, h.config.MaxRequestsPerDayIP())
-		return c.Status(fiber.StatusTooManyRequests).JSON(models.ErrorResponse{
-			Error: errorMsg,
-		})
-	}
-
-	// 2. Check per-token hourly throttle (per-network: Starknet ETH and Ethereum ETH have separate throttles)
 	network := req.Network
 	if network == "" {
 		network = h.defaultNetwork
 	}
 
+	// 1. Check daily limits and 24h cooldown. Every layer (per-IP, subnet) must allow the request.
+	for _, quota := range quotas {
+		label, who := "DAILY LIMIT", "You've"
+		cooldownCode, limitCode := models.ErrCodeDailyCooldown, models.ErrCodeDailyLimit
+		if quota.Kind == "subnet" {
+			label, who = "SUBNET LIMIT", "Your network has"
+			cooldownCode, limitCode = models.ErrCodeSubnetLimit, models.ErrCodeSubnetLimit
+		}
+
+		canRequest, currentCount, cooldownEnd, err := h.redis.CheckDailyLimit(ctx, quota)
+		if err != nil {
+			h.logger.Error("Failed to check daily limit", zap.Error(err))
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Code:  models.ErrCodeInternal,
+				Error: "Failed to check rate limit",
+			})
+		}
+
+		// If in 24h cooldown after hitting limit
+		if !canRequest && cooldownEnd != nil {
+			remaining := time.Until(*cooldownEnd)
+			hours := int(remaining.Hours())
+			minutes := int(remaining.Minutes()) % 60
+			var timeStr string
+			if hours > 0 {
+				timeStr = fmt.Sprintf("%dh %dm", hours, minutes)
+			} else {
+				timeStr = fmt.Sprintf("%dm", minutes)
+			}
+			errorMsg := fmt.Sprintf("[%s] %s used all %d daily requests. 24-hour cooldown: %s remaining.", label, who, quota.DailyLimit, timeStr)
+			h.recordPoWPenalty(ctx, ip)
+			return rateLimited(c, quota.DailyLimit, 0, *cooldownEnd, models.ErrorResponse{
+				Code:    cooldownCode,
+				Error:   errorMsg,
+				Details: &models.ErrorDetails{Limit: quota.DailyLimit, Used: quota.DailyLimit, Network: network, Token: req.Token},
+			})
+		}
+
+		// Check if there's enough quota
+		if !canRequest || (currentCount+requestCost) > quota.DailyLimit {
+			used, remaining, _, _ := h.redis.GetDailyQuota(ctx, quota)
+			errorMsg := fmt.Sprintf("[%s] Request would exceed daily limit (%d/%d used). Wait for quota reset.",
+				label, used, quota.DailyLimit)
+			h.recordPoWPenalty(ctx, ip)
+			return rateLimited(c, quota.DailyLimit, remaining, h.redis.DailyLimitReset(ctx, quota), models.ErrorResponse{
+				Code:    limitCode,
+				Error:   errorMsg,
+				Details: &models.ErrorDetails{Limit: quota.DailyLimit, Used: used, Network: network, Token: req.Token},
+			})
+		}
+	}
+
+	// 2. Check per-token hourly throttle (per-network: Starknet ETH and Ethereum ETH have separate throttles)
 	if req.Token == "BOTH" {
 		// For BOTH, check all supported tokens for this network
 		supportedTokens := chain.GetSupportedTokens()
 		for _, token := range supportedTokens {
-			canRequestToken, nextTime, err := h.redis.CheckTokenHourlyThrottle(ctx, ip, network, token)
+			canRequestToken, nextTime, err := h.redis.CheckTokenHourlyThrottle(ctx, subject, network, token)
 			if err != nil {
 				h.logger.Error("Failed to check token throttle", zap.Error(err), zap.String("token", token))
 				return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+					Code:  models.ErrCodeInternal,
 					Error: "Failed to check rate limit",
 				})
 			}
@@ -267,17 +481,21 @@ func (h *Handler) RequestTokens(c *fiber.Ctx) error {
 				minutesRemaining := int(time.Until(*nextTime).Minutes()) + 1 // +1 to round up
 				errorMsg := fmt.Sprintf("[HOURLY LIMIT] %s on %s: 1 request per hour. Try again in %d minutes.",
 					token, network, minutesRemaining)
-				return c.Status(fiber.StatusTooManyRequests).JSON(models.ErrorResponse{
-					Error: errorMsg,
+				h.recordPoWPenalty(ctx, ip)
+				return rateLimited(c, 1, 0, *nextTime, models.ErrorResponse{
+					Code:    models.ErrCodeHourlyLimit,
+					Error:   errorMsg,
+					Details: &models.ErrorDetails{Limit: 1, Used: 1, Network: network, Token: token},
 				})
 			}
 		}
 	} else {
 		// For single token, check that token's throttle on this network
-		canRequestToken, nextAvailable, err := h.redis.CheckTokenHourlyThrottle(ctx, ip, network, req.Token)
+		canRequestToken, nextAvailable, err := h.redis.CheckTokenHourlyThrottle(ctx, subject, network, req.Token)
 		if err != nil {
 			h.logger.Error("Failed to check token throttle", zap.Error(err), zap.String("token", req.Token))
 			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Code:  models.ErrCodeInternal,
 				Error: "Failed to check rate limit",
 			})
 		}
@@ -285,97 +503,164 @@ func (h *Handler) RequestTokens(c *fiber.Ctx) error {
 			minutesRemaining := int(time.Until(*nextAvailable).Minutes()) + 1 // +1 to round up
 			errorMsg := fmt.Sprintf("[HOURLY LIMIT] %s on %s: 1 request per hour. Try again in %d minutes.",
 				req.Token, network, minutesRemaining)
-			return c.Status(fiber.StatusTooManyRequests).JSON(models.ErrorResponse{
-				Error: errorMsg,
+			h.recordPoWPenalty(ctx, ip)
+			return rateLimited(c, 1, 0, *nextAvailable, models.ErrorResponse{
+				Code:    models.ErrCodeHourlyLimit,
+				Error:   errorMsg,
+				Details: &models.ErrorDetails{Limit: 1, Used: 1, Network: network, Token: req.Token},
 			})
 		}
 	}
 
-	// Verify challenge exists
-	storedChallenge, err := h.redis.GetChallenge(ctx, req.ChallengeID)
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error: "Invalid or expired challenge",
-		})
+	// Human verification; test tokens stand in for it as they do for PoW
+	if req.TestToken == "" && h.config().RequiresHumanVerification(network, h.requestTier(c)) {
+		if status, body := h.verifyHuman(ctx, req.CaptchaToken, ip); status != 0 {
+			return c.Status(status).JSON(body)
+		}
 	}
 
-	// Verify PoW solution
-	if !h.powGenerator.VerifyPoW(storedChallenge, req.Nonce, h.config.PoWDifficulty()) {
-		h.logger.Warn("Invalid PoW solution",
-			zap.String("challenge_id", req.ChallengeID),
-			zap.Int64("nonce", req.Nonce),
+	if req.TestToken != "" {
+		// Signed test tokens stand in for the PoW challenge in automated tests
+		claims, err := testtoken.Verify([]byte(h.config().TestTokenSecret), req.TestToken, network, time.Now())
+		if err != nil {
+			h.logger.Warn("Invalid test token",
+				zap.String("network", network),
+				zap.String("ip", ip),
+				zap.Error(err),
+			)
+			h.recordPoWPenalty(ctx, ip)
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse{
+				Code:  models.ErrCodeInvalidTestToken,
+				Error: "Invalid or expired test token",
+			})
+		}
+		h.logger.Info("Test token accepted",
+			zap.String("token_id", claims.ID),
+			zap.String("network", network),
 			zap.String("ip", ip),
 		)
-		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error: "Invalid proof of work solution",
-		})
-	}
+		tracker = h.trackRequest(newRequestID())
+		tracker.spent = true
+	} else {
+		// Verify challenge exists
+		storedChallenge, err := h.loadChallenge(ctx, req.ChallengeID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Code:  models.ErrCodeInvalidChallenge,
+				Error: "Invalid or expired challenge",
+			})
+		}
+		tracker = h.trackRequest(requestIDFor(req.ChallengeID))
+
+		// The solution may only be spent on the request the challenge was issued for
+		if !challengeMatches(storedChallenge, req.Address, network, req.Token) {
+			h.logger.Warn("Challenge parameters mismatch",
+				zap.String("challenge_id", req.ChallengeID),
+				zap.String("network", network),
+				zap.String("token", req.Token),
+				zap.String("ip", ip),
+			)
+			h.recordPoWPenalty(ctx, ip)
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Code:  models.ErrCodeChallengeMismatch,
+				Error: "Challenge was issued for a different address, network or token",
+			})
+		}
 
-	// Delete challenge to prevent reuse
-	if err := h.redis.DeleteChallenge(ctx, req.ChallengeID); err != nil {
-		h.logger.Error("Failed to delete challenge", zap.Error(err))
-	}
+		// Each challenge gets a few attempts, counted before the hash is computed
+		allowed, err := h.countAttempt(ctx, storedChallenge)
+		if err != nil {
+			h.logger.Error("Failed to count challenge attempt", zap.Error(err))
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Code:  models.ErrCodeInternal,
+				Error: "Failed to verify challenge",
+			})
+		}
+		if !allowed {
+			tracker.spent = true
+			h.recordPoWPenalty(ctx, ip)
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Code:  models.ErrCodeInvalidChallenge,
+				Error: fmt.Sprintf("Challenge has had %d invalid solutions; request a new one", maxChallengeAttempts),
+			})
+		}
 
-	// Handle BOTH token request
-	if req.Token == "BOTH" {
-		return h.handleBothTokensRequest(c, ctx, req, ip, chain, chainProvider)
-	}
+		// Verify PoW solution at the difficulty this challenge was issued with
+		valid, err := h.powGenerator.VerifyPoWContext(ctx, storedChallenge.Algorithm, storedChallenge.Params, storedChallenge.Challenge, req.Nonce, storedChallenge.Bits)
+		if err != nil {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(models.ErrorResponse{
+				Code:  models.ErrCodeUnavailable,
+				Error: "Too many solutions are being verified; try again shortly",
+			})
+		}
+		if !valid {
+			h.logger.Warn("Invalid PoW solution",
+				zap.String("challenge_id", req.ChallengeID),
+				zap.Int64("nonce", req.Nonce),
+				zap.Int("difficulty_bits", storedChallenge.Bits),
+				zap.String("ip", ip),
+			)
+			h.recordPoWPenalty(ctx, ip)
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Code:  models.ErrCodeInvalidProofOfWork,
+				Error: "Invalid proof of work solution",
+			})
+		}
 
-	// Determine amount (single token) using chain provider
-	amountStr := chainProvider.GetDripAmount(req.Token)
-	amountFloat, _ := strconv.ParseFloat(amountStr, 64)
-	maxHourly := chainProvider.GetMaxTokensPerHour(req.Token)
-	maxDaily := chainProvider.GetMaxTokensPerDay(req.Token)
+		// Prove the requester owns the recipient, when required or offered
+		if status, body := h.verifyOwnership(ctx, network, req.Address, storedChallenge.Challenge, req.Signature, ip); status != 0 {
+			return c.Status(status).JSON(body)
+		}
 
-	// Check global distribution limits (anti-drain protection)
-	canDistribute, err := h.redis.TrackGlobalDistribution(ctx, req.Token, amountFloat, maxHourly, maxDaily)
-	if err != nil {
-		h.logger.Error("Failed to check global distribution limits", zap.Error(err))
-		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error: "Failed to process request",
-		})
-	}
-	if !canDistribute {
-		h.logger.Warn("Global distribution limit reached",
-			zap.String("token", req.Token),
-			zap.String("ip", ip),
-		)
-		return c.Status(fiber.StatusServiceUnavailable).JSON(models.ErrorResponse{
-			Error: "[FAUCET LIMIT] Faucet has temporarily reached its distribution limit. Please try again in an hour.",
-		})
+		// Consume challenge to prevent reuse
+		if err := h.consumeChallenge(ctx, req.ChallengeID, storedChallenge); err != nil {
+			if errors.Is(err, errChallengeSpent) {
+				tracker.spent = true
+				h.recordPoWPenalty(ctx, ip)
+				return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+					Code:  models.ErrCodeInvalidChallenge,
+					Error: "Invalid or expired challenge",
+				})
+			}
+			// A challenge that may not have been consumed could be replayed
+			h.logger.Error("Failed to consume challenge", zap.Error(err))
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Code:  models.ErrCodeInternal,
+				Error: "Failed to verify challenge",
+			})
+		}
+		tracker.spent = true
 	}
 
-	// Check minimum balance protection (stop at configured percentage)
-	currentBalance, err := chain.GetBalance(ctx, chainProvider.GetFaucetAddress(), req.Token)
-	if err != nil {
-		h.logger.Error("Failed to check faucet balance", zap.Error(err))
-		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error: "Failed to check faucet balance",
-		})
+	// Refuse recipients that already hold plenty of test tokens
+	if status, body := h.checkRecipientBalance(ctx, network, chain, chainProvider, req.Address, req.Token); status != 0 {
+		return c.Status(status).JSON(body)
 	}
+	tracker.emit(models.RequestEvent{Stage: models.StageVerified})
 
-	// Convert amount to wei for comparison
-	amountWei := chains.AmountToWei(amountFloat)
-
-	// Check if balance would drop below minimum threshold
-	minBalancePct := float64(chainProvider.GetMinBalanceProtectPct()) / 100.0
-	currentBalanceFloat := chains.WeiToAmount(currentBalance)
-	minBalanceRequired := currentBalanceFloat * minBalancePct
-	balanceAfterTransfer := currentBalanceFloat - amountFloat
+	// Handle BOTH token request
+	if req.Token == "BOTH" {
+		return h.handleBothTokensRequest(c, ctx, req, ip, quotas, chain, chainProvider, tracker)
+	}
 
-	if balanceAfterTransfer < minBalanceRequired {
-		h.logger.Warn("Balance protection triggered",
-			zap.String("token", req.Token),
-			zap.Float64("current_balance", currentBalanceFloat),
-			zap.Float64("min_balance_required", minBalanceRequired),
+	// Don't start a transfer for a client that has already gone away
+	if ctx.Err() != nil {
+		h.logger.Warn("Request cancelled before transfer",
+			zap.Error(ctx.Err()),
+			zap.String("recipient", req.Address),
 			zap.String("ip", ip),
 		)
 		return c.Status(fiber.StatusServiceUnavailable).JSON(models.ErrorResponse{
-			Error: fmt.Sprintf("[LOW BALANCE] Faucet %s balance too low (%.4f). Please try again later.", req.Token, currentBalanceFloat),
+			Code:  models.ErrCodeRequestCancelled,
+			Error: "Request cancelled before tokens were sent. Please try again.",
 		})
 	}
 
-	// Transfer tokens
+	transferCtx, endTransfer := h.beginTransfer(ctx)
+	defer endTransfer()
+
+	// Transfer tokens through the distribution limits and balance protection
+	amountStr := chainProvider.GetDripAmount(req.Token)
 	h.logger.Info("Transferring tokens",
 		zap.String("network", req.Network),
 		zap.String("recipient", req.Address),
@@ -383,41 +668,45 @@ func (h *Handler) RequestTokens(c *fiber.Ctx) error {
 		zap.String("amount", amountStr),
 		zap.String("ip", ip),
 	)
-
-	txHash, err := chain.TransferTokens(ctx, req.Address, req.Token, amountWei)
-	if err != nil {
-		h.logger.Error("Failed to transfer tokens",
-			zap.Error(err),
-			zap.String("recipient", req.Address),
-			zap.String("token", req.Token),
-		)
-		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error: "Failed to send tokens. Please try again later.",
-		})
+	tx, status, body := h.disburse(transferCtx, disbursement{
+		Network:   network,
+		Chain:     chain,
+		Provider:  chainProvider,
+		Recipient: req.Address,
+		Token:     req.Token,
+		Amount:    amountStr,
+		Tracker:   tracker,
+	})
+	if status != 0 {
+		return errorResponse(c, status, body)
 	}
 
-	// Increment IP daily counter (1 for single token)
-	if err := h.redis.IncrementIPDailyLimit(ctx, ip, 1); err != nil {
-		h.logger.Error("Failed to increment IP daily limit", zap.Error(err))
+	// Increment daily counters (1 for single token)
+	for _, quota := range quotas {
+		if err := h.redis.IncrementDailyLimit(transferCtx, quota, 1); err != nil {
+			h.logger.Error("Failed to increment daily limit", zap.Error(err), zap.String("kind", quota.Kind))
+		}
 	}
 
 	// Set token hourly throttle (1 hour cooldown for this token on this network)
-	if err := h.redis.SetTokenHourlyThrottle(ctx, ip, network, req.Token); err != nil {
+	if err := h.redis.SetTokenHourlyThrottle(transferCtx, subject, network, req.Token); err != nil {
 		h.logger.Error("Failed to set token throttle", zap.Error(err))
 	}
 
 	// Build response
 	response := models.FaucetResponse{
 		Success:     true,
-		TxHash:      txHash,
-		Amount:      amountStr,
-		Token:       req.Token,
-		ExplorerURL: chain.GetExplorerURL(txHash),
+		TxHash:      tx.TxHash,
+		Amount:      tx.Amount,
+		Token:       tx.Token,
+		ExplorerURL: tx.ExplorerURL,
 		Message:     "Tokens sent successfully",
+		RequestID:   tracker.id,
 	}
+	tracker.follow(chain, []models.TransactionInfo{tx})
 
 	h.logger.Info("Tokens sent successfully",
-		zap.String("tx_hash", txHash),
+		zap.String("tx_hash", tx.TxHash),
 		zap.String("recipient", req.Address),
 		zap.String("token", req.Token),
 	)
@@ -427,7 +716,7 @@ func (h *Handler) RequestTokens(c *fiber.Ctx) error {
 
 // GetStatus returns the status of an address
 func (h *Handler) GetStatus(c *fiber.Ctx) error {
-	ctx := context.Background()
+	ctx := c.UserContext()
 
 	address := c.Params("address")
 	network := c.Query("network", h.defaultNetwork)
@@ -436,6 +725,7 @@ func (h *Handler) GetStatus(c *fiber.Ctx) error {
 	chain, _, err := h.getChain(network)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeUnsupportedNetwork,
 			Error: err.Error(),
 		})
 	}
@@ -443,18 +733,25 @@ func (h *Handler) GetStatus(c *fiber.Ctx) error {
 	// Validate address using chain-specific validation
 	if err := chain.ValidateAddress(address); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeInvalidAddress,
 			Error: fmt.Sprintf("Invalid address: %s", err.Error()),
 		})
 	}
 
 	// Get IP from request
-	ip := c.IP()
+	ip := h.clientIP(c)
+
+	quotas, err := h.requestQuotas(c)
+	if err != nil {
+		return sessionError(c, err)
+	}
 
-	// Get IP daily quota
-	used, remaining, cooldownEnd, err := h.redis.GetIPDailyQuota(ctx, ip)
+	// Get the tightest daily quota
+	_, used, remaining, cooldownEnd, err := h.dailyQuota(ctx, quotas)
 	if err != nil {
-		h.logger.Error("Failed to get IP daily quota", zap.Error(err))
+		h.logger.Error("Failed to get daily quota", zap.Error(err))
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeInternal,
 			Error: "Failed to check status",
 		})
 	}
@@ -478,13 +775,14 @@ func (h *Handler) GetStatus(c *fiber.Ctx) error {
 
 // GetInfo returns information about the faucet
 func (h *Handler) GetInfo(c *fiber.Ctx) error {
-	ctx := context.Background()
+	ctx := c.UserContext()
 	network := c.Query("network", h.defaultNetwork)
 
 	// Get the chain for the specified network
 	chain, chainProvider, err := h.getChain(network)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeUnsupportedNetwork,
 			Error: err.Error(),
 		})
 	}
@@ -495,16 +793,17 @@ func (h *Handler) GetInfo(c *fiber.Ctx) error {
 	// Get faucet balances for each supported token
 	balances := make(map[string]string)
 	for _, token := range supportedTokens {
-		balance, err := chain.GetBalance(ctx, chainProvider.GetFaucetAddress(), token)
+		balance, err := h.faucetBalance(ctx, network, chain, chainProvider, token)
 		if err != nil {
 			h.logger.Error("Failed to get balance", zap.Error(err), zap.String("token", token))
 			balances[token] = "0"
 		} else {
-			// Use different precision for different tokens
+			// v1 keeps its fixed precisions; v2 reports exact balances
+			amount := chains.FromBaseUnits(balance, chainProvider.GetTokenDecimals(token))
 			if token == "ETH" {
-				balances[token] = fmt.Sprintf("%.4f", chains.WeiToAmount(balance))
+				balances[token] = fmt.Sprintf("%.4f", amount)
 			} else {
-				balances[token] = fmt.Sprintf("%.2f", chains.WeiToAmount(balance))
+				balances[token] = fmt.Sprintf("%.2f", amount)
 			}
 		}
 	}
@@ -526,108 +825,106 @@ func (h *Handler) GetInfo(c *fiber.Ctx) error {
 	for name := range h.chains {
 		availableNetworks = append(availableNetworks, name)
 	}
+	// Stable order keeps the response body, and so its ETag, deterministic
+	sort.Strings(availableNetworks)
+
+	minDifficulty, maxDifficulty := h.powGenerator.DifficultyRange()
 
 	response := models.InfoResponse{
 		Network: chain.GetNetworkName(),
 		Limits: models.LimitInfo{
 			StrkPerRequest:     chainProvider.GetDripAmount("STRK"),
 			EthPerRequest:      chainProvider.GetDripAmount("ETH"),
-			DailyRequestsPerIP: h.config.MaxRequestsPerDayIP(),
-			TokenThrottleHours: 1, // 1 hour throttle per token
+			DailyRequestsPerIP: h.config().MaxRequestsPerDayIP(),
+			TokenThrottleHours: int(cache.TokenThrottleWindow.Hours()),
 		},
 		PoW: models.PoWInfo{
-			Enabled:    true,
-			Difficulty: h.config.PoWDifficulty(),
+			Enabled:       true,
+			Algorithm:     h.powGenerator.Algorithm(),
+			Format:        h.powGenerator.DifficultyFormat(),
+			Difficulty:    h.config().PoWDifficulty(),
+			MinDifficulty: minDifficulty,
+			MaxDifficulty: maxDifficulty,
 		},
 		FaucetBalance:     balanceInfo,
 		AvailableNetworks: availableNetworks,
+		OwnershipProof:    h.config().RequiresOwnershipProof(network),
+	}
+	if hv := h.config().HumanVerification; hv.Provider != "" {
+		response.HumanVerification = &models.HumanVerificationInfo{
+			Provider: hv.Provider,
+			SiteKey:  hv.SiteKey,
+			Required: h.config().RequiresHumanVerification(network, tierAnonymous),
+		}
 	}
 
+	// Balances come from the cache, so clients may reuse this briefly;
+	// the ETag middleware on this route handles conditional requests
+	c.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", h.config().InfoMaxAge()))
+
 	return c.JSON(response)
 }
 
 // handleBothTokensRequest handles requests for both STRK and ETH tokens
-func (h *Handler) handleBothTokensRequest(c *fiber.Ctx, ctx context.Context, req models.FaucetRequest, ip string, chain chains.Chain, chainProvider ChainProvider) error {
+func (h *Handler) handleBothTokensRequest(c *fiber.Ctx, ctx context.Context, req models.FaucetRequest, ip string, quotas []cache.Subject, chain chains.Chain, chainProvider ChainProvider, tracker *requestTracker) error {
 	// Process all supported tokens for this chain
 	tokens := chain.GetSupportedTokens()
 	var transactions []models.TransactionInfo
 	var failedToken string
+	var failedStatus int
+	var failure models.ErrorResponse
 
-	for _, token := range tokens {
-		// Determine amount using chain provider
-		amountStr := chainProvider.GetDripAmount(token)
-		amountFloat, _ := strconv.ParseFloat(amountStr, 64)
-		maxHourly := chainProvider.GetMaxTokensPerHour(token)
-		maxDaily := chainProvider.GetMaxTokensPerDay(token)
-
-		// Check global distribution limits
-		canDistribute, err := h.redis.TrackGlobalDistribution(ctx, token, amountFloat, maxHourly, maxDaily)
-		if err != nil {
-			h.logger.Error("Failed to check global distribution limits", zap.Error(err), zap.String("token", token))
-			failedToken = token
-			break
-		}
-		if !canDistribute {
-			h.logger.Warn("Global distribution limit reached", zap.String("token", token), zap.String("ip", ip))
-			failedToken = token
-			break
-		}
-
-		// Check minimum balance protection
-		currentBalance, err := chain.GetBalance(ctx, chainProvider.GetFaucetAddress(), token)
-		if err != nil {
-			h.logger.Error("Failed to check faucet balance", zap.Error(err), zap.String("token", token))
-			failedToken = token
-			break
-		}
+	network := req.Network
+	if network == "" {
+		network = h.defaultNetwork
+	}
 
-		amountWei := chains.AmountToWei(amountFloat)
-		minBalancePct := float64(chainProvider.GetMinBalanceProtectPct()) / 100.0
-		currentBalanceFloat := chains.WeiToAmount(currentBalance)
-		minBalanceRequired := currentBalanceFloat * minBalancePct
-		balanceAfterTransfer := currentBalanceFloat - amountFloat
+	transferCtx, endTransfer := h.beginTransfer(ctx)
+	defer endTransfer()
 
-		if balanceAfterTransfer < minBalanceRequired {
-			h.logger.Warn("Balance protection triggered", zap.String("token", token), zap.Float64("current_balance", currentBalanceFloat))
-			failedToken = token
+	for _, token := range tokens {
+		// Stop before the next transfer if the client has gone away
+		if ctx.Err() != nil {
+			h.logger.Warn("Request cancelled before transfer", zap.Error(ctx.Err()), zap.String("token", token))
+			failedToken, failedStatus = token, fiber.StatusServiceUnavailable
+			failure = models.ErrorResponse{
+				Code:  models.ErrCodeRequestCancelled,
+				Error: "Request cancelled before tokens were sent. Please try again.",
+			}
 			break
 		}
 
-		// Transfer tokens
-		h.logger.Info("Transferring tokens", zap.String("recipient", req.Address), zap.String("token", token), zap.String("amount", amountStr))
-
-		txHash, err := chain.TransferTokens(ctx, req.Address, token, amountWei)
-		if err != nil {
-			h.logger.Error("Failed to transfer tokens", zap.Error(err), zap.String("token", token))
-			failedToken = token
+		h.logger.Info("Transferring tokens", zap.String("recipient", req.Address), zap.String("token", token))
+		tx, status, body := h.disburse(transferCtx, disbursement{
+			Network:   network,
+			Chain:     chain,
+			Provider:  chainProvider,
+			Recipient: req.Address,
+			Token:     token,
+			Amount:    chainProvider.GetDripAmount(token),
+			Tracker:   tracker,
+		})
+		if status != 0 {
+			failedToken, failedStatus, failure = token, status, body
 			break
 		}
+		transactions = append(transactions, tx)
 
-		// Add to transactions list
-		transactions = append(transactions, models.TransactionInfo{
-			Token:       token,
-			Amount:      amountStr,
-			TxHash:      txHash,
-			ExplorerURL: chain.GetExplorerURL(txHash),
-		})
-
-		h.logger.Info("Tokens sent successfully", zap.String("tx_hash", txHash), zap.String("token", token))
+		h.logger.Info("Tokens sent successfully", zap.String("tx_hash", tx.TxHash), zap.String("token", token))
 	}
 
 	// If any token failed and we have partial success, still return success with what worked
 	if len(transactions) > 0 {
-		// Increment IP daily counter by 2 (BOTH = 1 STRK + 1 ETH)
-		if err := h.redis.IncrementIPDailyLimit(ctx, ip, 2); err != nil {
-			h.logger.Error("Failed to increment IP daily limit", zap.Error(err))
+		// Increment daily counters by 2 (BOTH = 1 STRK + 1 ETH)
+		for _, quota := range quotas {
+			if err := h.redis.IncrementDailyLimit(transferCtx, quota, 2); err != nil {
+				h.logger.Error("Failed to increment daily limit", zap.Error(err), zap.String("kind", quota.Kind))
+			}
 		}
 
 		// Set hourly throttle for both tokens on this network
-		network := req.Network
-		if network == "" {
-			network = h.defaultNetwork
-		}
 		for _, tx := range transactions {
-			if err := h.redis.SetTokenHourlyThrottle(ctx, ip, network, tx.Token); err != nil {
+			if err := h.redis.SetTokenHourlyThrottle(transferCtx, quotas[0], network, tx.Token); err != nil {
 				h.logger.Error("Failed to set token throttle", zap.Error(err), zap.String("token", tx.Token))
 			}
 		}
@@ -635,64 +932,76 @@ func (h *Handler) handleBothTokensRequest(c *fiber.Ctx, ctx context.Context, req
 		message := "Both tokens sent successfully"
 		if failedToken != "" {
 			message = fmt.Sprintf("Sent %d token(s) successfully, but %s failed", len(transactions), failedToken)
+			tracker.emit(models.RequestEvent{
+				Stage: models.StageFailed,
+				Token: failedToken,
+				Code:  failure.Code,
+				Error: failure.Error,
+			})
 		}
+		tracker.follow(chain, transactions)
 
 		return c.JSON(models.FaucetResponse{
 			Success:      true,
 			Transactions: transactions,
 			Message:      message,
+			RequestID:    tracker.id,
 		})
 	}
 
-	// If no transactions succeeded, return error
-	return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-		Error: fmt.Sprintf("Failed to send %s tokens. Please try again later.", failedToken),
-	})
+	// If no transactions succeeded, return why the first token failed
+	return errorResponse(c, failedStatus, failure)
 }
 
-// GetQuota returns the current rate limit quota for the requesting IP
+// GetQuota returns the current rate limit quota for the requesting IP or signed-in account
 func (h *Handler) GetQuota(c *fiber.Ctx) error {
-	ctx := context.Background()
-	ip := c.IP()
+	ctx := c.UserContext()
+
+	quotas, err := h.requestQuotas(c)
+	if err != nil {
+		return sessionError(c, err)
+	}
 
-	// Get IP daily quota (global across all networks)
-	used, remaining, cooldownEnd, err := h.redis.GetIPDailyQuota(ctx, ip)
+	// Get the tightest daily quota (global across all networks)
+	limit, used, remaining, cooldownEnd, err := h.dailyQuota(ctx, quotas)
 	if err != nil {
-		h.logger.Error("Failed to get IP daily quota", zap.Error(err))
+		h.logger.Error("Failed to get daily quota", zap.Error(err))
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeInternal,
 			Error: "Failed to get quota",
 		})
 	}
 
 	// Build per-network throttle status
-	networkThrottles := make(map[string]map[string]interface{})
+	networkThrottles := make(map[string]map[string]models.TokenThrottle)
 	for networkName, chain := range h.chains {
 		tokens := chain.GetSupportedTokens()
-		tokenThrottles := make(map[string]interface{})
+		tokenThrottles := make(map[string]models.TokenThrottle)
 
 		for _, token := range tokens {
-			available, nextTime, err := h.redis.CheckTokenHourlyThrottle(ctx, ip, networkName, token)
+			available, nextTime, err := h.redis.CheckTokenHourlyThrottle(ctx, quotas[0], networkName, token)
 			if err != nil {
 				h.logger.Error("Failed to check token throttle", zap.Error(err), zap.String("network", networkName), zap.String("token", token))
 				continue
 			}
-			tokenThrottles[strings.ToLower(token)] = map[string]interface{}{
-				"available":       available,
-				"next_request_at": nextTime,
+			tokenThrottles[strings.ToLower(token)] = models.TokenThrottle{
+				Available:     available,
+				NextRequestAt: nextTime,
 			}
 		}
 		networkThrottles[networkName] = tokenThrottles
 	}
 
-	response := map[string]interface{}{
-		"daily_limit": map[string]interface{}{
-			"total":        h.config.MaxRequestsPerDayIP(),
-			"used":         used,
-			"remaining":    remaining,
-			"cooldown_end": cooldownEnd,
-			"in_cooldown":  cooldownEnd != nil,
+	response := models.QuotaResponse{
+		DailyLimit: models.DailyQuota{
+			Scope:       limit.Kind, // ip, subnet or the sign-in provider: whichever limit is tightest
+			Total:       limit.DailyLimit,
+			Used:        used,
+			Remaining:   remaining,
+			CooldownEnd: cooldownEnd,
+			InCooldown:  cooldownEnd != nil,
 		},
-		"hourly_throttle_by_network": networkThrottles,
+		HourlyThrottle: networkThrottles,
 	}
 
 	return c.JSON(response)
@@ -700,11 +1009,12 @@ func (h *Handler) GetQuota(c *fiber.Ctx) error {
 
 // Health returns the health status of the API
 func (h *Handler) Health(c *fiber.Ctx) error {
-	ctx := context.Background()
+	ctx := c.UserContext()
 
 	// Check Redis
 	if err := h.redis.Ping(ctx); err != nil {
 		return c.Status(fiber.StatusServiceUnavailable).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeUnavailable,
 			Error: "Redis unavailable",
 		})
 	}
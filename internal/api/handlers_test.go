@@ -0,0 +1,39 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitForTransfers(t *testing.T) {
+	h := &Handler{}
+	ctx, cancel := context.WithCancel(context.Background())
+	transferCtx, endTransfer := h.beginTransfer(ctx)
+
+	cancel()
+	assert.NoError(t, transferCtx.Err(), "a transfer outlives its request")
+	assert.False(t, h.WaitForTransfers(20*time.Millisecond), "a transfer is still held open")
+
+	endTransfer()
+	assert.True(t, h.WaitForTransfers(time.Second))
+}
+
+func TestNormalizeAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		want    string
+	}{
+		{"0xAbC123", "0xabc123"},
+		{"  0xabc123 ", "0xabc123"},
+		{"abc123", "0xabc123"},
+		{"0x000abc123", "0xabc123"},
+		{"0X00ABC", "0xabc"},
+		{"0x0", "0x"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, normalizeAddress(tt.address), tt.address)
+	}
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/human"
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// tierAnonymous is the tier of clients that are not signed in
+const tierAnonymous = "anonymous"
+
+// SetHumanVerifier sets the captcha verifier used for requests that require it
+func (h *Handler) SetHumanVerifier(verifier human.HumanVerifier) {
+	h.humanVerifier = verifier
+}
+
+// requestTier returns the tier of the client making a request, used to decide
+// which requests need human verification
+func (h *Handler) requestTier(c *fiber.Ctx) string {
+	if session, err := h.session(c); err == nil && session != nil {
+		return tierGitHub
+	}
+	return tierAnonymous
+}
+
+// verifyHuman checks a captcha token and maps the outcome to an HTTP status.
+// Returns 0 when the token is valid.
+func (h *Handler) verifyHuman(ctx context.Context, token, ip string) (int, models.ErrorResponse) {
+	unavailable := models.ErrorResponse{
+		Code:  models.ErrCodeUnavailable,
+		Error: "Human verification is unavailable. Try again later.",
+	}
+	if h.humanVerifier == nil {
+		h.logger.Error("Human verification required but no verifier configured")
+		return fiber.StatusServiceUnavailable, unavailable
+	}
+
+	err := h.humanVerifier.Verify(ctx, token, ip)
+	switch {
+	case err == nil:
+		return 0, models.ErrorResponse{}
+	case errors.Is(err, human.ErrMissingToken):
+		return fiber.StatusBadRequest, models.ErrorResponse{
+			Code:  models.ErrCodeCaptchaRequired,
+			Error: "Human verification required: complete the captcha and send captcha_token",
+		}
+	case errors.Is(err, human.ErrRejected):
+		h.recordPoWPenalty(ctx, ip)
+		return fiber.StatusForbidden, models.ErrorResponse{
+			Code:  models.ErrCodeCaptchaFailed,
+			Error: "Human verification failed",
+		}
+	default:
+		h.logger.Error("Human verification error",
+			zap.String("provider", h.humanVerifier.Provider()),
+			zap.Error(err),
+		)
+		return fiber.StatusServiceUnavailable, unavailable
+	}
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+// disconnectPollInterval is how often the connection is probed for a client hang-up
+const disconnectPollInterval = 250 * time.Millisecond
+
+// parentContextKey holds the context of a call dispatched in-process, such as
+// a gRPC call, so that its cancellation reaches the handler
+type parentContextKey struct{}
+
+// RequestContext attaches a per-request context to every handler.
+// The context is cancelled when the timeout elapses or the client disconnects,
+// so chain RPC calls made on behalf of an abandoned request stop early.
+func RequestContext(timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		parent := context.Background()
+		if p, ok := c.Locals(parentContextKey{}).(context.Context); ok {
+			parent = p
+		}
+		ctx, cancel := context.WithTimeout(parent, timeout)
+		defer cancel()
+
+		done := make(chan struct{})
+		defer close(done)
+		go watchDisconnect(c.Context().Conn(), cancel, done)
+
+		c.SetUserContext(ctx)
+		return c.Next()
+	}
+}
+
+// ErrorHandler renders errors that escape a handler, such as unknown routes or
+// oversized bodies, in the API's error format
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	if e, ok := err.(*fiber.Error); ok {
+		status = e.Code
+	}
+
+	code := models.ErrCodeInternal
+	switch {
+	case status == fiber.StatusNotFound || status == fiber.StatusMethodNotAllowed:
+		code = models.ErrCodeNotFound
+	case status == fiber.StatusServiceUnavailable:
+		code = models.ErrCodeUnavailable
+	case status >= 400 && status < 500:
+		code = models.ErrCodeInvalidRequest
+	}
+
+	return c.Status(status).JSON(models.ErrorResponse{
+		Code:  code,
+		Error: err.Error(),
+	})
+}
+
+// watchDisconnect cancels the request context once the peer closes the connection
+func watchDisconnect(conn net.Conn, cancel context.CancelFunc, done <-chan struct{}) {
+	if conn == nil {
+		return
+	}
+
+	ticker := time.NewTicker(disconnectPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if peerClosed(conn) {
+				cancel()
+				return
+			}
+		}
+	}
+}
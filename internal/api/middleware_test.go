@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// contextApp returns an app whose only route reports, on ended, how its
+// request context was cancelled
+func contextApp(timeout time.Duration, ended chan<- error) *fiber.App {
+	app := fiber.New()
+	app.Use(RequestContext(timeout))
+	app.Get("/", func(c *fiber.Ctx) error {
+		ctx := c.UserContext()
+		select {
+		case <-ctx.Done():
+		case <-time.After(5 * time.Second):
+		}
+		ended <- ctx.Err()
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+	return app
+}
+
+func TestRequestContextTimeout(t *testing.T) {
+	ended := make(chan error, 1)
+	app := contextApp(50*time.Millisecond, ended)
+
+	_, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil), 2000)
+	require.NoError(t, err)
+	assert.ErrorIs(t, <-ended, context.DeadlineExceeded)
+}
+
+func TestRequestContextPeerClose(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		t.Skip("disconnects are only detected on linux and darwin")
+	}
+
+	ended := make(chan error, 1)
+	app := contextApp(time.Minute, ended)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go app.Listener(ln)
+	defer app.Shutdown()
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	_, err = fmt.Fprintf(conn, "GET / HTTP/1.1\r\nHost: faucet\r\n\r\n")
+	require.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, conn.Close())
+
+	select {
+	case err := <-ended:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(2 * time.Second):
+		t.Fatal("request context was not cancelled after the client hung up")
+	}
+}
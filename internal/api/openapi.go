@@ -0,0 +1,30 @@
+package api
+
+import (
+	_ "embed"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// openAPISpec is the OpenAPI 3 document for the API. TestOpenAPIMatchesRoutes
+// and TestOpenAPIMatchesModels fail when it drifts from the routes or models.
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// docsPage renders openAPISpec in the browser without external assets
+//
+//go:embed docs.html
+var docsPage []byte
+
+// OpenAPI serves the OpenAPI document
+func (h *Handler) OpenAPI(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+	return c.Send(openAPISpec)
+}
+
+// Docs serves a page documenting the API from the OpenAPI document
+func (h *Handler) Docs(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	return c.Send(docsPage)
+}
@@ -0,0 +1,217 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/config"
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openAPISchemas maps each schema in openapi.json to the model it documents
+var openAPISchemas = map[string]any{
+	"ChallengeRequest":      models.ChallengeRequest{},
+	"ChallengeResponse":     models.ChallengeResponse{},
+	"PoWParams":             models.PoWParams{},
+	"FaucetRequest":         models.FaucetRequest{},
+	"FaucetResponse":        models.FaucetResponse{},
+	"TransactionInfo":       models.TransactionInfo{},
+	"RequestEvent":          models.RequestEvent{},
+	"BatchRequest":          models.BatchRequest{},
+	"BatchItem":             models.BatchItem{},
+	"BatchResponse":         models.BatchResponse{},
+	"BatchResult":           models.BatchResult{},
+	"SubscriptionRequest":   models.SubscriptionRequest{},
+	"Subscription":          models.Subscription{},
+	"SubscriptionsResponse": models.SubscriptionsResponse{},
+	"ErrorResponse":         models.ErrorResponse{},
+	"ErrorDetails":          models.ErrorDetails{},
+	"PreflightResponse":     models.PreflightResponse{},
+	"RecipientBalance":      models.RecipientBalance{},
+	"StatusResponse":        models.StatusResponse{},
+	"QuotaResponse":         models.QuotaResponse{},
+	"DailyQuota":            models.DailyQuota{},
+	"TokenThrottle":         models.TokenThrottle{},
+	"StatsResponse":         models.StatsResponse{},
+	"StatsWindow":           models.StatsWindow{},
+	"TokenStats":            models.TokenStats{},
+	"RequestCounts":         models.RequestCounts{},
+	"InfoResponse":          models.InfoResponse{},
+	"HumanVerificationInfo": models.HumanVerificationInfo{},
+	"LimitInfo":             models.LimitInfo{},
+	"PoWInfo":               models.PoWInfo{},
+	"BalanceInfo":           models.BalanceInfo{},
+	"HealthResponse":        models.HealthResponse{},
+	"DeviceLoginResponse":   models.DeviceLoginResponse{},
+	"DeviceTokenRequest":    models.DeviceTokenRequest{},
+	"LoginResponse":         models.LoginResponse{},
+	"InfoResponseV2":        models.InfoResponseV2{},
+	"NetworkInfo":           models.NetworkInfo{},
+	"TokenInfo":             models.TokenInfo{},
+	"LimitsResponse":        models.LimitsResponse{},
+	"NetworkLimits":         models.NetworkLimits{},
+	"TokenLimits":           models.TokenLimits{},
+	"BalancesResponse":      models.BalancesResponse{},
+	"NetworkBalances":       models.NetworkBalances{},
+	"TokenBalance":          models.TokenBalance{},
+}
+
+type openAPIDoc struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]struct {
+			Required   []string                   `json:"required"`
+			Properties map[string]json.RawMessage `json:"properties"`
+			Enum       []string                   `json:"enum"`
+		} `json:"schemas"`
+	} `json:"components"`
+}
+
+func loadOpenAPI(t *testing.T) openAPIDoc {
+	t.Helper()
+	var doc openAPIDoc
+	require.NoError(t, json.Unmarshal(openAPISpec, &doc))
+	return doc
+}
+
+var routeParam = regexp.MustCompile(`:(\w+)`)
+
+func TestOpenAPIMatchesRoutes(t *testing.T) {
+	app := fiber.New()
+	SetupRoutes(app, newTestHandler(&config.Config{}))
+
+	var routes []string
+	for _, r := range app.GetRoutes(true) {
+		if r.Method == fiber.MethodHead || (!strings.HasPrefix(r.Path, "/api/") && r.Path != "/health") {
+			continue
+		}
+		routes = append(routes, r.Method+" "+routeParam.ReplaceAllString(r.Path, "{$1}"))
+	}
+
+	var documented []string
+	for path, ops := range loadOpenAPI(t).Paths {
+		for method := range ops {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+
+	sort.Strings(routes)
+	sort.Strings(documented)
+	assert.Equal(t, routes, documented, "openapi.json paths differ from the registered routes")
+}
+
+func TestOpenAPIMatchesModels(t *testing.T) {
+	doc := loadOpenAPI(t)
+
+	for name := range doc.Components.Schemas {
+		if name == "ErrorCode" {
+			continue
+		}
+		_, ok := openAPISchemas[name]
+		assert.True(t, ok, "schema %s does not document a model", name)
+	}
+
+	for name, model := range openAPISchemas {
+		schema, ok := doc.Components.Schemas[name]
+		if !assert.True(t, ok, "model %s is missing from openapi.json", name) {
+			continue
+		}
+
+		fields, required := jsonFields(reflect.TypeOf(model))
+		var properties []string
+		for p := range schema.Properties {
+			properties = append(properties, p)
+		}
+		sort.Strings(properties)
+		assert.Equal(t, fields, properties, "properties of %s", name)
+
+		// Request bodies document what handlers insist on; responses always
+		// carry their non-omitempty fields
+		if strings.HasSuffix(name, "Request") {
+			assert.Subset(t, fields, schema.Required, "required fields of %s", name)
+		} else {
+			got := append([]string(nil), schema.Required...)
+			sort.Strings(got)
+			assert.Equal(t, required, got, "required fields of %s", name)
+		}
+	}
+}
+
+func TestOpenAPIErrorCodes(t *testing.T) {
+	var codes []string
+	for _, code := range models.ErrorCodes {
+		codes = append(codes, string(code))
+	}
+	assert.Equal(t, codes, loadOpenAPI(t).Components.Schemas["ErrorCode"].Enum)
+}
+
+func TestOpenAPIRefsResolve(t *testing.T) {
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(openAPISpec, &doc))
+	components := doc["components"].(map[string]any)
+
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			if ref, ok := v["$ref"].(string); ok {
+				parts := strings.Split(strings.TrimPrefix(ref, "#/components/"), "/")
+				require.Len(t, parts, 2, ref)
+				section, _ := components[parts[0]].(map[string]any)
+				assert.Contains(t, section, parts[1], "unresolved %s", ref)
+			}
+			for _, child := range v {
+				walk(child)
+			}
+		case []any:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+	walk(doc)
+}
+
+func TestOpenAPIServed(t *testing.T) {
+	app := fiber.New()
+	SetupRoutes(app, newTestHandler(&config.Config{}))
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/api/v1/openapi.json", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var doc map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&doc))
+	assert.Equal(t, "3.0.3", doc["openapi"])
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/api/v1/docs", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get(fiber.HeaderContentType), "text/html")
+}
+
+// jsonFields returns the sorted JSON field names of a struct and those that
+// are always present: not omitempty and not pointers
+func jsonFields(t reflect.Type) (fields, required []string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" || name == "" {
+			continue
+		}
+		fields = append(fields, name)
+		if !strings.Contains(opts, "omitempty") && f.Type.Kind() != reflect.Pointer {
+			required = append(required, name)
+		}
+	}
+	sort.Strings(fields)
+	sort.Strings(required)
+	return fields, required
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/Giri-Aayush/starknet-faucet/internal/ownership"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// SetOwnershipVerifier sets how recipient ownership proofs are checked on a network
+func (h *Handler) SetOwnershipVerifier(network string, verifier ownership.Verifier) {
+	if h.ownership == nil {
+		h.ownership = make(map[string]ownership.Verifier)
+	}
+	h.ownership[network] = verifier
+}
+
+// verifyOwnership checks the recipient's signature over challenge. Signatures
+// are checked whenever one is sent, and required on networks configured for
+// ownership proofs. It returns a zero status on success, otherwise the HTTP
+// status and error to respond with.
+func (h *Handler) verifyOwnership(ctx context.Context, network, address, challenge, signature, ip string) (int, models.ErrorResponse) {
+	required := h.config().RequiresOwnershipProof(network)
+	if !required && signature == "" {
+		return 0, models.ErrorResponse{}
+	}
+
+	verifier, ok := h.ownership[network]
+	if !ok {
+		if required {
+			h.logger.Error("Ownership proof required but no verifier configured", zap.String("network", network))
+			return fiber.StatusInternalServerError, models.ErrorResponse{
+				Code:    models.ErrCodeInternal,
+				Error:   "Ownership proofs are not available on this network",
+				Details: &models.ErrorDetails{Network: network},
+			}
+		}
+		return 0, models.ErrorResponse{}
+	}
+
+	err := verifier.Verify(ctx, address, challenge, signature)
+	switch {
+	case err == nil:
+		return 0, models.ErrorResponse{}
+	case errors.Is(err, ownership.ErrMissingSignature):
+		return fiber.StatusForbidden, models.ErrorResponse{
+			Code:    models.ErrCodeSignatureRequired,
+			Error:   "[OWNERSHIP] This faucet requires a signature from the recipient address. Sign with --sign-key or --keystore.",
+			Details: &models.ErrorDetails{Network: network},
+		}
+	case errors.Is(err, ownership.ErrInvalidSignature):
+		h.logger.Warn("Invalid ownership signature",
+			zap.String("network", network),
+			zap.String("recipient", address),
+			zap.String("ip", ip),
+		)
+		h.recordPoWPenalty(ctx, ip)
+		return fiber.StatusForbidden, models.ErrorResponse{
+			Code:    models.ErrCodeInvalidSignature,
+			Error:   "[OWNERSHIP] Signature does not prove ownership of the recipient address",
+			Details: &models.ErrorDetails{Network: network},
+		}
+	case errors.Is(err, ownership.ErrAccountUnverifiable):
+		h.logger.Warn("Recipient account could not verify ownership signature",
+			zap.String("network", network),
+			zap.String("recipient", address),
+			zap.Error(err),
+		)
+		return fiber.StatusForbidden, models.ErrorResponse{
+			Code:    models.ErrCodeSignatureUnverifiable,
+			Error:   "[OWNERSHIP] Could not verify the signature with the recipient account. Is it deployed?",
+			Details: &models.ErrorDetails{Network: network},
+		}
+	default:
+		// The chain could not be asked; the client may retry
+		h.logger.Error("Failed to verify ownership signature",
+			zap.String("network", network),
+			zap.String("recipient", address),
+			zap.Error(err),
+		)
+		return fiber.StatusServiceUnavailable, models.ErrorResponse{
+			Code:    models.ErrCodeUnavailable,
+			Error:   "Could not reach the network to verify the signature; try again shortly",
+			Details: &models.ErrorDetails{Network: network},
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/config"
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/Giri-Aayush/starknet-faucet/internal/ownership"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// verifierFunc adapts a function to ownership.Verifier
+type verifierFunc func(ctx context.Context, address, challenge, signature string) error
+
+func (f verifierFunc) Verify(ctx context.Context, address, challenge, signature string) error {
+	return f(ctx, address, challenge, signature)
+}
+
+func TestVerifyOwnershipErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   models.ErrorCode
+	}{
+		{"valid", nil, 0, ""},
+		{"missing", ownership.ErrMissingSignature, fiber.StatusForbidden, models.ErrCodeSignatureRequired},
+		{"account not deployed", fmt.Errorf("%w: contract not found", ownership.ErrAccountUnverifiable), fiber.StatusForbidden, models.ErrCodeSignatureUnverifiable},
+		{"rpc down", errors.New("failed to call is_valid_signature: connection refused"), fiber.StatusServiceUnavailable, models.ErrCodeUnavailable},
+	}
+	for _, tt := range tests {
+		h := newTestHandler(&config.Config{})
+		h.SetOwnershipVerifier("starknet", verifierFunc(func(context.Context, string, string, string) error { return tt.err }))
+
+		status, body := h.verifyOwnership(context.Background(), "starknet", "0xabc", "seed", "0x1,0x2", "203.0.113.1")
+		assert.Equal(t, tt.status, status, tt.name)
+		assert.Equal(t, tt.code, body.Code, tt.name)
+	}
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/cache"
+	"github.com/Giri-Aayush/starknet-faucet/internal/clientip"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ipKey returns the address range a client IP is rate limited as, so every
+// address in one IPv6 /64 (by default) shares a single limit
+func (h *Handler) ipKey(ip string) string {
+	return clientip.Prefix(ip, h.config().RateLimits.IPv4PrefixLength, h.config().RateLimits.IPv6PrefixLength)
+}
+
+// requestQuotas returns who a request is charged to: the signed-in account if
+// there is one, otherwise the client's address range. The first subject is the
+// primary one; anonymous requests are also charged to their subnet when subnet
+// limits are enabled, and must fit within every subject's limit.
+func (h *Handler) requestQuotas(c *fiber.Ctx) ([]cache.Subject, error) {
+	session, err := h.session(c)
+	if err != nil {
+		return nil, err
+	}
+	if session != nil {
+		return []cache.Subject{
+			cache.AccountSubject(session.Provider, session.Subject(), h.config().GitHub.MaxRequestsPerDay),
+		}, nil
+	}
+
+	ip := h.clientIP(c)
+	quotas := []cache.Subject{cache.IPSubject(h.ipKey(ip), h.config().MaxRequestsPerDayIP())}
+
+	if subnet := h.config().RateLimits.Subnet; subnet.MaxRequestsPerDay > 0 {
+		prefix := clientip.Prefix(ip, subnet.IPv4PrefixLength, subnet.IPv6PrefixLength)
+		quotas = append(quotas, cache.SubnetSubject(prefix, subnet.MaxRequestsPerDay))
+	}
+	return quotas, nil
+}
+
+// dailyQuota returns the most restrictive of the subjects' daily quotas
+func (h *Handler) dailyQuota(ctx context.Context, quotas []cache.Subject) (cache.Subject, int, int, *time.Time, error) {
+	var (
+		tightest    cache.Subject
+		used        int
+		remaining   = -1
+		cooldownEnd *time.Time
+	)
+	for _, quota := range quotas {
+		u, r, end, err := h.redis.GetDailyQuota(ctx, quota)
+		if err != nil {
+			return cache.Subject{}, 0, 0, nil, err
+		}
+		if end != nil {
+			r = 0
+		}
+		if remaining < 0 || r < remaining {
+			tightest, used, remaining, cooldownEnd = quota, u, r, end
+		}
+	}
+	return tightest, used, remaining, cooldownEnd, nil
+}
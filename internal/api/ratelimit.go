@@ -0,0 +1,54 @@
+package api
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Rate limit response headers
+const (
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+)
+
+// retryAt sets Retry-After for a response that may be retried at reset, and
+// fills the matching retry hints into body
+func retryAt(c *fiber.Ctx, reset time.Time, body models.ErrorResponse) models.ErrorResponse {
+	wait := max(time.Until(reset), 0)
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	return retryHints(reset, body)
+}
+
+// retryHints fills the hints for retrying at reset into body, for helpers
+// that return an error response rather than write it
+func retryHints(reset time.Time, body models.ErrorResponse) models.ErrorResponse {
+	wait := max(time.Until(reset), 0)
+	hours := math.Round(wait.Hours()*100) / 100
+	body.NextRequestTime = &reset
+	body.RemainingHours = &hours
+	return body
+}
+
+// errorResponse writes an error response returned by a helper, with
+// Retry-After when it carries a retry time
+func errorResponse(c *fiber.Ctx, status int, body models.ErrorResponse) error {
+	if body.NextRequestTime != nil {
+		body = retryAt(c, *body.NextRequestTime, body)
+	}
+	return c.Status(status).JSON(body)
+}
+
+// rateLimited responds 429 for a request over a limit of limit requests that
+// resets at reset, with Retry-After and X-RateLimit-* headers so clients
+// need not parse the message
+func rateLimited(c *fiber.Ctx, limit, remaining int, reset time.Time, body models.ErrorResponse) error {
+	c.Set(headerRateLimitLimit, strconv.Itoa(limit))
+	c.Set(headerRateLimitRemaining, strconv.Itoa(remaining))
+	c.Set(headerRateLimitReset, strconv.FormatInt(reset.Unix(), 10))
+	return c.Status(fiber.StatusTooManyRequests).JSON(retryAt(c, reset, body))
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimited(t *testing.T) {
+	reset := time.Now().Add(90*time.Minute + 30*time.Second).Truncate(time.Second)
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		return rateLimited(c, 5, 0, reset, models.ErrorResponse{Code: models.ErrCodeDailyLimit, Error: "limit"})
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "5", resp.Header.Get(headerRateLimitLimit))
+	assert.Equal(t, "0", resp.Header.Get(headerRateLimitRemaining))
+	assert.Equal(t, strconv.FormatInt(reset.Unix(), 10), resp.Header.Get(headerRateLimitReset))
+
+	retryAfter, err := strconv.Atoi(resp.Header.Get(fiber.HeaderRetryAfter))
+	require.NoError(t, err)
+	assert.InDelta(t, time.Until(reset).Seconds(), retryAfter, 2)
+
+	var body models.ErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, models.ErrCodeDailyLimit, body.Code)
+	require.NotNil(t, body.NextRequestTime)
+	assert.True(t, reset.Equal(*body.NextRequestTime))
+	require.NotNil(t, body.RemainingHours)
+	assert.InDelta(t, 1.51, *body.RemainingHours, 0.01)
+}
+
+func TestRetryAtInThePast(t *testing.T) {
+	app := fiber.New()
+	var body models.ErrorResponse
+	app.Get("/", func(c *fiber.Ctx) error {
+		body = retryAt(c, time.Now().Add(-time.Minute), models.ErrorResponse{})
+		return c.SendStatus(fiber.StatusServiceUnavailable)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+	require.NoError(t, err)
+	assert.Equal(t, "0", resp.Header.Get(fiber.HeaderRetryAfter), "never a negative wait")
+	assert.Empty(t, resp.Header.Get(headerRateLimitLimit), "retryAt alone sets no limit headers")
+	require.NotNil(t, body.RemainingHours)
+	assert.Zero(t, *body.RemainingHours)
+}
+
+func TestErrorResponse(t *testing.T) {
+	reset := time.Now().Add(time.Hour)
+	app := fiber.New()
+	app.Get("/hinted", func(c *fiber.Ctx) error {
+		return errorResponse(c, fiber.StatusServiceUnavailable, retryHints(reset, models.ErrorResponse{Code: models.ErrCodeFaucetLimit}))
+	})
+	app.Get("/plain", func(c *fiber.Ctx) error {
+		return errorResponse(c, fiber.StatusServiceUnavailable, models.ErrorResponse{Code: models.ErrCodeLowBalance})
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/hinted", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+	assert.NotEmpty(t, resp.Header.Get(fiber.HeaderRetryAfter), "a helper's retry time becomes Retry-After")
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/plain", nil))
+	require.NoError(t, err)
+	assert.Empty(t, resp.Header.Get(fiber.HeaderRetryAfter))
+}
@@ -0,0 +1,204 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/chains"
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// preflightMaxAge is how long a recipient balance read for a preflight is
+// reused. Preflights need no challenge, so without it every call could make
+// the faucet send RPCs.
+const preflightMaxAge = 30 * time.Second
+
+// recipientBalances reads the recipient's balance of each token that has a
+// max_recipient_balance cap. Tokens without a cap are skipped. With a maxAge,
+// balances read from chain within it are reused.
+func (h *Handler) recipientBalances(ctx context.Context, network string, chain chains.Chain, chainProvider ChainProvider, address string, tokens []string, maxAge time.Duration) ([]models.RecipientBalance, error) {
+	var balances []models.RecipientBalance
+	for _, token := range tokens {
+		maxBalance := chainProvider.GetMaxRecipientBalance(token)
+		if maxBalance <= 0 {
+			continue
+		}
+
+		balance, err := h.recipientBalance(ctx, network, chain, address, token, maxAge)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read recipient %s balance: %w", token, err)
+		}
+
+		amount := chains.FromBaseUnits(balance, chainProvider.GetTokenDecimals(token))
+		balances = append(balances, models.RecipientBalance{
+			Token:      token,
+			Balance:    amount,
+			MaxBalance: maxBalance,
+			OverCap:    amount >= maxBalance,
+		})
+	}
+	return balances, nil
+}
+
+// recipientBalance reads a recipient's balance of one token, from Redis when
+// maxAge is set and a read that recent is stored
+func (h *Handler) recipientBalance(ctx context.Context, network string, chain chains.Chain, address, token string, maxAge time.Duration) (*big.Int, error) {
+	if maxAge > 0 {
+		balance, ok, err := h.redis.GetRecipientBalance(ctx, network, address, token)
+		if err != nil {
+			h.logger.Warn("Failed to read cached recipient balance", zap.Error(err))
+		} else if ok {
+			return balance, nil
+		}
+	}
+
+	balanceCtx, cancel := h.chainContext(ctx)
+	defer cancel()
+	balance, err := chain.GetBalance(balanceCtx, address, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxAge > 0 {
+		if err := h.redis.SetRecipientBalance(ctx, network, address, token, balance, maxAge); err != nil {
+			h.logger.Warn("Failed to cache recipient balance", zap.Error(err))
+		}
+	}
+	return balance, nil
+}
+
+// requestTokens returns the tokens a request would send
+func requestTokens(chain chains.Chain, token string) []string {
+	if token == "BOTH" {
+		return chain.GetSupportedTokens()
+	}
+	return []string{token}
+}
+
+// checkRecipientBalance refuses requests for addresses that already hold at
+// least the faucet's cap. It returns a zero status when the request may proceed,
+// otherwise the HTTP status and error to respond with. Balances are always read
+// from chain, as they may have changed since a preflight.
+func (h *Handler) checkRecipientBalance(ctx context.Context, network string, chain chains.Chain, chainProvider ChainProvider, address, token string) (int, models.ErrorResponse) {
+	balances, err := h.recipientBalances(ctx, network, chain, chainProvider, address, requestTokens(chain, token), 0)
+	if err != nil {
+		h.logger.Error("Failed to check recipient balance", zap.Error(err), zap.String("recipient", address))
+		return fiber.StatusInternalServerError, models.ErrorResponse{
+			Code:  models.ErrCodeInternal,
+			Error: "Failed to check recipient balance",
+		}
+	}
+
+	for _, b := range balances {
+		if b.OverCap {
+			h.logger.Info("Recipient already funded",
+				zap.String("recipient", address),
+				zap.String("token", b.Token),
+				zap.Float64("balance", b.Balance),
+				zap.Float64("max_balance", b.MaxBalance),
+			)
+			return fiber.StatusForbidden, models.ErrorResponse{
+				Code: models.ErrCodeAlreadyFunded,
+				Error: fmt.Sprintf("[ALREADY FUNDED] Address already holds %.4f %s; the faucet only funds addresses below %g %s.",
+					b.Balance, b.Token, b.MaxBalance, b.Token),
+				Details: &models.ErrorDetails{
+					Network:    network,
+					Token:      b.Token,
+					Balance:    b.Balance,
+					MaxBalance: b.MaxBalance,
+				},
+			}
+		}
+	}
+	return 0, models.ErrorResponse{}
+}
+
+// Preflight reports whether a request would currently be accepted, without
+// spending a challenge: the recipient's balance against the cap and the
+// caller's remaining daily quota
+func (h *Handler) Preflight(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	network := c.Query("network", h.defaultNetwork)
+	token := strings.ToUpper(c.Query("token", "BOTH"))
+	address := c.Query("address")
+
+	chain, chainProvider, err := h.getChain(network)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeUnsupportedNetwork,
+			Error: err.Error(),
+		})
+	}
+
+	if err := chain.ValidateAddress(address); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeInvalidAddress,
+			Error: fmt.Sprintf("Invalid address: %s", err.Error()),
+		})
+	}
+	address = chain.NormalizeAddress(address)
+
+	if token != "BOTH" {
+		if err := chain.ValidateToken(token); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Code:    models.ErrCodeUnsupportedToken,
+				Error:   err.Error(),
+				Details: &models.ErrorDetails{Token: token},
+			})
+		}
+	}
+
+	quotas, err := h.requestQuotas(c)
+	if err != nil {
+		return sessionError(c, err)
+	}
+
+	_, _, remaining, _, err := h.dailyQuota(ctx, quotas)
+	if err != nil {
+		h.logger.Error("Failed to get daily quota", zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeInternal,
+			Error: "Failed to check quota",
+		})
+	}
+
+	balances, err := h.recipientBalances(ctx, network, chain, chainProvider, address, requestTokens(chain, token), preflightMaxAge)
+	if err != nil {
+		h.logger.Error("Failed to check recipient balance", zap.Error(err), zap.String("recipient", address))
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeInternal,
+			Error: "Failed to check recipient balance",
+		})
+	}
+
+	response := models.PreflightResponse{
+		Address:        address,
+		Network:        network,
+		Token:          token,
+		DailyRemaining: remaining,
+		Recipient:      balances,
+	}
+	judgePreflight(&response, len(requestTokens(chain, token)))
+	return c.JSON(response)
+}
+
+// judgePreflight decides whether a request costing cost daily requests would
+// be accepted, from the remaining quota and recipient balances in response
+func judgePreflight(response *models.PreflightResponse, cost int) {
+	response.Eligible = true
+	if response.DailyRemaining < cost {
+		response.Eligible = false
+		response.Reasons = append(response.Reasons, "daily request limit reached")
+	}
+	for _, b := range response.Recipient {
+		if b.OverCap {
+			response.Eligible = false
+			response.Reasons = append(response.Reasons, fmt.Sprintf("recipient already holds %.4f %s (cap %g)", b.Balance, b.Token, b.MaxBalance))
+		}
+	}
+}
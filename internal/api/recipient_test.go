@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Giri-Aayush/starknet-faucet/chains"
+	"github.com/Giri-Aayush/starknet-faucet/internal/config"
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRecipientBalance(t *testing.T) {
+	h := newTestHandler(&config.Config{})
+	tokens := func(amount float64) *big.Int { return chains.ToBaseUnits(amount, chains.DefaultDecimals) }
+
+	tests := []struct {
+		name     string
+		balances map[string]*big.Int
+		cap      float64
+		token    string
+		err      error
+		status   int
+		code     models.ErrorCode
+	}{
+		{"below the cap", map[string]*big.Int{"STRK": tokens(0.5)}, 1, "STRK", nil, 0, ""},
+		{"at the cap", map[string]*big.Int{"STRK": tokens(1)}, 1, "STRK", nil, fiber.StatusForbidden, models.ErrCodeAlreadyFunded},
+		{"above the cap", map[string]*big.Int{"STRK": tokens(2)}, 1, "STRK", nil, fiber.StatusForbidden, models.ErrCodeAlreadyFunded},
+		{"no cap", map[string]*big.Int{"STRK": tokens(1000)}, 0, "STRK", nil, 0, ""},
+		{"one of BOTH at the cap", map[string]*big.Int{"ETH": tokens(1)}, 1, "BOTH", nil, fiber.StatusForbidden, models.ErrCodeAlreadyFunded},
+		{"balance unreadable", nil, 1, "STRK", errors.New("rpc down"), fiber.StatusInternalServerError, models.ErrCodeInternal},
+	}
+	for _, tt := range tests {
+		chain := &fakeChain{name: "starknet", tokens: []string{"ETH", "STRK"}, balances: tt.balances, err: tt.err}
+		status, body := h.checkRecipientBalance(context.Background(), "starknet", chain, fakeProvider{maxRecipientBalance: tt.cap}, "0xabc", tt.token)
+		assert.Equal(t, tt.status, status, tt.name)
+		assert.Equal(t, tt.code, body.Code, tt.name)
+		if tt.code == models.ErrCodeAlreadyFunded {
+			require.NotNil(t, body.Details, tt.name)
+			assert.Equal(t, "starknet", body.Details.Network, "%s: the resolved network, not the request's", tt.name)
+			assert.Equal(t, 1.0, body.Details.MaxBalance, tt.name)
+		}
+	}
+}
+
+func TestJudgePreflight(t *testing.T) {
+	tests := []struct {
+		name      string
+		remaining int
+		cost      int
+		recipient []models.RecipientBalance
+		eligible  bool
+		reasons   int
+	}{
+		{"eligible", 2, 2, []models.RecipientBalance{{Token: "STRK", Balance: 0.5, MaxBalance: 1}}, true, 0},
+		{"quota too low for BOTH", 1, 2, nil, false, 1},
+		{"over the cap", 5, 1, []models.RecipientBalance{{Token: "STRK", Balance: 1, MaxBalance: 1, OverCap: true}}, false, 1},
+		{"both reasons", 0, 1, []models.RecipientBalance{{Token: "STRK", Balance: 3, MaxBalance: 1, OverCap: true}}, false, 2},
+	}
+	for _, tt := range tests {
+		response := models.PreflightResponse{DailyRemaining: tt.remaining, Recipient: tt.recipient}
+		judgePreflight(&response, tt.cost)
+		assert.Equal(t, tt.eligible, response.Eligible, tt.name)
+		assert.Len(t, response.Reasons, tt.reasons, tt.name)
+	}
+}
+
+func TestPreflightRejectsInvalidRequests(t *testing.T) {
+	h := newTestHandler(&config.Config{})
+	h.chains = map[string]chains.Chain{"starknet": &fakeChain{name: "starknet", tokens: []string{"ETH", "STRK"}}}
+	h.defaultNetwork = "starknet"
+	h.ApplyConfig(h.config(), map[string]ChainProvider{"starknet": fakeProvider{}})
+	app := fiber.New()
+	SetupRoutes(app, h)
+
+	tests := []struct {
+		name  string
+		query string
+		code  models.ErrorCode
+	}{
+		{"unknown network", "?network=nowhere&address=0xabc", models.ErrCodeUnsupportedNetwork},
+		{"invalid address", "?address=abc", models.ErrCodeInvalidAddress},
+		{"unsupported token", "?address=0xabc&token=doge", models.ErrCodeUnsupportedToken},
+	}
+	for _, tt := range tests {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/api/v1/preflight"+tt.query, nil))
+		require.NoError(t, err, tt.name)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode, tt.name)
+
+		var body models.ErrorResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body), tt.name)
+		assert.Equal(t, tt.code, body.Code, tt.name)
+	}
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/apikey"
+	"github.com/Giri-Aayush/starknet-faucet/internal/config"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newTestHandler returns a handler serving cfg without chains or Redis
+func newTestHandler(cfg *config.Config) *Handler {
+	h := &Handler{logger: zap.NewNop()}
+	h.ApplyConfig(cfg, map[string]ChainProvider{})
+	return h
+}
+
+func TestApplyConfigSwapsAPIKeys(t *testing.T) {
+	h := newTestHandler(&config.Config{})
+	app := fiber.New()
+	SetupRoutes(app, h)
+
+	// An empty batch is refused after the key is checked, without reaching Redis
+	batch := func() int {
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/batch", strings.NewReader(`{"items":[]}`))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Set(apiKeyHeader, "fk_qa")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		return resp.StatusCode
+	}
+	assert.Equal(t, fiber.StatusUnauthorized, batch())
+
+	h.ApplyConfig(&config.Config{APIKeys: []config.APIKeyConfig{{Name: "qa", KeySHA256: apikey.Hash("fk_qa")}}}, map[string]ChainProvider{})
+	assert.Equal(t, fiber.StatusBadRequest, batch(), "a key added by a reload is accepted")
+}
+
+func TestProvidersIsACopy(t *testing.T) {
+	h := &Handler{logger: zap.NewNop()}
+	h.ApplyConfig(&config.Config{}, map[string]ChainProvider{"starknet": fakeProvider{}})
+
+	next := h.Providers()
+	next["ethereum"] = fakeProvider{}
+	assert.Len(t, h.providers(), 1, "building the next set leaves the served one alone")
+	assert.Contains(t, next, "starknet", "providers that were not reloaded carry over")
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/etag"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 )
@@ -12,12 +13,15 @@ func SetupRoutes(app *fiber.App, handler *Handler) {
 	// Middleware
 	app.Use(recover.New())
 	app.Use(logger.New())
+	// Per-request deadline, cancelled early if the client disconnects
+	app.Use(RequestContext(handler.config().RequestTimeout()))
 	// CORS - Allow all origins for public faucet API
 	// CLI and frontend can make requests from anywhere
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",  // Public API - allow all domains
-		AllowHeaders: "Origin, Content-Type, Accept",
-		AllowMethods: "GET, POST, OPTIONS",
+		AllowOrigins:  "*", // Public API - allow all domains
+		AllowHeaders:  "Origin, Content-Type, Accept, Authorization, If-None-Match, Last-Event-ID, X-API-Key",
+		ExposeHeaders: "ETag, Retry-After, X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset",
+		AllowMethods:  "GET, POST, DELETE, OPTIONS",
 	}))
 
 	// Health check
@@ -32,12 +36,43 @@ func SetupRoutes(app *fiber.App, handler *Handler) {
 	// Faucet endpoint
 	v1.Post("/faucet", handler.RequestTokens)
 
+	// Batch funding for API key holders
+	v1.Post("/batch", handler.RequestBatch)
+
+	// Recurring drips for API key holders
+	v1.Post("/subscriptions", handler.CreateSubscription)
+	v1.Get("/subscriptions", handler.ListSubscriptions)
+	v1.Delete("/subscriptions/:id", handler.DeleteSubscription)
+
+	// Live progress of a faucet request, as Server-Sent Events
+	v1.Get("/requests/:id/events", handler.RequestEvents)
+
+	// Usage statistics
+	v1.Get("/stats", etag.New(), handler.Stats)
+
 	// Status endpoint
 	v1.Get("/status/:address", handler.GetStatus)
 
 	// Info endpoint
-	v1.Get("/info", handler.GetInfo)
+	v1.Get("/info", etag.New(), handler.GetInfo)
 
 	// Quota endpoint
 	v1.Get("/quota", handler.GetQuota)
+
+	// Preflight endpoint: would a request be accepted right now?
+	v1.Get("/preflight", handler.Preflight)
+
+	// GitHub sign-in (OAuth device flow)
+	v1.Post("/auth/github/device", handler.StartGitHubLogin)
+	v1.Post("/auth/github/token", handler.CompleteGitHubLogin)
+
+	// API documentation
+	v1.Get("/openapi.json", etag.New(), handler.OpenAPI)
+	v1.Get("/docs", handler.Docs)
+
+	// API v2 routes: every network and token, rather than fixed STRK and ETH fields
+	v2 := app.Group("/api/v2")
+	v2.Get("/info", etag.New(), handler.InfoV2)
+	v2.Get("/limits", etag.New(), handler.LimitsV2)
+	v2.Get("/balances", etag.New(), handler.BalancesV2)
 }
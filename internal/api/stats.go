@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/cache"
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// statsTimeout bounds recording one statistic
+const statsTimeout = 5 * time.Second
+
+// recordTransfer counts a transfer in the faucet statistics. Like request
+// events, statistics are best effort and never fail a request.
+func (h *Handler) recordTransfer(network, token, recipient, amount string) {
+	amountFloat, _ := strconv.ParseFloat(amount, 64)
+
+	ctx, cancel := context.WithTimeout(context.Background(), statsTimeout)
+	defer cancel()
+	if err := h.redis.RecordTransfer(ctx, network, token, recipient, amountFloat); err != nil {
+		h.logger.Warn("Failed to record transfer statistics", zap.Error(err), zap.String("token", token))
+	}
+}
+
+// recordOutcome counts a finished faucet request in the faucet statistics.
+// Server faults count as failed; other errors, including the faucet's own
+// limits, count as rejected under their code.
+func (h *Handler) recordOutcome(c *fiber.Ctx) {
+	outcome, code := models.OutcomeSucceeded, ""
+	if status := c.Response().StatusCode(); status >= fiber.StatusBadRequest {
+		body := errorBody(c)
+		if body.Code == models.ErrCodeInternal || body.Code == models.ErrCodeUnavailable {
+			outcome = models.OutcomeFailed
+		} else {
+			outcome, code = models.OutcomeRejected, string(body.Code)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), statsTimeout)
+	defer cancel()
+	if err := h.redis.RecordOutcome(ctx, outcome, code); err != nil {
+		h.logger.Warn("Failed to record request statistics", zap.Error(err))
+	}
+}
+
+// Stats reports how much the faucet has given out, to how many recipients, and
+// how faucet requests ended, over the last hour, day and week and all time.
+// The response is computed at most once per info max-age.
+func (h *Handler) Stats(c *fiber.Ctx) error {
+	maxAge := time.Duration(h.config().InfoMaxAge()) * time.Second
+	response, err := h.stats.get(time.Now().UTC(), maxAge, func(now time.Time) (models.StatsResponse, error) {
+		hour, day, week, total, err := h.redis.Stats(c.UserContext(), now)
+		if err != nil {
+			return models.StatsResponse{}, err
+		}
+		return models.StatsResponse{
+			Hour:        statsWindow(hour),
+			Day:         statsWindow(day),
+			Week:        statsWindow(week),
+			AllTime:     statsWindow(total),
+			GeneratedAt: now,
+		}, nil
+	})
+	if err != nil {
+		h.logger.Error("Failed to read statistics", zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeInternal,
+			Error: "Failed to read statistics",
+		})
+	}
+
+	c.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", h.config().InfoMaxAge()))
+	return c.JSON(response)
+}
+
+// statsCache keeps the last statistics response, so that polling clients do
+// not each read every counter. Concurrent misses wait for a single read.
+type statsCache struct {
+	mu        sync.Mutex
+	response  models.StatsResponse
+	expiresAt time.Time
+}
+
+// get returns the cached response, or the one computed by load when it is
+// older than maxAge
+func (s *statsCache) get(now time.Time, maxAge time.Duration, load func(now time.Time) (models.StatsResponse, error)) (models.StatsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Before(s.expiresAt) {
+		return s.response, nil
+	}
+
+	response, err := load(now)
+	if err != nil {
+		return models.StatsResponse{}, err
+	}
+	s.response, s.expiresAt = response, now.Add(maxAge)
+	return response, nil
+}
+
+// statsWindow converts the counters of a time window to its response
+func statsWindow(counters cache.StatsCounters) models.StatsWindow {
+	window := models.StatsWindow{
+		Distributed:      []models.TokenStats{},
+		UniqueRecipients: counters.Recipients,
+		Requests: models.RequestCounts{
+			Succeeded: counters.Outcome(models.OutcomeSucceeded),
+			Rejected:  counters.Outcome(models.OutcomeRejected),
+			Failed:    counters.Outcome(models.OutcomeFailed),
+		},
+		Rejections: counters.Rejections(),
+	}
+	for _, t := range counters.Tokens() {
+		window.Distributed = append(window.Distributed, models.TokenStats{
+			Network:   t[0],
+			Token:     t[1],
+			Amount:    counters.Amount(t[0], t[1]),
+			Transfers: counters.Transfers(t[0], t[1]),
+		})
+	}
+	// Stable order keeps the response deterministic
+	sort.Slice(window.Distributed, func(i, j int) bool {
+		a, b := window.Distributed[i], window.Distributed[j]
+		if a.Network != b.Network {
+			return a.Network < b.Network
+		}
+		return a.Token < b.Token
+	})
+	return window
+}
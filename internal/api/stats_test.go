@@ -0,0 +1,71 @@
+package api
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/cache"
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsWindow(t *testing.T) {
+	window := statsWindow(cache.StatsCounters{
+		Recipients: 3,
+		Fields: map[string]float64{
+			"amount:starknet:STRK":    30,
+			"transfers:starknet:STRK": 3,
+			"amount:ethereum:ETH":     0.02,
+			"transfers:ethereum:ETH":  2,
+			"outcome:succeeded":       4,
+			"outcome:rejected":        2,
+			"outcome:failed":          1,
+			"rejected:hourly_limit":   2,
+		},
+	})
+
+	assert.Equal(t, []models.TokenStats{
+		{Network: "ethereum", Token: "ETH", Amount: 0.02, Transfers: 2},
+		{Network: "starknet", Token: "STRK", Amount: 30, Transfers: 3},
+	}, window.Distributed)
+	assert.Equal(t, int64(3), window.UniqueRecipients)
+	assert.Equal(t, models.RequestCounts{Succeeded: 4, Rejected: 2, Failed: 1}, window.Requests)
+	assert.Equal(t, map[string]int64{"hourly_limit": 2}, window.Rejections)
+
+	empty := statsWindow(cache.StatsCounters{Fields: map[string]float64{}})
+	assert.NotNil(t, empty.Distributed, "an empty window lists no tokens rather than null")
+	assert.Empty(t, empty.Rejections)
+}
+
+func TestStatsCache(t *testing.T) {
+	var s statsCache
+	loads := 0
+	load := func(now time.Time) (models.StatsResponse, error) {
+		loads++
+		return models.StatsResponse{GeneratedAt: now}, nil
+	}
+
+	start := time.Now()
+	first, err := s.get(start, time.Minute, load)
+	require.NoError(t, err)
+	cached, err := s.get(start.Add(30*time.Second), time.Minute, load)
+	require.NoError(t, err)
+	assert.Equal(t, first, cached)
+	assert.Equal(t, 1, loads, "served from the cache within max-age")
+
+	fresh, err := s.get(start.Add(time.Minute), time.Minute, load)
+	require.NoError(t, err)
+	assert.Equal(t, 2, loads)
+	assert.True(t, fresh.GeneratedAt.After(first.GeneratedAt))
+
+	_, err = s.get(start.Add(2*time.Minute), time.Minute, func(time.Time) (models.StatsResponse, error) {
+		return models.StatsResponse{}, errors.New("redis down")
+	})
+	assert.Error(t, err)
+	cached, err = s.get(start.Add(2*time.Minute), time.Minute, load)
+	require.NoError(t, err)
+	assert.Equal(t, 3, loads, "failures are not cached")
+	assert.NotEqual(t, fresh, cached)
+}
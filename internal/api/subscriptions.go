@@ -0,0 +1,406 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/chains"
+	"github.com/Giri-Aayush/starknet-faucet/internal/cache"
+	"github.com/Giri-Aayush/starknet-faucet/internal/config"
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// newSubscriptionID returns a random subscription ID
+func newSubscriptionID() string {
+	return "sub_" + newRequestID()
+}
+
+// CreateSubscription registers a recurring drip on the API key's budget:
+// either keep an address at a minimum balance, or send an amount every interval
+func (h *Handler) CreateSubscription(c *fiber.Ctx) error {
+	key, status, body := h.apiKey(c)
+	if status != 0 {
+		return c.Status(status).JSON(body)
+	}
+
+	var req models.SubscriptionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeInvalidRequest,
+			Error: "Invalid request body",
+		})
+	}
+	if req.Network == "" {
+		req.Network = h.defaultNetwork
+	}
+	req.Token = strings.ToUpper(req.Token)
+
+	if status, body := h.validateSubscription(key, req); status != 0 {
+		return c.Status(status).JSON(body)
+	}
+
+	sub := models.Subscription{
+		ID:          newSubscriptionID(),
+		Mode:        req.Mode,
+		Address:     req.Address,
+		Network:     req.Network,
+		Token:       req.Token,
+		MinBalance:  req.MinBalance,
+		Amount:      req.Amount,
+		IntervalSec: req.IntervalSec,
+		CreatedAt:   time.Now().UTC(),
+	}
+	// The count is checked as the subscription is stored, so concurrent creates cannot pass the limit
+	limit := h.config().Subscriptions.MaxPerKey
+	count, err := h.redis.CreateSubscription(c.UserContext(), cache.SubscriptionRecord{KeyName: key.Name, Subscription: sub}, limit)
+	if err != nil {
+		h.logger.Error("Failed to save subscription", zap.Error(err), zap.String("api_key", key.Name))
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeInternal,
+			Error: "Failed to create subscription",
+		})
+	}
+	if count >= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Code:    models.ErrCodeInvalidRequest,
+			Error:   fmt.Sprintf("An API key may have at most %d subscriptions", limit),
+			Details: &models.ErrorDetails{Limit: limit, Used: count},
+		})
+	}
+
+	h.logger.Info("Subscription created",
+		zap.String("api_key", key.Name),
+		zap.String("id", sub.ID),
+		zap.String("mode", sub.Mode),
+		zap.String("network", sub.Network),
+		zap.String("token", sub.Token),
+	)
+
+	return c.Status(fiber.StatusCreated).JSON(sub)
+}
+
+// validateSubscription checks a subscription request against the chain and
+// the API key. Like the other handler helpers it returns a status of 0 when
+// the subscription may be created.
+func (h *Handler) validateSubscription(key *config.APIKeyConfig, req models.SubscriptionRequest) (int, models.ErrorResponse) {
+	chain, _, err := h.getChain(req.Network)
+	if err != nil {
+		return fiber.StatusBadRequest, models.ErrorResponse{
+			Code:  models.ErrCodeUnsupportedNetwork,
+			Error: err.Error(),
+		}
+	}
+	if err := chain.ValidateAddress(req.Address); err != nil {
+		return fiber.StatusBadRequest, models.ErrorResponse{
+			Code:  models.ErrCodeInvalidAddress,
+			Error: "Invalid address: " + err.Error(),
+		}
+	}
+	if err := chain.ValidateToken(req.Token); err != nil {
+		return fiber.StatusBadRequest, models.ErrorResponse{
+			Code:  models.ErrCodeUnsupportedToken,
+			Error: err.Error(),
+		}
+	}
+
+	switch req.Mode {
+	case models.SubscriptionTopUp:
+		if req.MinBalance <= 0 {
+			return fiber.StatusBadRequest, models.ErrorResponse{
+				Code:  models.ErrCodeInvalidRequest,
+				Error: "A top_up subscription needs a positive min_balance",
+			}
+		}
+	case models.SubscriptionInterval:
+		if req.Amount <= 0 {
+			return fiber.StatusBadRequest, models.ErrorResponse{
+				Code:  models.ErrCodeInvalidRequest,
+				Error: "An interval subscription needs a positive amount",
+			}
+		}
+		if minimum := h.config().Subscriptions.MinIntervalSec; req.IntervalSec < minimum {
+			return fiber.StatusBadRequest, models.ErrorResponse{
+				Code:    models.ErrCodeInvalidRequest,
+				Error:   fmt.Sprintf("interval_seconds must be at least %d", minimum),
+				Details: &models.ErrorDetails{Limit: minimum},
+			}
+		}
+	default:
+		return fiber.StatusBadRequest, models.ErrorResponse{
+			Code:  models.ErrCodeInvalidRequest,
+			Error: fmt.Sprintf("mode must be %q or %q", models.SubscriptionTopUp, models.SubscriptionInterval),
+		}
+	}
+
+	if key.DailyBudget[req.Network][req.Token] <= 0 {
+		return fiber.StatusForbidden, models.ErrorResponse{
+			Code:    models.ErrCodeBudgetExceeded,
+			Error:   fmt.Sprintf("The API key has no budget for %s on %s", req.Token, req.Network),
+			Details: &models.ErrorDetails{Network: req.Network, Token: req.Token},
+		}
+	}
+	return 0, models.ErrorResponse{}
+}
+
+// ListSubscriptions returns the API key's subscriptions, oldest first
+func (h *Handler) ListSubscriptions(c *fiber.Ctx) error {
+	key, status, body := h.apiKey(c)
+	if status != 0 {
+		return c.Status(status).JSON(body)
+	}
+
+	records, err := h.redis.ListSubscriptions(c.UserContext(), key.Name)
+	if err != nil {
+		h.logger.Error("Failed to list subscriptions", zap.Error(err), zap.String("api_key", key.Name))
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeInternal,
+			Error: "Failed to list subscriptions",
+		})
+	}
+
+	response := models.SubscriptionsResponse{Subscriptions: make([]models.Subscription, len(records))}
+	for i, record := range records {
+		response.Subscriptions[i] = record.Subscription
+	}
+	sort.Slice(response.Subscriptions, func(i, j int) bool {
+		return response.Subscriptions[i].CreatedAt.Before(response.Subscriptions[j].CreatedAt)
+	})
+	return c.JSON(response)
+}
+
+// DeleteSubscription cancels one of the API key's subscriptions
+func (h *Handler) DeleteSubscription(c *fiber.Ctx) error {
+	key, status, body := h.apiKey(c)
+	if status != 0 {
+		return c.Status(status).JSON(body)
+	}
+
+	record, err := h.redis.GetSubscription(c.UserContext(), c.Params("id"))
+	if err != nil {
+		h.logger.Error("Failed to read subscription", zap.Error(err), zap.String("api_key", key.Name))
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeInternal,
+			Error: "Failed to delete subscription",
+		})
+	}
+	// Other keys' subscriptions are reported as missing, not forbidden
+	if record == nil || record.KeyName != key.Name {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeNotFound,
+			Error: "Subscription not found",
+		})
+	}
+
+	if err := h.redis.DeleteSubscription(c.UserContext(), *record); err != nil {
+		h.logger.Error("Failed to delete subscription", zap.Error(err), zap.String("api_key", key.Name))
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Code:  models.ErrCodeInternal,
+			Error: "Failed to delete subscription",
+		})
+	}
+
+	h.logger.Info("Subscription deleted", zap.String("api_key", key.Name), zap.String("id", record.Subscription.ID))
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// StartSubscriptionScheduler checks subscriptions for drips that are due
+// immediately and then every interval, until ctx is cancelled
+func (h *Handler) StartSubscriptionScheduler(ctx context.Context, interval time.Duration) {
+	go func() {
+		h.RunSubscriptions(ctx)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				h.RunSubscriptions(ctx)
+			}
+		}
+	}()
+}
+
+// RunSubscriptions sends every drip that is due. Each subscription is claimed
+// first, so with several server instances only one of them sends it.
+func (h *Handler) RunSubscriptions(ctx context.Context) {
+	records, err := h.redis.ListSubscriptions(ctx, "")
+	if err != nil {
+		h.logger.Error("Failed to list subscriptions", zap.Error(err))
+		return
+	}
+
+	now := time.Now()
+	for _, record := range records {
+		if ctx.Err() != nil {
+			return
+		}
+		if h.subscriptionDue(record.Subscription, now) {
+			h.runClaimed(ctx, record.Subscription.ID)
+		}
+	}
+}
+
+// runClaimed claims a subscription and runs it if it is still due, holding
+// the claim until the outcome is saved. The listed record may be stale: another
+// instance can have run and released it since.
+func (h *Handler) runClaimed(ctx context.Context, id string) {
+	// Released after the save; the expiry only covers an instance that dies
+	// mid-run, which may read two balances and send one transfer
+	ttl := h.config().SubscriptionCheckInterval() + 3*h.config().ChainTimeout()
+	claim, err := h.redis.ClaimSubscription(ctx, id, ttl)
+	if err != nil {
+		h.logger.Error("Failed to claim subscription", zap.Error(err), zap.String("id", id))
+		return
+	}
+	if claim == "" {
+		return
+	}
+	defer func() {
+		if err := h.redis.ReleaseSubscription(context.WithoutCancel(ctx), id, claim); err != nil {
+			h.logger.Error("Failed to release subscription", zap.Error(err), zap.String("id", id))
+		}
+	}()
+
+	record, err := h.redis.GetSubscription(ctx, id)
+	if err != nil {
+		h.logger.Error("Failed to read subscription", zap.Error(err), zap.String("id", id))
+		return
+	}
+	if record == nil || !h.subscriptionDue(record.Subscription, time.Now()) {
+		return
+	}
+	h.runSubscription(ctx, *record)
+}
+
+// subscriptionDue reports whether a subscription should be run now. Interval
+// subscriptions are due once their interval has passed; top-ups are checked
+// on every run once the cooldown after their last top-up has passed.
+func (h *Handler) subscriptionDue(sub models.Subscription, now time.Time) bool {
+	if sub.LastRunAt == nil {
+		return true
+	}
+	wait := h.config().SubscriptionTopUpCooldown()
+	if sub.Mode == models.SubscriptionInterval {
+		wait = time.Duration(sub.IntervalSec) * time.Second
+	}
+	return !now.Before(sub.LastRunAt.Add(wait))
+}
+
+// runSubscription sends one drip, charged to the API key's budget, and
+// records the outcome on the subscription
+func (h *Handler) runSubscription(ctx context.Context, record cache.SubscriptionRecord) {
+	sub := record.Subscription
+	logger := h.logger.With(
+		zap.String("api_key", record.KeyName),
+		zap.String("id", sub.ID),
+		zap.String("network", sub.Network),
+		zap.String("token", sub.Token),
+	)
+
+	key := h.config().APIKey(record.KeyName)
+	if key == nil {
+		// The key was removed from the config: keep the subscription, but never charge it
+		logger.Warn("Skipping subscription of an unknown API key")
+		return
+	}
+	chain, provider, err := h.getChain(sub.Network)
+	if err != nil {
+		logger.Warn("Skipping subscription on an unavailable network", zap.Error(err))
+		return
+	}
+
+	amount := sub.Amount
+	if sub.Mode == models.SubscriptionTopUp {
+		balanceCtx, cancel := h.chainContext(ctx)
+		balance, err := chain.GetBalance(balanceCtx, sub.Address, sub.Token)
+		cancel()
+		if err != nil {
+			logger.Warn("Failed to read subscription balance", zap.Error(err))
+			return
+		}
+		amount = sub.MinBalance - chains.FromBaseUnits(balance, provider.GetTokenDecimals(sub.Token))
+		if amount <= 0 {
+			return
+		}
+	}
+
+	now := time.Now().UTC()
+	sub.LastRunAt = &now
+	sub.LastCode, sub.LastError = "", ""
+
+	tx, status, body := h.sendSubscription(ctx, key, disbursement{
+		Network:   sub.Network,
+		Chain:     chain,
+		Provider:  provider,
+		Recipient: sub.Address,
+		Token:     sub.Token,
+		Amount:    strconv.FormatFloat(amount, 'f', -1, 64),
+	})
+	if status != 0 {
+		sub.LastCode, sub.LastError = body.Code, body.Error
+		logger.Warn("Subscription drip failed", zap.String("code", string(body.Code)), zap.String("error", body.Error))
+	} else {
+		sub.LastAmount, sub.LastTxHash = tx.Amount, tx.TxHash
+		logger.Info("Subscription drip sent", zap.String("amount", tx.Amount), zap.String("tx_hash", tx.TxHash))
+	}
+
+	// Record the outcome even during shutdown, so the drip is not sent twice
+	ctx = context.WithoutCancel(ctx)
+
+	// The subscription may have been deleted while the drip was sent; it stays deleted
+	record.Subscription = sub
+	if _, err := h.redis.UpdateSubscription(ctx, record); err != nil {
+		logger.Error("Failed to record subscription run", zap.Error(err))
+	}
+}
+
+// sendSubscription charges a drip to the API key's budget and sends it,
+// refunding the budget if it was not sent. Like the other handler helpers it
+// returns a status of 0 on success.
+func (h *Handler) sendSubscription(ctx context.Context, key *config.APIKeyConfig, d disbursement) (models.TransactionInfo, int, models.ErrorResponse) {
+	amount, _ := strconv.ParseFloat(d.Amount, 64)
+	line := cache.BudgetLine{
+		Network: d.Network,
+		Token:   d.Token,
+		Amount:  amount,
+		Limit:   key.DailyBudget[d.Network][d.Token],
+	}
+
+	reservedAt := time.Now()
+	over, err := h.redis.ReserveBudget(ctx, key.Name, []cache.BudgetLine{line}, reservedAt)
+	if err != nil {
+		h.logger.Error("Failed to reserve API key budget", zap.Error(err), zap.String("api_key", key.Name))
+		return models.TransactionInfo{}, fiber.StatusInternalServerError, models.ErrorResponse{
+			Code:  models.ErrCodeInternal,
+			Error: "Failed to check API key budget",
+		}
+	}
+	if over >= 0 {
+		return models.TransactionInfo{}, fiber.StatusTooManyRequests, retryHints(cache.BudgetResetAt(reservedAt), models.ErrorResponse{
+			Code:    models.ErrCodeBudgetExceeded,
+			Error:   fmt.Sprintf("Drip of %g %s on %s exceeds the API key's daily budget of %g", amount, d.Token, d.Network, line.Limit),
+			Details: &models.ErrorDetails{Network: d.Network, Token: d.Token},
+		})
+	}
+
+	// A started drip is finished even during shutdown
+	transferCtx, endTransfer := h.beginTransfer(ctx)
+	defer endTransfer()
+
+	tx, status, body := h.disburse(transferCtx, d)
+	if status != 0 {
+		if err := h.redis.ReleaseBudget(transferCtx, key.Name, d.Network, d.Token, amount, reservedAt); err != nil {
+			h.logger.Error("Failed to refund API key budget", zap.Error(err), zap.String("api_key", key.Name))
+		}
+	}
+	return tx, status, body
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/config"
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscriptionDue(t *testing.T) {
+	h := newTestHandler(&config.Config{Subscriptions: config.SubscriptionsConfig{TopUpCooldownSec: 600}})
+	now := time.Now()
+	ago := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+
+	tests := []struct {
+		name string
+		sub  models.Subscription
+		due  bool
+	}{
+		{"never run", models.Subscription{Mode: models.SubscriptionInterval, IntervalSec: 3600}, true},
+		{"interval not passed", models.Subscription{Mode: models.SubscriptionInterval, IntervalSec: 3600, LastRunAt: ago(time.Minute)}, false},
+		{"interval passed", models.Subscription{Mode: models.SubscriptionInterval, IntervalSec: 3600, LastRunAt: ago(time.Hour)}, true},
+		{"top-up cooling down", models.Subscription{Mode: models.SubscriptionTopUp, LastRunAt: ago(time.Minute)}, false},
+		{"top-up cooled down", models.Subscription{Mode: models.SubscriptionTopUp, LastRunAt: ago(10 * time.Minute)}, true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.due, h.subscriptionDue(tt.sub, now), tt.name)
+	}
+}
@@ -0,0 +1,186 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/Giri-Aayush/starknet-faucet/chains"
+	"github.com/Giri-Aayush/starknet-faucet/internal/cache"
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// InfoV2 describes every network the faucet serves, or only the one given
+// by the network query parameter, with the limits and balance of each token
+func (h *Handler) InfoV2(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	networks, err := h.queryNetworks(c)
+	if err != nil {
+		return unsupportedNetwork(c, err)
+	}
+
+	minDifficulty, maxDifficulty := h.powGenerator.DifficultyRange()
+	response := models.InfoResponseV2{
+		DefaultNetwork:     h.defaultNetwork,
+		DailyRequestsPerIP: h.config().MaxRequestsPerDayIP(),
+		PoW: models.PoWInfo{
+			Enabled:       true,
+			Algorithm:     h.powGenerator.Algorithm(),
+			Format:        h.powGenerator.DifficultyFormat(),
+			Difficulty:    h.config().PoWDifficulty(),
+			MinDifficulty: minDifficulty,
+			MaxDifficulty: maxDifficulty,
+		},
+		Networks: make([]models.NetworkInfo, 0, len(networks)),
+	}
+
+	for _, network := range networks {
+		chain, chainProvider := h.chains[network], h.providers()[network]
+		info := models.NetworkInfo{
+			Network:        network,
+			Chain:          chain.GetChainName(),
+			Name:           chain.GetNetworkName(),
+			Default:        network == h.defaultNetwork,
+			FaucetAddress:  chainProvider.GetFaucetAddress(),
+			OwnershipProof: h.config().RequiresOwnershipProof(network),
+			Tokens:         h.tokenInfos(ctx, network, chain, chainProvider, true),
+		}
+		if hv := h.config().HumanVerification; hv.Provider != "" {
+			info.HumanVerification = &models.HumanVerificationInfo{
+				Provider: hv.Provider,
+				SiteKey:  hv.SiteKey,
+				Required: h.config().RequiresHumanVerification(network, tierAnonymous),
+			}
+		}
+		response.Networks = append(response.Networks, info)
+	}
+
+	c.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", h.config().InfoMaxAge()))
+	return c.JSON(response)
+}
+
+// LimitsV2 lists how much the faucet sends of each token and how often,
+// without reading balances
+func (h *Handler) LimitsV2(c *fiber.Ctx) error {
+	networks, err := h.queryNetworks(c)
+	if err != nil {
+		return unsupportedNetwork(c, err)
+	}
+
+	response := models.LimitsResponse{
+		DailyRequestsPerIP: h.config().MaxRequestsPerDayIP(),
+		Networks:           make([]models.NetworkLimits, 0, len(networks)),
+	}
+	for _, network := range networks {
+		limits := models.NetworkLimits{Network: network, Tokens: []models.TokenLimits{}}
+		for _, t := range h.tokenInfos(c.UserContext(), network, h.chains[network], h.providers()[network], false) {
+			limits.Tokens = append(limits.Tokens, models.TokenLimits{
+				Symbol:                t.Symbol,
+				Decimals:              t.Decimals,
+				DripAmount:            t.DripAmount,
+				MaxPerHour:            t.MaxPerHour,
+				MaxPerDay:             t.MaxPerDay,
+				MaxRecipientBalance:   t.MaxRecipientBalance,
+				ThrottleWindowSeconds: t.ThrottleWindowSeconds,
+			})
+		}
+		response.Networks = append(response.Networks, limits)
+	}
+
+	c.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", h.config().InfoMaxAge()))
+	return c.JSON(response)
+}
+
+// BalancesV2 lists the faucet's balance of each token
+func (h *Handler) BalancesV2(c *fiber.Ctx) error {
+	networks, err := h.queryNetworks(c)
+	if err != nil {
+		return unsupportedNetwork(c, err)
+	}
+
+	response := models.BalancesResponse{
+		Networks: make([]models.NetworkBalances, 0, len(networks)),
+	}
+	for _, network := range networks {
+		chainProvider := h.providers()[network]
+		balances := models.NetworkBalances{
+			Network:       network,
+			FaucetAddress: chainProvider.GetFaucetAddress(),
+			Tokens:        []models.TokenBalance{},
+		}
+		for _, t := range h.tokenInfos(c.UserContext(), network, h.chains[network], chainProvider, true) {
+			balances.Tokens = append(balances.Tokens, models.TokenBalance{
+				Symbol:          t.Symbol,
+				Decimals:        t.Decimals,
+				ContractAddress: t.ContractAddress,
+				Balance:         t.Balance,
+			})
+		}
+		response.Networks = append(response.Networks, balances)
+	}
+
+	c.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", h.config().InfoMaxAge()))
+	return c.JSON(response)
+}
+
+// tokenInfos describes each token on a network. Balances come from the
+// balance cache and are only read when withBalances is set; a balance that
+// cannot be read is left empty rather than failing the whole response.
+func (h *Handler) tokenInfos(ctx context.Context, network string, chain chains.Chain, chainProvider ChainProvider, withBalances bool) []models.TokenInfo {
+	tokens := chain.GetSupportedTokens()
+	infos := make([]models.TokenInfo, 0, len(tokens))
+	for _, token := range tokens {
+		info := models.TokenInfo{
+			Symbol:                token,
+			Decimals:              chainProvider.GetTokenDecimals(token),
+			ContractAddress:       chainProvider.GetTokenAddress(token),
+			DripAmount:            chainProvider.GetDripAmount(token),
+			MaxPerHour:            chainProvider.GetMaxTokensPerHour(token),
+			MaxPerDay:             chainProvider.GetMaxTokensPerDay(token),
+			MaxRecipientBalance:   chainProvider.GetMaxRecipientBalance(token),
+			ThrottleWindowSeconds: int(cache.TokenThrottleWindow.Seconds()),
+		}
+		if withBalances {
+			balance, err := h.faucetBalance(ctx, network, chain, chainProvider, token)
+			if err != nil {
+				h.logger.Error("Failed to get balance", zap.Error(err), zap.String("network", network), zap.String("token", token))
+			} else {
+				info.Balance = chains.FormatUnits(balance, info.Decimals)
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// queryNetworks returns the network named by the network query parameter, or
+// every network in a stable order when it is absent
+func (h *Handler) queryNetworks(c *fiber.Ctx) ([]string, error) {
+	if network := c.Query("network"); network != "" {
+		if _, _, err := h.getChain(network); err != nil {
+			return nil, err
+		}
+		return []string{network}, nil
+	}
+
+	networks := make([]string, 0, len(h.chains))
+	for name := range h.chains {
+		if _, ok := h.providers()[name]; ok {
+			networks = append(networks, name)
+		}
+	}
+	// Stable order keeps the response body, and so its ETag, deterministic
+	sort.Strings(networks)
+	return networks, nil
+}
+
+// unsupportedNetwork responds to a request for a network the faucet does not serve
+func unsupportedNetwork(c *fiber.Ctx, err error) error {
+	return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+		Code:  models.ErrCodeUnsupportedNetwork,
+		Error: err.Error(),
+	})
+}
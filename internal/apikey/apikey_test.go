@@ -0,0 +1,32 @@
+package apikey
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAndLookup(t *testing.T) {
+	key, hash, err := Generate()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(key, Prefix))
+	assert.Equal(t, Hash(key), hash)
+
+	registry := NewRegistry([]Key{{Name: "other", Hash: Hash("fk_other")}, {Name: "qa", Hash: hash}})
+	found, err := registry.Lookup(key)
+	require.NoError(t, err)
+	assert.Equal(t, "qa", found.Name)
+}
+
+func TestLookupRejects(t *testing.T) {
+	registry := NewRegistry([]Key{{Name: "qa", Hash: Hash("fk_secret")}})
+
+	_, err := registry.Lookup("")
+	assert.ErrorIs(t, err, ErrMissing)
+	_, err = registry.Lookup("fk_wrong")
+	assert.ErrorIs(t, err, ErrUnknown)
+	_, err = registry.Lookup(Hash("fk_secret"))
+	assert.ErrorIs(t, err, ErrUnknown, "the stored hash is not itself a key")
+}
@@ -0,0 +1,125 @@
+package cache
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// BalanceCache keeps the faucet's own token balances in memory so that
+// info requests and balance protection checks don't each hit the RPC provider.
+// Entries are refreshed in the background and adjusted locally after every
+// transfer; an entry older than maxAge is treated as missing.
+type BalanceCache struct {
+	mu      sync.RWMutex
+	entries map[string]balanceEntry
+	debited map[string]*big.Int // total debited per token, so a chain read can be adjusted for transfers sent since
+	maxAge  time.Duration
+}
+
+type balanceEntry struct {
+	balance   *big.Int
+	updatedAt time.Time
+}
+
+// NewBalanceCache creates an empty balance cache
+func NewBalanceCache(maxAge time.Duration) *BalanceCache {
+	return &BalanceCache{
+		entries: make(map[string]balanceEntry),
+		debited: make(map[string]*big.Int),
+		maxAge:  maxAge,
+	}
+}
+
+// Get returns the cached balance for a token on a network, if present and fresh
+func (b *BalanceCache) Get(network, token string) (*big.Int, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	entry, ok := b.entries[balanceKey(network, token)]
+	if !ok || time.Since(entry.updatedAt) > b.maxAge {
+		return nil, false
+	}
+	return new(big.Int).Set(entry.balance), true
+}
+
+// Set stores a balance freshly read from chain
+func (b *BalanceCache) Set(network, token string, balance *big.Int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.entries[balanceKey(network, token)] = balanceEntry{
+		balance:   new(big.Int).Set(balance),
+		updatedAt: time.Now(),
+	}
+}
+
+// Debited returns the total amount debited from a token so far. Read it before
+// reading the balance from chain, and store the balance with SetRead.
+func (b *BalanceCache) Debited(network, token string) *big.Int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	total, ok := b.debited[balanceKey(network, token)]
+	if !ok {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(total)
+}
+
+// SetRead stores a balance read from chain, less whatever was debited after
+// debited was taken, since the read may predate those transfers. If the chain
+// had already seen one of them, the balance is understated until the next
+// refresh, which errs on the safe side for balance protection. Returns the
+// balance stored.
+func (b *BalanceCache) SetRead(network, token string, balance, debited *big.Int) *big.Int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key := balanceKey(network, token)
+	remaining := new(big.Int).Set(balance)
+	if total, ok := b.debited[key]; ok {
+		remaining.Sub(remaining, new(big.Int).Sub(total, debited))
+	}
+	if remaining.Sign() < 0 {
+		remaining.SetInt64(0)
+	}
+	b.entries[key] = balanceEntry{
+		balance:   remaining,
+		updatedAt: time.Now(),
+	}
+	return new(big.Int).Set(remaining)
+}
+
+// Debit subtracts a transfer we just sent from the cached balance, so the
+// next reader sees it without waiting for a refresh. Gas fees are not
+// included; the background refresh corrects for them.
+func (b *BalanceCache) Debit(network, token string, amount *big.Int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key := balanceKey(network, token)
+	total, ok := b.debited[key]
+	if !ok {
+		total = new(big.Int)
+		b.debited[key] = total
+	}
+	total.Add(total, amount)
+
+	entry, ok := b.entries[key]
+	if !ok {
+		return
+	}
+
+	remaining := new(big.Int).Sub(entry.balance, amount)
+	if remaining.Sign() < 0 {
+		remaining.SetInt64(0)
+	}
+	entry.balance = remaining
+	b.entries[key] = entry
+}
+
+func balanceKey(network, token string) string {
+	return fmt.Sprintf("%s:%s", network, token)
+}
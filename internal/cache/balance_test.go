@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBalanceCacheGetSet(t *testing.T) {
+	b := NewBalanceCache(time.Minute)
+	_, ok := b.Get("starknet", "STRK")
+	assert.False(t, ok)
+
+	balance := big.NewInt(100)
+	b.Set("starknet", "STRK", balance)
+	balance.SetInt64(1)
+
+	got, ok := b.Get("starknet", "STRK")
+	assert.True(t, ok)
+	assert.Equal(t, int64(100), got.Int64(), "the cache keeps its own copy")
+	got.SetInt64(2)
+	got, _ = b.Get("starknet", "STRK")
+	assert.Equal(t, int64(100), got.Int64(), "callers get a copy")
+
+	_, ok = b.Get("ethereum", "STRK")
+	assert.False(t, ok)
+}
+
+func TestBalanceCacheExpiry(t *testing.T) {
+	b := NewBalanceCache(20 * time.Millisecond)
+	b.Set("starknet", "STRK", big.NewInt(100))
+	time.Sleep(30 * time.Millisecond)
+
+	_, ok := b.Get("starknet", "STRK")
+	assert.False(t, ok)
+}
+
+func TestBalanceCacheDebit(t *testing.T) {
+	b := NewBalanceCache(time.Minute)
+	b.Debit("starknet", "STRK", big.NewInt(10))
+	_, ok := b.Get("starknet", "STRK")
+	assert.False(t, ok, "a debit does not create an entry")
+
+	b.Set("starknet", "STRK", big.NewInt(100))
+	b.Debit("starknet", "STRK", big.NewInt(30))
+	got, _ := b.Get("starknet", "STRK")
+	assert.Equal(t, int64(70), got.Int64())
+
+	b.Debit("starknet", "STRK", big.NewInt(100))
+	got, _ = b.Get("starknet", "STRK")
+	assert.Equal(t, int64(0), got.Int64(), "the balance is clamped at zero")
+}
+
+func TestBalanceCacheSetRead(t *testing.T) {
+	b := NewBalanceCache(time.Minute)
+	b.Set("starknet", "STRK", big.NewInt(100))
+
+	// A read that predates a debit is stored less that debit
+	debited := b.Debited("starknet", "STRK")
+	b.Debit("starknet", "STRK", big.NewInt(30))
+	assert.Equal(t, int64(65), b.SetRead("starknet", "STRK", big.NewInt(95), debited).Int64())
+	got, _ := b.Get("starknet", "STRK")
+	assert.Equal(t, int64(65), got.Int64())
+
+	// Earlier debits are already part of the read
+	debited = b.Debited("starknet", "STRK")
+	b.SetRead("starknet", "STRK", big.NewInt(64), debited)
+	got, _ = b.Get("starknet", "STRK")
+	assert.Equal(t, int64(64), got.Int64())
+
+	debited = b.Debited("starknet", "STRK")
+	b.Debit("starknet", "STRK", big.NewInt(50))
+	b.Debit("starknet", "STRK", big.NewInt(50))
+	b.SetRead("starknet", "STRK", big.NewInt(64), debited)
+	got, _ = b.Get("starknet", "STRK")
+	assert.Equal(t, int64(0), got.Int64(), "the balance is clamped at zero")
+}
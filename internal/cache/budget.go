@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// API key budgets are counted per UTC day in whole tokens, one counter per
+// key, network and token. Counters outlive their day so late refunds still land.
+const budgetTTL = 48 * time.Hour
+
+// BudgetLine is an amount of one token on one network to charge to an API key
+type BudgetLine struct {
+	Network string
+	Token   string
+	Amount  float64
+	Limit   float64 // The key's daily budget for this token
+}
+
+// reserveBudgetScript charges every line to its counter, or none of them if
+// any would pass its limit. ARGV holds amount and limit pairs, then the TTL.
+// Returns the 1-based index of the first line over budget, or 0.
+var reserveBudgetScript = redis.NewScript(`
+local n = #KEYS
+local ttl = tonumber(ARGV[2 * n + 1])
+for i = 1, n do
+	local spent = tonumber(redis.call('GET', KEYS[i]) or '0')
+	-- Tolerate float rounding, so a budget can be spent exactly
+	if spent + tonumber(ARGV[2 * i - 1]) > tonumber(ARGV[2 * i]) + 1e-9 then
+		return i
+	end
+end
+for i = 1, n do
+	redis.call('INCRBYFLOAT', KEYS[i], ARGV[2 * i - 1])
+	redis.call('EXPIRE', KEYS[i], ttl)
+end
+return 0
+`)
+
+// releaseBudgetScript refunds ARGV[1] to a counter, keeping its TTL of ARGV[2]
+var releaseBudgetScript = redis.NewScript(`
+redis.call('INCRBYFLOAT', KEYS[1], -tonumber(ARGV[1]))
+redis.call('EXPIRE', KEYS[1], ARGV[2])
+return 0
+`)
+
+func budgetKey(keyName, network, token string, now time.Time) string {
+	return fmt.Sprintf("apikey:budget:%s:%s:%s:%s", keyName, network, token, now.UTC().Format("2006-01-02"))
+}
+
+// BudgetResetAt returns when the budget day containing now ends: the next UTC midnight
+func BudgetResetAt(now time.Time) time.Time {
+	return now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+}
+
+// ReserveBudget charges all lines to an API key's budget for the day of now at
+// once. If any line would exceed its limit nothing is charged and its index is
+// returned; otherwise the index is -1.
+func (r *RedisClient) ReserveBudget(ctx context.Context, keyName string, lines []BudgetLine, now time.Time) (int, error) {
+	if len(lines) == 0 {
+		return -1, nil
+	}
+
+	keys := make([]string, len(lines))
+	args := make([]any, 0, 2*len(lines)+1)
+	for i, line := range lines {
+		keys[i] = budgetKey(keyName, line.Network, line.Token, now)
+		args = append(args, line.Amount, line.Limit)
+	}
+	args = append(args, int(budgetTTL.Seconds()))
+
+	over, err := reserveBudgetScript.Run(ctx, r.client, keys, args...).Int()
+	if err != nil {
+		return -1, err
+	}
+	return over - 1, nil
+}
+
+// ReleaseBudget returns an amount that was reserved at reservedAt but not
+// sent, to the day it was charged to
+func (r *RedisClient) ReleaseBudget(ctx context.Context, keyName, network, token string, amount float64, reservedAt time.Time) error {
+	key := budgetKey(keyName, network, token, reservedAt)
+	return releaseBudgetScript.Run(ctx, r.client, []string{key}, amount, int(budgetTTL.Seconds())).Err()
+}
+
+// BudgetSpent returns how much of a token an API key has been charged today
+func (r *RedisClient) BudgetSpent(ctx context.Context, keyName, network, token string) (float64, error) {
+	spent, err := r.client.Get(ctx, budgetKey(keyName, network, token, time.Now())).Float64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return spent, err
+}
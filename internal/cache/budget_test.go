@@ -0,0 +1,26 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBudgetResetAt(t *testing.T) {
+	lateEvening := time.Date(2030, 1, 2, 23, 59, 59, 0, time.UTC)
+	assert.Equal(t, time.Date(2030, 1, 3, 0, 0, 0, 0, time.UTC), BudgetResetAt(lateEvening))
+
+	midnight := time.Date(2030, 1, 3, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2030, 1, 4, 0, 0, 0, 0, time.UTC), BudgetResetAt(midnight))
+
+	// Budget days are UTC days whatever the server's zone
+	east := time.FixedZone("UTC+10", 10*60*60)
+	assert.Equal(t, time.Date(2030, 1, 3, 0, 0, 0, 0, time.UTC), BudgetResetAt(time.Date(2030, 1, 3, 9, 0, 0, 0, east)))
+}
+
+func TestBudgetKeyUsesReservationDay(t *testing.T) {
+	reserved := time.Date(2030, 1, 2, 23, 59, 59, 0, time.UTC)
+	assert.Equal(t, "apikey:budget:qa:starknet:STRK:2030-01-02", budgetKey("qa", "starknet", "STRK", reserved))
+	assert.NotEqual(t, budgetKey("qa", "starknet", "STRK", reserved), budgetKey("qa", "starknet", "STRK", reserved.Add(time.Second)))
+}
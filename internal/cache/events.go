@@ -0,0 +1,162 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// RequestEventsTTL is how long the events of a request can be replayed after the last one
+const RequestEventsTTL = time.Hour
+
+// Request events are kept in a list per request so that a stream opened late,
+// or on another instance, can replay them. Appends publish on a channel per
+// request to wake live streams, which then read the list from where they left off.
+// Each instance holds one pattern subscription to every request's channel and
+// hands wake-ups to its own streams, so streams cost no Redis connections.
+
+const requestEventsPrefix = "request:events:"
+
+func requestEventsKey(requestID string) string {
+	return requestEventsPrefix + requestID
+}
+
+func requestIssuedKey(requestID string) string {
+	return fmt.Sprintf("request:issued:%s", requestID)
+}
+
+// MarkRequestIssued records that a challenge for a request was handed out, so
+// its event stream can be opened before the request is made
+func (r *RedisClient) MarkRequestIssued(ctx context.Context, requestID string, ttl time.Duration) error {
+	return r.client.Set(ctx, requestIssuedKey(requestID), 1, ttl).Err()
+}
+
+// RequestKnown reports whether a request has recorded events or a live challenge
+func (r *RedisClient) RequestKnown(ctx context.Context, requestID string) (bool, error) {
+	n, err := r.client.Exists(ctx, requestEventsKey(requestID), requestIssuedKey(requestID)).Result()
+	return n > 0, err
+}
+
+// AppendRequestEvent records an event of a request and wakes its live streams
+func (r *RedisClient) AppendRequestEvent(ctx context.Context, requestID string, event models.RequestEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode request event: %w", err)
+	}
+
+	key := requestEventsKey(requestID)
+	pipe := r.client.TxPipeline()
+	pipe.RPush(ctx, key, data)
+	pipe.Expire(ctx, key, RequestEventsTTL)
+	pipe.Publish(ctx, key, "")
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// RequestEventsSince returns the events of a request after the first skip, in
+// order, with Seq numbered from 1 across the whole stream
+func (r *RedisClient) RequestEventsSince(ctx context.Context, requestID string, skip int) ([]models.RequestEvent, error) {
+	items, err := r.client.LRange(ctx, requestEventsKey(requestID), int64(skip), -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]models.RequestEvent, 0, len(items))
+	for i, item := range items {
+		var event models.RequestEvent
+		if err := json.Unmarshal([]byte(item), &event); err != nil {
+			return nil, fmt.Errorf("failed to decode request event: %w", err)
+		}
+		event.Seq = skip + i + 1
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// WatchRequestEvents returns a channel that receives a value whenever an event
+// is appended to a request. The subscription is active when it returns, so
+// reading RequestEventsSince afterwards misses nothing. Call stop when done.
+func (r *RedisClient) WatchRequestEvents(ctx context.Context, requestID string) (appended <-chan struct{}, stop func(), err error) {
+	if err := r.events.start(ctx, r.client); err != nil {
+		return nil, nil, err
+	}
+	notify := make(chan struct{}, 1)
+	r.events.add(requestID, notify)
+	return notify, func() { r.events.remove(requestID, notify) }, nil
+}
+
+// eventHub fans the instance's pattern subscription out to its live streams
+type eventHub struct {
+	mu       sync.Mutex
+	sub      *redis.PubSub
+	watchers map[string]map[chan struct{}]bool // by request ID
+}
+
+// start subscribes to every request's channel, once
+func (e *eventHub) start(ctx context.Context, client *redis.Client) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.sub != nil {
+		return nil
+	}
+
+	// The subscription outlives the stream that starts it
+	sub := client.PSubscribe(context.Background(), requestEventsKey("*"))
+	if _, err := sub.Receive(ctx); err != nil {
+		sub.Close()
+		return err
+	}
+	e.sub = sub
+	e.watchers = make(map[string]map[chan struct{}]bool)
+	go e.fanOut(sub.Channel())
+	return nil
+}
+
+// fanOut wakes the streams of each request an event is appended to
+func (e *eventHub) fanOut(messages <-chan *redis.Message) {
+	for msg := range messages {
+		requestID := strings.TrimPrefix(msg.Channel, requestEventsPrefix)
+		e.mu.Lock()
+		for notify := range e.watchers[requestID] {
+			// Coalesce: one pending wake-up covers any number of appends
+			select {
+			case notify <- struct{}{}:
+			default:
+			}
+		}
+		e.mu.Unlock()
+	}
+}
+
+func (e *eventHub) add(requestID string, notify chan struct{}) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.watchers[requestID] == nil {
+		e.watchers[requestID] = make(map[chan struct{}]bool)
+	}
+	e.watchers[requestID][notify] = true
+}
+
+func (e *eventHub) remove(requestID string, notify chan struct{}) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.watchers[requestID], notify)
+	if len(e.watchers[requestID]) == 0 {
+		delete(e.watchers, requestID)
+	}
+}
+
+// close ends the subscription; streams still open stop being woken
+func (e *eventHub) close() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.sub != nil {
+		e.sub.Close()
+	}
+}
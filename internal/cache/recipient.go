@@ -0,0 +1,37 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// GetRecipientBalance returns a recipient's balance stored by
+// SetRecipientBalance, in the token's smallest unit, if it has not expired
+func (r *RedisClient) GetRecipientBalance(ctx context.Context, network, address, token string) (*big.Int, bool, error) {
+	value, err := r.client.Get(ctx, recipientBalanceKey(network, address, token)).Result()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	balance, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return nil, false, nil
+	}
+	return balance, true, nil
+}
+
+// SetRecipientBalance stores a recipient's balance read from chain for ttl
+func (r *RedisClient) SetRecipientBalance(ctx context.Context, network, address, token string, balance *big.Int, ttl time.Duration) error {
+	return r.client.Set(ctx, recipientBalanceKey(network, address, token), balance.String(), ttl).Err()
+}
+
+func recipientBalanceKey(network, address, token string) string {
+	return fmt.Sprintf("recipient:balance:%s:%s:%s", network, address, token)
+}
@@ -2,21 +2,23 @@ package cache
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
 	"github.com/redis/go-redis/v9"
 )
 
 // RedisClient wraps the Redis client with faucet-specific operations
 type RedisClient struct {
-	client                *redis.Client
-	maxDailyRequestsIP    int // Max requests per IP per day (5)
-	maxChallengesPerHour  int // Max PoW challenges per IP per hour (8)
+	client               *redis.Client
+	maxChallengesPerHour int // Max PoW challenges per IP per hour (8)
+	events               eventHub
 }
 
 // NewRedisClient creates a new Redis client
-func NewRedisClient(redisURL string, maxDailyRequestsIP, maxChallengesPerHour int) (*RedisClient, error) {
+func NewRedisClient(redisURL string, maxChallengesPerHour int) (*RedisClient, error) {
 	opt, err := redis.ParseURL(redisURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse Redis URL: %w", err)
@@ -33,71 +35,149 @@ func NewRedisClient(redisURL string, maxDailyRequestsIP, maxChallengesPerHour in
 	}
 
 	return &RedisClient{
-		client:                client,
-		maxDailyRequestsIP:    maxDailyRequestsIP,
-		maxChallengesPerHour:  maxChallengesPerHour,
+		client:               client,
+		maxChallengesPerHour: maxChallengesPerHour,
 	}, nil
 }
 
 // Close closes the Redis connection
 func (r *RedisClient) Close() error {
+	r.events.close()
 	return r.client.Close()
 }
 
 // Challenge-related operations
 
+// ChallengeRecord is a PoW challenge as stored in Redis.
+// The difficulty is stored per challenge because it varies with load.
+// Address, Network and Token bind the challenge to the request it was issued for.
+type ChallengeRecord struct {
+	Challenge  string           `json:"challenge"`
+	Difficulty int              `json:"difficulty"`
+	Bits       int              `json:"bits"`
+	Algorithm  string           `json:"algorithm"`
+	Params     models.PoWParams `json:"params"`
+	Address    string           `json:"address"`
+	Network    string           `json:"network"`
+	Token      string           `json:"token"`
+	ExpiresAt  int64            `json:"expires_at,omitempty"`
+}
+
 // StoreChallenge stores a challenge in Redis with TTL
-func (r *RedisClient) StoreChallenge(ctx context.Context, challengeID, challenge string, ttl time.Duration) error {
+func (r *RedisClient) StoreChallenge(ctx context.Context, challengeID string, record ChallengeRecord, ttl time.Duration) error {
 	key := fmt.Sprintf("challenge:%s", challengeID)
-	return r.client.Set(ctx, key, challenge, ttl).Err()
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode challenge: %w", err)
+	}
+	return r.client.Set(ctx, key, data, ttl).Err()
 }
 
 // GetChallenge retrieves a challenge from Redis
-func (r *RedisClient) GetChallenge(ctx context.Context, challengeID string) (string, error) {
+func (r *RedisClient) GetChallenge(ctx context.Context, challengeID string) (*ChallengeRecord, error) {
 	key := fmt.Sprintf("challenge:%s", challengeID)
-	return r.client.Get(ctx, key).Result()
+	data, err := r.client.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	var record ChallengeRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to decode challenge: %w", err)
+	}
+	// Challenges stored before bit-level difficulty counted leading hex zeros
+	if record.Bits == 0 {
+		record.Bits = record.Difficulty * 4
+	}
+	return &record, nil
 }
 
-// DeleteChallenge removes a challenge from Redis (prevents reuse)
-func (r *RedisClient) DeleteChallenge(ctx context.Context, challengeID string) error {
+// DeleteChallenge removes a challenge from Redis (prevents reuse).
+// Returns false if it was already gone, so only one caller can spend it.
+func (r *RedisClient) DeleteChallenge(ctx context.Context, challengeID string) (bool, error) {
 	key := fmt.Sprintf("challenge:%s", challengeID)
-	return r.client.Del(ctx, key).Err()
+	deleted, err := r.client.Del(ctx, key).Result()
+	return deleted == 1, err
+}
+
+// MarkChallengeSpent records a stateless challenge as used until it expires.
+// Returns false if it was already spent.
+func (r *RedisClient) MarkChallengeSpent(ctx context.Context, seed string, ttl time.Duration) (bool, error) {
+	key := fmt.Sprintf("challenge:spent:%s", seed)
+	return r.client.SetNX(ctx, key, 1, ttl).Result()
+}
+
+// CountChallengeAttempt records a submitted solution for a challenge and
+// returns how many have been submitted, including this one. The count is
+// kept for ttl, which should outlive the challenge.
+func (r *RedisClient) CountChallengeAttempt(ctx context.Context, seed string, ttl time.Duration) (int64, error) {
+	key := fmt.Sprintf("challenge:attempts:%s", seed)
+	pipe := r.client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
 }
 
 // New Simplified Rate Limiting Operations
 
-// CheckIPDailyLimit checks if IP has exceeded daily request limit (5/day) or is in 24h cooldown
+// Subject identifies who a request quota is charged to: a client IP address,
+// or a signed-in account. Kind is part of every key, so the two never collide.
+type Subject struct {
+	Kind       string // "ip", "subnet" or an identity provider such as "github"
+	ID         string
+	DailyLimit int // Max requests per day for this subject
+}
+
+// IPSubject returns the quota subject for a client IP address
+func IPSubject(ip string, dailyLimit int) Subject {
+	return Subject{Kind: "ip", ID: ip, DailyLimit: dailyLimit}
+}
+
+// SubnetSubject returns the quota subject shared by every client in a subnet
+func SubnetSubject(prefix string, dailyLimit int) Subject {
+	return Subject{Kind: "subnet", ID: prefix, DailyLimit: dailyLimit}
+}
+
+// AccountSubject returns the quota subject for a signed-in account
+func AccountSubject(provider, accountID string, dailyLimit int) Subject {
+	return Subject{Kind: provider, ID: accountID, DailyLimit: dailyLimit}
+}
+
+// CheckDailyLimit checks if a subject has exceeded its daily request limit or is in 24h cooldown
 // Returns (canRequest, currentCount, cooldownEnd, error)
-func (r *RedisClient) CheckIPDailyLimit(ctx context.Context, ip string) (bool, int, *time.Time, error) {
-	// First check if IP is in 24h cooldown (after hitting 5 requests)
-	cooldownKey := fmt.Sprintf("cooldown:ip:%s", ip)
+func (r *RedisClient) CheckDailyLimit(ctx context.Context, s Subject) (bool, int, *time.Time, error) {
+	// First check if subject is in 24h cooldown (after hitting the limit)
+	cooldownKey := fmt.Sprintf("cooldown:%s:%s", s.Kind, s.ID)
 	cooldownEnd, err := r.client.Get(ctx, cooldownKey).Result()
 	if err == nil {
 		// Cooldown exists, parse the end time
 		endTime, parseErr := time.Parse(time.RFC3339, cooldownEnd)
 		if parseErr == nil && time.Now().Before(endTime) {
-			return false, r.maxDailyRequestsIP, &endTime, nil
+			return false, s.DailyLimit, &endTime, nil
 		}
 		// Cooldown expired, delete it
 		r.client.Del(ctx, cooldownKey)
 	}
 
 	// Check current request count
-	key := fmt.Sprintf("ratelimit:ip:day:%s", ip)
+	key := fmt.Sprintf("ratelimit:%s:day:%s", s.Kind, s.ID)
 	count, err := r.client.Get(ctx, key).Int()
 	if err != nil && err != redis.Nil {
 		return false, 0, nil, err
 	}
-	if count >= r.maxDailyRequestsIP {
+	if count >= s.DailyLimit {
 		return false, count, nil, nil
 	}
 	return true, count, nil, nil
 }
 
-// IncrementIPDailyLimit increments IP daily counter by specified amount (1 for single token, 2 for BOTH)
-// If this increment reaches the max limit (5), it sets a 24-hour cooldown
-func (r *RedisClient) IncrementIPDailyLimit(ctx context.Context, ip string, incrementBy int) error {
-	key := fmt.Sprintf("ratelimit:ip:day:%s", ip)
+// IncrementDailyLimit increments a subject's daily counter by specified amount (1 for single token, 2 for BOTH)
+// If this increment reaches the subject's limit, it sets a 24-hour cooldown
+func (r *RedisClient) IncrementDailyLimit(ctx context.Context, s Subject, incrementBy int) error {
+	key := fmt.Sprintf("ratelimit:%s:day:%s", s.Kind, s.ID)
 
 	// Increment counter
 	newCount, err := r.client.IncrBy(ctx, key, int64(incrementBy)).Result()
@@ -106,8 +186,8 @@ func (r *RedisClient) IncrementIPDailyLimit(ctx context.Context, ip string, incr
 	}
 
 	// If we've reached the limit, set 24h cooldown
-	if newCount >= int64(r.maxDailyRequestsIP) {
-		cooldownKey := fmt.Sprintf("cooldown:ip:%s", ip)
+	if newCount >= int64(s.DailyLimit) {
+		cooldownKey := fmt.Sprintf("cooldown:%s:%s", s.Kind, s.ID)
 		cooldownEnd := time.Now().Add(24 * time.Hour)
 
 		pipe := r.client.Pipeline()
@@ -121,11 +201,32 @@ func (r *RedisClient) IncrementIPDailyLimit(ctx context.Context, ip string, incr
 	return r.client.Expire(ctx, key, 24*time.Hour).Err()
 }
 
+// DailyLimitReset returns when a subject's daily quota frees up again: the end
+// of its cooldown, or when its request counter expires
+func (r *RedisClient) DailyLimitReset(ctx context.Context, s Subject) time.Time {
+	cooldownEnd, err := r.client.Get(ctx, fmt.Sprintf("cooldown:%s:%s", s.Kind, s.ID)).Result()
+	if err == nil {
+		if endTime, parseErr := time.Parse(time.RFC3339, cooldownEnd); parseErr == nil {
+			return endTime
+		}
+	}
+	return r.expiresAt(ctx, fmt.Sprintf("ratelimit:%s:day:%s", s.Kind, s.ID), 24*time.Hour)
+}
+
+// expiresAt returns when key expires, or fallback from now if it has no TTL
+func (r *RedisClient) expiresAt(ctx context.Context, key string, fallback time.Duration) time.Time {
+	ttl, err := r.client.TTL(ctx, key).Result()
+	if err != nil || ttl <= 0 {
+		ttl = fallback
+	}
+	return time.Now().Add(ttl)
+}
+
 // CheckTokenHourlyThrottle checks if a specific token on a specific network was requested in the last hour
 // Returns (canRequest, nextAvailableTime, error)
 // The throttle is per-network, so Starknet ETH and Ethereum ETH have separate throttles
-func (r *RedisClient) CheckTokenHourlyThrottle(ctx context.Context, ip, network, token string) (bool, *time.Time, error) {
-	key := fmt.Sprintf("throttle:ip:network:token:%s:%s:%s", ip, network, token)
+func (r *RedisClient) CheckTokenHourlyThrottle(ctx context.Context, s Subject, network, token string) (bool, *time.Time, error) {
+	key := fmt.Sprintf("throttle:%s:network:token:%s:%s:%s", s.Kind, s.ID, network, token)
 
 	// Check if key exists
 	exists, err := r.client.Exists(ctx, key).Result()
@@ -147,28 +248,31 @@ func (r *RedisClient) CheckTokenHourlyThrottle(ctx context.Context, ip, network,
 	return false, &nextAvailable, nil
 }
 
+// TokenThrottleWindow is the minimum time between requests for the same token on a network
+const TokenThrottleWindow = time.Hour
+
 // SetTokenHourlyThrottle sets hourly throttle for a token on a specific network (1 hour cooldown)
 // The throttle is per-network, so Starknet ETH and Ethereum ETH have separate throttles
-func (r *RedisClient) SetTokenHourlyThrottle(ctx context.Context, ip, network, token string) error {
-	key := fmt.Sprintf("throttle:ip:network:token:%s:%s:%s", ip, network, token)
-	return r.client.Set(ctx, key, time.Now().Unix(), time.Hour).Err()
+func (r *RedisClient) SetTokenHourlyThrottle(ctx context.Context, s Subject, network, token string) error {
+	key := fmt.Sprintf("throttle:%s:network:token:%s:%s:%s", s.Kind, s.ID, network, token)
+	return r.client.Set(ctx, key, time.Now().Unix(), TokenThrottleWindow).Err()
 }
 
-// GetIPDailyQuota returns current usage, remaining quota, and cooldown end time for an IP
-func (r *RedisClient) GetIPDailyQuota(ctx context.Context, ip string) (used, remaining int, cooldownEnd *time.Time, err error) {
+// GetDailyQuota returns current usage, remaining quota, and cooldown end time for a subject
+func (r *RedisClient) GetDailyQuota(ctx context.Context, s Subject) (used, remaining int, cooldownEnd *time.Time, err error) {
 	// Check if in cooldown
-	cooldownKey := fmt.Sprintf("cooldown:ip:%s", ip)
+	cooldownKey := fmt.Sprintf("cooldown:%s:%s", s.Kind, s.ID)
 	cooldownEndStr, err := r.client.Get(ctx, cooldownKey).Result()
 	if err == nil {
 		// Parse cooldown end time
 		endTime, parseErr := time.Parse(time.RFC3339, cooldownEndStr)
 		if parseErr == nil && time.Now().Before(endTime) {
-			return r.maxDailyRequestsIP, 0, &endTime, nil
+			return s.DailyLimit, 0, &endTime, nil
 		}
 	}
 
 	// Not in cooldown, check current count
-	key := fmt.Sprintf("ratelimit:ip:day:%s", ip)
+	key := fmt.Sprintf("ratelimit:%s:day:%s", s.Kind, s.ID)
 	count, err := r.client.Get(ctx, key).Int()
 	if err != nil && err != redis.Nil {
 		return 0, 0, nil, err
@@ -176,14 +280,13 @@ func (r *RedisClient) GetIPDailyQuota(ctx context.Context, ip string) (used, rem
 	if err == redis.Nil {
 		count = 0
 	}
-	remaining = r.maxDailyRequestsIP - count
+	remaining = s.DailyLimit - count
 	if remaining < 0 {
 		remaining = 0
 	}
 	return count, remaining, nil, nil
 }
 
-
 // Global distribution tracking (anti-drain protection)
 
 // TrackGlobalDistribution tracks tokens distributed globally and checks limits
@@ -259,16 +362,18 @@ func (r *RedisClient) GetGlobalDistribution(ctx context.Context, tokenType strin
 // Challenge rate limiting
 
 // CheckChallengeRateLimit checks if an IP has exceeded challenge request limits
-func (r *RedisClient) CheckChallengeRateLimit(ctx context.Context, ip string) (bool, error) {
+// Returns (canRequest, resetTime, error); resetTime is only set when the limit is reached
+func (r *RedisClient) CheckChallengeRateLimit(ctx context.Context, ip string) (bool, *time.Time, error) {
 	key := fmt.Sprintf("ratelimit:challenge:hour:%s", ip)
 	count, err := r.client.Get(ctx, key).Int()
 	if err != nil && err != redis.Nil {
-		return false, err
+		return false, nil, err
 	}
 	if count >= r.maxChallengesPerHour {
-		return false, nil
+		reset := r.expiresAt(ctx, key, time.Hour)
+		return false, &reset, nil
 	}
-	return true, nil
+	return true, nil, nil
 }
 
 // IncrementChallengeRateLimit increments the challenge rate limit counter for an IP
@@ -281,6 +386,41 @@ func (r *RedisClient) IncrementChallengeRateLimit(ctx context.Context, ip string
 	return err
 }
 
+// Adaptive PoW signals
+
+// IncrementChallengeLoad counts an issued challenge in the current minute
+// bucket and returns the total so far, used as the global load signal
+func (r *RedisClient) IncrementChallengeLoad(ctx context.Context) (int, error) {
+	key := fmt.Sprintf("pow:load:minute:%d", time.Now().Unix()/60)
+	pipe := r.client.Pipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, 2*time.Minute)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return int(incr.Val()), nil
+}
+
+// RecordPoWPenalty records an invalid solution or rejected request for an IP.
+// Penalties raise the PoW difficulty of that IP's next challenges until the window expires.
+func (r *RedisClient) RecordPoWPenalty(ctx context.Context, ip string, window time.Duration) error {
+	key := fmt.Sprintf("pow:penalty:ip:%s", ip)
+	pipe := r.client.Pipeline()
+	pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, window)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetPoWPenalty returns the number of recent penalties recorded for an IP
+func (r *RedisClient) GetPoWPenalty(ctx context.Context, ip string) (int, error) {
+	key := fmt.Sprintf("pow:penalty:ip:%s", ip)
+	count, err := r.client.Get(ctx, key).Int()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return count, err
+}
 
 // Health check
 
@@ -0,0 +1,200 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Statistics are counted in time buckets that roll up into windows: 5-minute
+// buckets make up the last hour, hourly buckets the last day and week. All-time
+// totals have their own counters. Each bucket is a hash of counters, with a
+// HyperLogLog of its recipients beside it.
+const (
+	statsFineBucket   = 5 * time.Minute
+	statsCoarseBucket = time.Hour
+
+	statsFineTTL   = 2 * time.Hour
+	statsCoarseTTL = 8 * 24 * time.Hour
+
+	statsHourBuckets = int(time.Hour / statsFineBucket)
+	statsDayBuckets  = 24
+	statsWeekBuckets = 7 * 24
+)
+
+// Stats counter fields
+const (
+	statsAmountField    = "amount"    // amount:<network>:<token>
+	statsTransfersField = "transfers" // transfers:<network>:<token>
+	statsOutcomeField   = "outcome"   // outcome:<outcome>
+	statsRejectedField  = "rejected"  // rejected:<code>
+)
+
+// StatsCounters are the counters of one time window, keyed by field
+type StatsCounters struct {
+	Fields     map[string]float64
+	Recipients int64
+}
+
+// Amount returns the amount sent of a token on a network
+func (s StatsCounters) Amount(network, token string) float64 {
+	return s.Fields[statsField(statsAmountField, network, token)]
+}
+
+// Transfers returns the number of transfers of a token on a network
+func (s StatsCounters) Transfers(network, token string) int64 {
+	return int64(s.Fields[statsField(statsTransfersField, network, token)])
+}
+
+// Outcome returns the number of requests with an outcome
+func (s StatsCounters) Outcome(outcome string) int64 {
+	return int64(s.Fields[statsField(statsOutcomeField, outcome)])
+}
+
+// Tokens returns the network and token of every transfer counted
+func (s StatsCounters) Tokens() [][2]string {
+	var tokens [][2]string
+	for field := range s.Fields {
+		parts := strings.SplitN(field, ":", 3)
+		if len(parts) == 3 && parts[0] == statsTransfersField {
+			tokens = append(tokens, [2]string{parts[1], parts[2]})
+		}
+	}
+	return tokens
+}
+
+// Rejections returns the number of rejected requests per error code
+func (s StatsCounters) Rejections() map[string]int64 {
+	rejections := map[string]int64{}
+	prefix := statsRejectedField + ":"
+	for field, count := range s.Fields {
+		if code, ok := strings.CutPrefix(field, prefix); ok {
+			rejections[code] = int64(count)
+		}
+	}
+	return rejections
+}
+
+func statsField(parts ...string) string {
+	return strings.Join(parts, ":")
+}
+
+// statsBucketKeys returns the keys of the counters and recipients of the
+// bucket of the given size holding t
+func statsBucketKeys(size time.Duration, t time.Time) (counters, recipients string) {
+	start := t.Truncate(size).Unix()
+	label := fmt.Sprintf("%dm", int(size/time.Minute))
+	return fmt.Sprintf("stats:%s:%d", label, start), fmt.Sprintf("stats:recipients:%s:%d", label, start)
+}
+
+const (
+	statsTotalKey           = "stats:total"
+	statsTotalRecipientsKey = "stats:recipients:total"
+)
+
+// recordStats applies fn to the counters of every bucket holding now, and adds the
+// recipient, if any, to their recipients
+func (r *RedisClient) recordStats(ctx context.Context, recipient string, fn func(pipe redis.Pipeliner, key string)) error {
+	now := time.Now()
+	pipe := r.client.Pipeline()
+	for _, bucket := range []struct {
+		size time.Duration
+		ttl  time.Duration
+	}{{statsFineBucket, statsFineTTL}, {statsCoarseBucket, statsCoarseTTL}} {
+		counters, recipients := statsBucketKeys(bucket.size, now)
+		fn(pipe, counters)
+		pipe.Expire(ctx, counters, bucket.ttl)
+		if recipient != "" {
+			pipe.PFAdd(ctx, recipients, recipient)
+			pipe.Expire(ctx, recipients, bucket.ttl)
+		}
+	}
+	fn(pipe, statsTotalKey)
+	if recipient != "" {
+		pipe.PFAdd(ctx, statsTotalRecipientsKey, recipient)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// RecordTransfer counts tokens sent to a recipient
+func (r *RedisClient) RecordTransfer(ctx context.Context, network, token, recipient string, amount float64) error {
+	return r.recordStats(ctx, strings.ToLower(recipient), func(pipe redis.Pipeliner, key string) {
+		pipe.HIncrByFloat(ctx, key, statsField(statsAmountField, network, token), amount)
+		pipe.HIncrBy(ctx, key, statsField(statsTransfersField, network, token), 1)
+	})
+}
+
+// RecordOutcome counts a finished faucet request. Rejected requests are also
+// counted under their error code.
+func (r *RedisClient) RecordOutcome(ctx context.Context, outcome, code string) error {
+	return r.recordStats(ctx, "", func(pipe redis.Pipeliner, key string) {
+		pipe.HIncrBy(ctx, key, statsField(statsOutcomeField, outcome), 1)
+		if code != "" {
+			pipe.HIncrBy(ctx, key, statsField(statsRejectedField, code), 1)
+		}
+	})
+}
+
+// Stats returns the counters of the last hour, day and week and of all time
+func (r *RedisClient) Stats(ctx context.Context, now time.Time) (hour, day, week, total StatsCounters, err error) {
+	var fine, coarse []string
+	var fineRecipients, coarseRecipients []string
+	for i := 0; i < statsHourBuckets; i++ {
+		counters, recipients := statsBucketKeys(statsFineBucket, now.Add(-time.Duration(i)*statsFineBucket))
+		fine, fineRecipients = append(fine, counters), append(fineRecipients, recipients)
+	}
+	for i := 0; i < statsWeekBuckets; i++ {
+		counters, recipients := statsBucketKeys(statsCoarseBucket, now.Add(-time.Duration(i)*statsCoarseBucket))
+		coarse, coarseRecipients = append(coarse, counters), append(coarseRecipients, recipients)
+	}
+
+	pipe := r.client.Pipeline()
+	fineCmds := make([]*redis.MapStringStringCmd, len(fine))
+	for i, key := range fine {
+		fineCmds[i] = pipe.HGetAll(ctx, key)
+	}
+	coarseCmds := make([]*redis.MapStringStringCmd, len(coarse))
+	for i, key := range coarse {
+		coarseCmds[i] = pipe.HGetAll(ctx, key)
+	}
+	totalCmd := pipe.HGetAll(ctx, statsTotalKey)
+	// PFCOUNT over several buckets counts the recipients of their union
+	hourRecipients := pipe.PFCount(ctx, fineRecipients...)
+	dayRecipients := pipe.PFCount(ctx, coarseRecipients[:statsDayBuckets]...)
+	weekRecipients := pipe.PFCount(ctx, coarseRecipients...)
+	totalRecipients := pipe.PFCount(ctx, statsTotalRecipientsKey)
+	if _, err = pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return hour, day, week, total, err
+	}
+
+	hour = StatsCounters{Fields: map[string]float64{}, Recipients: hourRecipients.Val()}
+	day = StatsCounters{Fields: map[string]float64{}, Recipients: dayRecipients.Val()}
+	week = StatsCounters{Fields: map[string]float64{}, Recipients: weekRecipients.Val()}
+	total = StatsCounters{Fields: map[string]float64{}, Recipients: totalRecipients.Val()}
+	for _, cmd := range fineCmds {
+		addStats(hour.Fields, cmd.Val())
+	}
+	for i, cmd := range coarseCmds {
+		if i < statsDayBuckets {
+			addStats(day.Fields, cmd.Val())
+		}
+		addStats(week.Fields, cmd.Val())
+	}
+	addStats(total.Fields, totalCmd.Val())
+	return hour, day, week, total, nil
+}
+
+// addStats adds the counters of a bucket to a window's totals
+func addStats(totals map[string]float64, bucket map[string]string) {
+	for field, value := range bucket {
+		n, err := strconv.ParseFloat(value, 64)
+		if err == nil {
+			totals[field] += n
+		}
+	}
+}
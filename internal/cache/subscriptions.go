@@ -0,0 +1,166 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// Subscriptions are stored without expiry, one record per ID, and indexed in a
+// set of all subscriptions for the scheduler and a set per API key.
+const allSubscriptionsKey = "subscriptions"
+
+// SubscriptionRecord is a subscription as stored in Redis, with the API key it is charged to
+type SubscriptionRecord struct {
+	KeyName      string              `json:"key_name"`
+	Subscription models.Subscription `json:"subscription"`
+}
+
+func subscriptionKey(id string) string {
+	return fmt.Sprintf("subscription:%s", id)
+}
+
+func keySubscriptionsKey(keyName string) string {
+	return fmt.Sprintf("subscriptions:key:%s", keyName)
+}
+
+// createSubscriptionScript stores subscription ARGV[2] as ARGV[1] and indexes
+// it, unless the API key's index already holds ARGV[3] subscriptions.
+// Returns the API key's count when it is at the limit, or -1.
+var createSubscriptionScript = redis.NewScript(`
+local count = redis.call('SCARD', KEYS[3])
+if count >= tonumber(ARGV[3]) then
+	return count
+end
+redis.call('SET', KEYS[1], ARGV[1])
+redis.call('SADD', KEYS[2], ARGV[2])
+redis.call('SADD', KEYS[3], ARGV[2])
+return -1
+`)
+
+// updateSubscriptionScript overwrites a subscription with ARGV[1] only if it
+// still exists. Returns 1 if it was updated.
+var updateSubscriptionScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[1])
+return 1
+`)
+
+// CreateSubscription stores a new subscription unless its API key already has
+// limit of them. It returns -1 once stored, or the API key's count otherwise.
+func (r *RedisClient) CreateSubscription(ctx context.Context, record SubscriptionRecord, limit int) (int, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode subscription: %w", err)
+	}
+
+	id := record.Subscription.ID
+	keys := []string{subscriptionKey(id), allSubscriptionsKey, keySubscriptionsKey(record.KeyName)}
+	return createSubscriptionScript.Run(ctx, r.client, keys, data, id, limit).Int()
+}
+
+// UpdateSubscription stores changes to an existing subscription. It reports
+// false, and stores nothing, if the subscription has been deleted.
+func (r *RedisClient) UpdateSubscription(ctx context.Context, record SubscriptionRecord) (bool, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode subscription: %w", err)
+	}
+
+	updated, err := updateSubscriptionScript.Run(ctx, r.client, []string{subscriptionKey(record.Subscription.ID)}, data).Int()
+	return updated == 1, err
+}
+
+// GetSubscription returns a subscription, or nil if there is none with the ID
+func (r *RedisClient) GetSubscription(ctx context.Context, id string) (*SubscriptionRecord, error) {
+	data, err := r.client.Get(ctx, subscriptionKey(id)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var record SubscriptionRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to decode subscription: %w", err)
+	}
+	return &record, nil
+}
+
+// DeleteSubscription removes a subscription
+func (r *RedisClient) DeleteSubscription(ctx context.Context, record SubscriptionRecord) error {
+	id := record.Subscription.ID
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, subscriptionKey(id))
+	pipe.SRem(ctx, allSubscriptionsKey, id)
+	pipe.SRem(ctx, keySubscriptionsKey(record.KeyName), id)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// ListSubscriptions returns the subscriptions of an API key, or every
+// subscription when keyName is empty
+func (r *RedisClient) ListSubscriptions(ctx context.Context, keyName string) ([]SubscriptionRecord, error) {
+	index := allSubscriptionsKey
+	if keyName != "" {
+		index = keySubscriptionsKey(keyName)
+	}
+	ids, err := r.client.SMembers(ctx, index).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]SubscriptionRecord, 0, len(ids))
+	for _, id := range ids {
+		record, err := r.GetSubscription(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if record != nil {
+			records = append(records, *record)
+		}
+	}
+	return records, nil
+}
+
+func subscriptionLockKey(id string) string {
+	return fmt.Sprintf("subscription:lock:%s", id)
+}
+
+// releaseSubscriptionScript deletes a lock only if it still holds the claim ARGV[1]
+var releaseSubscriptionScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// ClaimSubscription takes the right to run a subscription for up to ttl, so
+// that only one server instance sends each drip. It returns a claim to pass to
+// ReleaseSubscription, or "" if another instance holds it.
+func (r *RedisClient) ClaimSubscription(ctx context.Context, id string, ttl time.Duration) (string, error) {
+	b := make([]byte, 16)
+	rand.Read(b)
+	claim := hex.EncodeToString(b)
+
+	ok, err := r.client.SetNX(ctx, subscriptionLockKey(id), claim, ttl).Result()
+	if err != nil || !ok {
+		return "", err
+	}
+	return claim, nil
+}
+
+// ReleaseSubscription gives up a claim taken with ClaimSubscription. A claim
+// that has expired and been taken by another instance is left alone.
+func (r *RedisClient) ReleaseSubscription(ctx context.Context, id, claim string) error {
+	return releaseSubscriptionScript.Run(ctx, r.client, []string{subscriptionLockKey(id)}, claim).Err()
+}
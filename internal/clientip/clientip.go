@@ -0,0 +1,155 @@
+// Package clientip resolves the real client address of a request that may have
+// passed through reverse proxies. Forwarding headers are only believed when the
+// connection comes from a configured trusted proxy, so clients cannot spoof them.
+package clientip
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// Supported forwarding headers
+const (
+	HeaderXForwardedFor  = "X-Forwarded-For"
+	HeaderXRealIP        = "X-Real-IP"
+	HeaderCFConnectingIP = "CF-Connecting-IP"
+	DefaultHeader        = HeaderXForwardedFor
+)
+
+// Resolver picks the client IP from the connection address and a forwarding header
+type Resolver struct {
+	trusted []netip.Prefix
+	header  string
+}
+
+// New creates a resolver trusting proxies in the given CIDRs (single addresses
+// are accepted too). With no trusted proxies the connection address is always used.
+func New(trustedProxies []string, header string) (*Resolver, error) {
+	if header == "" {
+		header = DefaultHeader
+	}
+	switch {
+	case strings.EqualFold(header, HeaderXForwardedFor):
+		header = HeaderXForwardedFor
+	case strings.EqualFold(header, HeaderXRealIP):
+		header = HeaderXRealIP
+	case strings.EqualFold(header, HeaderCFConnectingIP):
+		header = HeaderCFConnectingIP
+	default:
+		return nil, fmt.Errorf("unsupported client IP header %q", header)
+	}
+
+	trusted := make([]netip.Prefix, 0, len(trustedProxies))
+	for _, cidr := range trustedProxies {
+		prefix, err := parsePrefix(cidr)
+		if err != nil {
+			return nil, err
+		}
+		trusted = append(trusted, prefix)
+	}
+
+	return &Resolver{trusted: trusted, header: header}, nil
+}
+
+// Header returns the forwarding header the resolver reads
+func (r *Resolver) Header() string {
+	return r.header
+}
+
+// ClientIP returns the client address for a connection from remoteAddr that
+// carried headerValue. For X-Forwarded-For it walks the chain from the right
+// and returns the first hop that is not a trusted proxy.
+func (r *Resolver) ClientIP(remoteAddr, headerValue string) string {
+	remote, err := netip.ParseAddr(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	remote = remote.Unmap()
+
+	if headerValue == "" || !r.isTrusted(remote) {
+		return remote.String()
+	}
+
+	if r.header != HeaderXForwardedFor {
+		if addr, ok := parseHop(headerValue); ok {
+			return addr.String()
+		}
+		return remote.String()
+	}
+
+	// Each proxy appends the address it received the request from, so hops to
+	// the right were added by our own proxies and the rest may be forged
+	client := remote
+	hops := strings.Split(headerValue, ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		addr, ok := parseHop(hops[i])
+		if !ok {
+			break
+		}
+		client = addr
+		if !r.isTrusted(addr) {
+			break
+		}
+	}
+	return client.String()
+}
+
+func (r *Resolver) isTrusted(addr netip.Addr) bool {
+	for _, prefix := range r.trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseHop parses one forwarded address, tolerating an optional port
+func parseHop(hop string) (netip.Addr, bool) {
+	hop = strings.TrimSpace(hop)
+	if addrPort, err := netip.ParseAddrPort(hop); err == nil {
+		return addrPort.Addr().Unmap(), true
+	}
+	addr, err := netip.ParseAddr(strings.Trim(hop, "[]"))
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
+
+func parsePrefix(cidr string) (netip.Prefix, error) {
+	cidr = strings.TrimSpace(cidr)
+	if strings.Contains(cidr, "/") {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid trusted proxy %q: %w", cidr, err)
+		}
+		return prefix.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(cidr)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid trusted proxy %q: %w", cidr, err)
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// Prefix returns the network of ip with the given prefix length, as a string
+// usable in limiter keys. Full-length prefixes return the bare address, so
+// /32 and /128 keys match the keys used before aggregation.
+func Prefix(ip string, ipv4Bits, ipv6Bits int) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ip
+	}
+	addr = addr.Unmap()
+
+	bits := ipv6Bits
+	if addr.Is4() {
+		bits = ipv4Bits
+	}
+	if bits <= 0 || bits >= addr.BitLen() {
+		return addr.String()
+	}
+	return netip.PrefixFrom(addr, bits).Masked().String()
+}
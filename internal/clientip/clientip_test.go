@@ -0,0 +1,77 @@
+package clientip
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientIPForwardedFor(t *testing.T) {
+	r, err := New([]string{"10.0.0.0/8", "192.168.1.1"}, "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		remote string
+		header string
+		want   string
+	}{
+		{"no header", "10.0.0.5", "", "10.0.0.5"},
+		{"untrusted remote ignores header", "203.0.113.9", "1.2.3.4", "203.0.113.9"},
+		{"single hop", "10.0.0.5", "198.51.100.7", "198.51.100.7"},
+		{"spoofed left-most hop", "10.0.0.5", "1.2.3.4, 198.51.100.7", "198.51.100.7"},
+		{"chain of trusted proxies", "10.0.0.5", "198.51.100.7, 192.168.1.1, 10.1.2.3", "198.51.100.7"},
+		{"all hops trusted", "10.0.0.5", "10.9.9.9, 10.1.1.1", "10.9.9.9"},
+		{"garbage hop", "10.0.0.5", "junk, 10.1.1.1", "10.1.1.1"},
+		{"hop with port", "10.0.0.5", "198.51.100.7:4711", "198.51.100.7"},
+		{"ipv6 hop", "10.0.0.5", "2001:db8::1", "2001:db8::1"},
+		{"ipv4-mapped remote", "::ffff:10.0.0.5", "198.51.100.7", "198.51.100.7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, r.ClientIP(tt.remote, tt.header))
+		})
+	}
+}
+
+func TestClientIPSingleValueHeader(t *testing.T) {
+	r, err := New([]string{"173.245.48.0/20"}, "cf-connecting-ip")
+	require.NoError(t, err)
+	assert.Equal(t, HeaderCFConnectingIP, r.Header())
+
+	assert.Equal(t, "198.51.100.7", r.ClientIP("173.245.48.1", "198.51.100.7"))
+	assert.Equal(t, "173.245.48.1", r.ClientIP("173.245.48.1", "not-an-ip"))
+	assert.Equal(t, "203.0.113.9", r.ClientIP("203.0.113.9", "198.51.100.7"))
+}
+
+func TestNewRejectsBadConfig(t *testing.T) {
+	_, err := New([]string{"10.0.0.0/33"}, "")
+	assert.Error(t, err)
+
+	_, err = New(nil, "X-Client-IP")
+	assert.Error(t, err)
+}
+
+func TestPrefix(t *testing.T) {
+	tests := []struct {
+		ip   string
+		v4   int
+		v6   int
+		want string
+	}{
+		{"198.51.100.7", 32, 64, "198.51.100.7"},
+		{"198.51.100.7", 24, 64, "198.51.100.0/24"},
+		{"2001:db8:1:2:aaaa::1", 32, 64, "2001:db8:1:2::/64"},
+		{"2001:db8:1:2:bbbb::9", 32, 64, "2001:db8:1:2::/64"},
+		{"2001:db8:1:2::1", 32, 48, "2001:db8:1::/48"},
+		{"2001:db8::1", 32, 128, "2001:db8::1"},
+		{"::ffff:198.51.100.7", 24, 64, "198.51.100.0/24"},
+		{"not-an-ip", 24, 64, "not-an-ip"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Prefix(tt.ip, tt.v4, tt.v6), tt.ip)
+	}
+}
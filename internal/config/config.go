@@ -5,7 +5,15 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/apikey"
+	"github.com/Giri-Aayush/starknet-faucet/internal/clientip"
+	"github.com/Giri-Aayush/starknet-faucet/internal/identity"
+	"github.com/Giri-Aayush/starknet-faucet/internal/pow"
+	"github.com/Giri-Aayush/starknet-faucet/internal/testtoken"
 	"github.com/joho/godotenv"
 )
 
@@ -21,26 +29,245 @@ type Config struct {
 	// Rate limiting
 	RateLimits RateLimitConfig `json:"rate_limits"`
 
+	// Response and balance caching
+	Cache CacheConfig `json:"cache"`
+
+	// Server-side captcha verification
+	HumanVerification HumanVerificationConfig `json:"human_verification"`
+
+	// Optional GitHub sign-in for per-account quotas
+	GitHub GitHubConfig `json:"github"`
+
+	// Optional proof that the requester owns the recipient address
+	OwnershipProof OwnershipProofConfig `json:"ownership_proof"`
+
+	// Optional gRPC API served on its own port
+	GRPC GRPCConfig `json:"grpc"`
+
+	// API keys for teams that fund many addresses at once
+	APIKeys []APIKeyConfig `json:"api_keys"`
+
+	// Recurring drips that API key holders register
+	Subscriptions SubscriptionsConfig `json:"subscriptions"`
+
 	// From .env (secrets)
 	RedisURL string `json:"-"`
+
+	// TestTokenSecret signs test tokens that skip PoW (FAUCET_TEST_TOKEN_SECRET).
+	// Test tokens are disabled when it is empty.
+	TestTokenSecret string `json:"-"`
+
+	// ChallengeSecret signs stateless PoW challenges (FAUCET_CHALLENGE_SECRET).
+	// Every instance verifying the same challenges must share it.
+	ChallengeSecret string `json:"-"`
+
+	// HumanVerificationSecret is the captcha provider's secret key (HUMAN_VERIFICATION_SECRET).
+	// For the stub provider it is the token the stub accepts.
+	HumanVerificationSecret string `json:"-"`
+
+	// SessionSecret signs sign-in session tokens (FAUCET_SESSION_SECRET).
+	// Required when GitHub sign-in is enabled.
+	SessionSecret string `json:"-"`
+
+	// path is the file the config was loaded from, and env the contents of
+	// .env at the time, so a reload can tell what changed
+	path string
+	env  map[string]string
 }
 
 // ServerConfig holds server configuration
 type ServerConfig struct {
 	Port     int    `json:"port"`
 	LogLevel string `json:"log_level"`
+
+	// RequestTimeoutSec bounds the total time spent handling a single request
+	RequestTimeoutSec int `json:"request_timeout_seconds"`
+
+	// ChainTimeoutSec bounds each individual RPC operation (balance check, transfer)
+	ChainTimeoutSec int `json:"chain_timeout_seconds"`
+
+	// ShutdownTimeoutSec is how long to wait for in-flight transfers on SIGTERM
+	ShutdownTimeoutSec int `json:"shutdown_timeout_seconds"`
+
+	// ConfirmTimeoutSec bounds how long a sent transaction is followed until it confirms
+	ConfirmTimeoutSec int `json:"confirm_timeout_seconds"`
+
+	// TrustedProxies lists the CIDRs of reverse proxies (load balancer, nginx)
+	// whose forwarding header is believed. Empty means the connection address is the client.
+	TrustedProxies []string `json:"trusted_proxies"`
+
+	// ClientIPHeader is the forwarding header set by the trusted proxies:
+	// X-Forwarded-For (default), X-Real-IP or CF-Connecting-IP
+	ClientIPHeader string `json:"client_ip_header"`
 }
 
 // PoWConfig holds proof of work configuration
 type PoWConfig struct {
-	Difficulty      int `json:"difficulty"`
-	ChallengeTTLSec int `json:"challenge_ttl_seconds"`
+	Difficulty      int               `json:"difficulty"`
+	ChallengeTTLSec int               `json:"challenge_ttl_seconds"`
+	Adaptive        AdaptivePoWConfig `json:"adaptive"`
+
+	// Stateless issues challenges as HMAC-signed IDs instead of storing them in
+	// Redis; only a spent-set is kept for replay protection. Requires FAUCET_CHALLENGE_SECRET.
+	Stateless bool `json:"stateless"`
+
+	// DifficultyFormat is the unit of difficulty and the adaptive bounds:
+	// "hex" (default) counts leading zero hex digits (16x per step),
+	// "bits" counts leading zero bits (2x per step)
+	DifficultyFormat string `json:"difficulty_format"`
+
+	// Algorithm is the puzzle hash: sha256 (default), argon2id or scrypt.
+	// Memory-hard algorithms are far slower per hash, so lower the difficulty to match.
+	Algorithm string       `json:"algorithm"`
+	Argon2    Argon2Config `json:"argon2"`
+	Scrypt    ScryptConfig `json:"scrypt"`
+}
+
+// Argon2Config holds Argon2id parameters for the memory-hard PoW option
+type Argon2Config struct {
+	Time      uint32 `json:"time"`
+	MemoryKiB uint32 `json:"memory_kib"`
+	Threads   uint8  `json:"threads"`
+}
+
+// ScryptConfig holds scrypt parameters for the memory-hard PoW option
+type ScryptConfig struct {
+	N int `json:"n"`
+	R int `json:"r"`
+	P int `json:"p"`
+}
+
+// AdaptivePoWConfig holds settings for per-challenge difficulty.
+// Difficulty starts at PoWConfig.Difficulty and moves within [MinDifficulty, MaxDifficulty].
+type AdaptivePoWConfig struct {
+	Enabled           bool `json:"enabled"`
+	MinDifficulty     int  `json:"min_difficulty"`
+	MaxDifficulty     int  `json:"max_difficulty"`
+	HighLoadPerMinute int  `json:"high_load_challenges_per_minute"`
+	LowLoadPerMinute  int  `json:"low_load_challenges_per_minute"`
+	PenaltyStep       int  `json:"penalty_step"`
+	PenaltyWindowSec  int  `json:"penalty_window_seconds"`
 }
 
 // RateLimitConfig holds rate limiting configuration
 type RateLimitConfig struct {
 	MaxRequestsPerDayIP  int `json:"max_requests_per_day_ip"`
 	MaxChallengesPerHour int `json:"max_challenges_per_hour"`
+
+	// Per-IP limits apply to the client's whole network of this prefix length
+	// (default /32 for IPv4, /64 for IPv6), since one user controls all of it
+	IPv4PrefixLength int `json:"ipv4_prefix_length"`
+	IPv6PrefixLength int `json:"ipv6_prefix_length"`
+
+	// Optional wider limit shared by a whole subnet, applied on top of the per-IP limit
+	Subnet SubnetLimitConfig `json:"subnet"`
+}
+
+// SubnetLimitConfig holds the subnet-level daily limit
+type SubnetLimitConfig struct {
+	// MaxRequestsPerDay is shared by every client in a subnet; 0 disables subnet limits
+	MaxRequestsPerDay int `json:"max_requests_per_day"`
+
+	// Subnet prefix lengths (default /24 for IPv4, /48 for IPv6)
+	IPv4PrefixLength int `json:"ipv4_prefix_length"`
+	IPv6PrefixLength int `json:"ipv6_prefix_length"`
+}
+
+// CacheConfig holds caching configuration
+type CacheConfig struct {
+	// BalanceRefreshSec is how often faucet balances are re-read from chain in the background
+	BalanceRefreshSec int `json:"balance_refresh_seconds"`
+
+	// BalanceMaxAgeSec is how old a cached balance may be before it is read from chain on demand
+	BalanceMaxAgeSec int `json:"balance_max_age_seconds"`
+
+	// InfoMaxAgeSec is the Cache-Control max-age advertised on /info responses
+	InfoMaxAgeSec int `json:"info_max_age_seconds"`
+}
+
+// HumanVerificationConfig selects a captcha provider and which requests must pass it
+type HumanVerificationConfig struct {
+	// Provider is turnstile, hcaptcha or stub; empty disables human verification
+	Provider string `json:"provider"`
+
+	// SiteKey is the provider's public site key, advertised to clients via /info
+	SiteKey string `json:"site_key"`
+
+	// Networks that require verification; empty means all networks
+	Networks []string `json:"networks"`
+
+	// Tiers that require verification (default: anonymous)
+	Tiers []string `json:"tiers"`
+}
+
+// GitHubConfig holds settings for GitHub sign-in through the OAuth device flow.
+// Signed-in users are charged against a per-account quota instead of their IP's.
+type GitHubConfig struct {
+	Enabled  bool   `json:"enabled"`
+	ClientID string `json:"client_id"`
+
+	// OAuthURL and APIURL default to github.com; point them at a mock for local testing
+	OAuthURL string `json:"oauth_url"`
+	APIURL   string `json:"api_url"`
+
+	// SessionTTLHours is how long a sign-in lasts before the CLI must log in again
+	SessionTTLHours int `json:"session_ttl_hours"`
+
+	// MaxRequestsPerDay is the daily request quota per signed-in account
+	MaxRequestsPerDay int `json:"max_requests_per_day"`
+
+	// Eligibility rules; zero disables a rule
+	MinAccountAgeDays int `json:"min_account_age_days"`
+	MinPublicRepos    int `json:"min_public_repos"`
+	MinFollowers      int `json:"min_followers"`
+}
+
+// OwnershipProofConfig holds settings for recipient ownership proofs. Requests
+// on the listed networks must carry a signature over their PoW challenge made
+// with the recipient's key.
+type OwnershipProofConfig struct {
+	// Networks that require a proof; empty disables the requirement
+	Networks []string `json:"networks"`
+}
+
+// GRPCConfig holds settings for the gRPC API. It is served by the same
+// handlers as the REST API and shares its limiters.
+type GRPCConfig struct {
+	// Port serves the gRPC API; 0 disables it
+	Port int `json:"port"`
+}
+
+// APIKeyConfig registers an API key. Key holders skip PoW and per-IP limits
+// and are charged against their own daily budget instead. Only the key's
+// SHA-256 is stored; issue keys with `server issue-api-key`.
+type APIKeyConfig struct {
+	// Name identifies the holder in logs and budget counters
+	Name      string `json:"name"`
+	KeySHA256 string `json:"key_sha256"`
+
+	// DailyBudget is how much the key may send per UTC day, in whole tokens:
+	// network -> token -> amount. Tokens not listed cannot be sent with the key.
+	DailyBudget map[string]map[string]float64 `json:"daily_budget"`
+
+	// MaxBatchSize caps the items of one batch request (default 100)
+	MaxBatchSize int `json:"max_batch_size"`
+}
+
+// SubscriptionsConfig holds settings for the recurring drips of API key
+// holders, which are charged to the key's daily budget
+type SubscriptionsConfig struct {
+	// CheckIntervalSec is how often subscriptions are checked for drips that are due (default 60)
+	CheckIntervalSec int `json:"check_interval_seconds"`
+
+	// MinIntervalSec is the shortest interval of an interval subscription (default 3600)
+	MinIntervalSec int `json:"min_interval_seconds"`
+
+	// TopUpCooldownSec is the least time between two top-ups of one
+	// subscription, so a top-up is not repeated before it lands (default 600)
+	TopUpCooldownSec int `json:"top_up_cooldown_seconds"`
+
+	// MaxPerKey caps the subscriptions of one API key (default 20)
+	MaxPerKey int `json:"max_per_key"`
 }
 
 // ChainConfig holds configuration for a specific chain (loaded from chain's config.json)
@@ -50,19 +277,33 @@ type ChainConfig struct {
 	Tokens               map[string]TokenConfig `json:"tokens"`
 	MinBalanceProtectPct int                    `json:"min_balance_protect_pct"`
 	ExplorerURL          string                 `json:"explorer_url"`
+	RPC                  RPCConfig              `json:"rpc"`
+}
+
+// RPCConfig holds RPC failover and circuit breaker settings for a chain.
+// Zero values fall back to the defaults in the chains package.
+type RPCConfig struct {
+	FailureThreshold   int `json:"failure_threshold"`
+	BreakerCooldownSec int `json:"breaker_cooldown_seconds"`
+	AttemptTimeoutSec  int `json:"attempt_timeout_seconds"`
 }
 
 // TokenConfig holds configuration for a specific token
 type TokenConfig struct {
 	ContractAddress string  `json:"contract_address,omitempty"`
+	Decimals        int     `json:"decimals,omitempty"` // Defaults to 18
 	DripAmount      string  `json:"drip_amount"`
 	MaxPerHour      float64 `json:"max_per_hour"`
 	MaxPerDay       float64 `json:"max_per_day"`
+
+	// MaxRecipientBalance refuses recipients already holding at least this much; 0 disables the cap
+	MaxRecipientBalance float64 `json:"max_recipient_balance,omitempty"`
 }
 
 // Load loads global configuration from config directory and .env
 // If FAUCET_TEST_MODE=true, loads config/config.test.json (relaxed settings)
 // Otherwise, loads config/config.json (production settings)
+// Test mode only selects the config file; it never disables PoW.
 func Load() (*Config, error) {
 	// Load .env for secrets
 	_ = godotenv.Load()
@@ -89,12 +330,19 @@ func Load() (*Config, error) {
 
 	// Load secrets from environment
 	config.RedisURL = getEnv("REDIS_URL", "redis://localhost:6379")
+	config.TestTokenSecret = getEnv("FAUCET_TEST_TOKEN_SECRET", "")
+	config.ChallengeSecret = getEnv("FAUCET_CHALLENGE_SECRET", "")
+	config.HumanVerificationSecret = getEnv("HUMAN_VERIFICATION_SECRET", "")
+	config.SessionSecret = getEnv("FAUCET_SESSION_SECRET", "")
 
 	// Validate
 	if err := config.Validate(); err != nil {
 		return nil, err
 	}
 
+	config.path = configPath
+	config.env, _ = godotenv.Read()
+
 	return config, nil
 }
 
@@ -165,10 +413,42 @@ func (c *Config) Validate() error {
 		return &ConfigError{Field: "REDIS_URL", Message: "is required (set in .env)"}
 	}
 
+	if c.TestTokenSecret != "" && len(c.TestTokenSecret) < testtoken.MinSecretLength {
+		return &ConfigError{Field: "FAUCET_TEST_TOKEN_SECRET", Message: fmt.Sprintf("must be at least %d characters", testtoken.MinSecretLength)}
+	}
+
+	if c.PoW.Stateless && len(c.ChallengeSecret) < pow.MinChallengeSecretLength {
+		return &ConfigError{Field: "FAUCET_CHALLENGE_SECRET", Message: fmt.Sprintf("must be at least %d characters when pow.stateless is enabled", pow.MinChallengeSecretLength)}
+	}
+
 	if c.Server.Port == 0 {
 		c.Server.Port = 8080
 	}
 
+	if c.Server.RequestTimeoutSec == 0 {
+		c.Server.RequestTimeoutSec = 60
+	}
+
+	if c.Server.ChainTimeoutSec == 0 {
+		c.Server.ChainTimeoutSec = 30
+	}
+
+	if c.Server.ShutdownTimeoutSec == 0 {
+		c.Server.ShutdownTimeoutSec = 30
+	}
+
+	if c.Server.ConfirmTimeoutSec == 0 {
+		c.Server.ConfirmTimeoutSec = 300
+	}
+
+	if c.Server.ClientIPHeader == "" {
+		c.Server.ClientIPHeader = clientip.DefaultHeader
+	}
+
+	if _, err := clientip.New(c.Server.TrustedProxies, c.Server.ClientIPHeader); err != nil {
+		return &ConfigError{Field: "server", Message: "has invalid client IP settings: " + err.Error()}
+	}
+
 	if c.PoW.Difficulty == 0 {
 		c.PoW.Difficulty = 4
 	}
@@ -177,6 +457,63 @@ func (c *Config) Validate() error {
 		c.PoW.ChallengeTTLSec = 300
 	}
 
+	if c.PoW.DifficultyFormat == "" {
+		c.PoW.DifficultyFormat = "hex"
+	}
+
+	if c.PoW.DifficultyFormat != "hex" && c.PoW.DifficultyFormat != "bits" {
+		return &ConfigError{Field: "pow.difficulty_format", Message: "must be hex or bits"}
+	}
+
+	// A 256-bit hash has 256 leading bits, or 64 hex digits, to zero
+	maxDifficulty := pow.MaxDifficulty(c.PoW.DifficultyFormat)
+	outOfRange := fmt.Sprintf("must be between 1 and %d in the %s format", maxDifficulty, c.PoW.DifficultyFormat)
+	if c.PoW.Difficulty < 1 || c.PoW.Difficulty > maxDifficulty {
+		return &ConfigError{Field: "pow.difficulty", Message: outOfRange}
+	}
+	if c.PoW.Adaptive.MinDifficulty < 0 || c.PoW.Adaptive.MinDifficulty > maxDifficulty {
+		return &ConfigError{Field: "pow.adaptive.min_difficulty", Message: outOfRange}
+	}
+	if c.PoW.Adaptive.MaxDifficulty < 0 || c.PoW.Adaptive.MaxDifficulty > maxDifficulty {
+		return &ConfigError{Field: "pow.adaptive.max_difficulty", Message: outOfRange}
+	}
+
+	if c.PoW.Algorithm == "" {
+		c.PoW.Algorithm = "sha256"
+	}
+
+	if c.PoW.Argon2.Time == 0 {
+		c.PoW.Argon2.Time = 1
+	}
+
+	if c.PoW.Argon2.MemoryKiB == 0 {
+		c.PoW.Argon2.MemoryKiB = 64 * 1024
+	}
+
+	if c.PoW.Argon2.Threads == 0 {
+		c.PoW.Argon2.Threads = 1
+	}
+
+	if c.PoW.Scrypt.N == 0 {
+		c.PoW.Scrypt.N = 1 << 15
+	}
+
+	if c.PoW.Scrypt.R == 0 {
+		c.PoW.Scrypt.R = 8
+	}
+
+	if c.PoW.Scrypt.P == 0 {
+		c.PoW.Scrypt.P = 1
+	}
+
+	if c.PoW.Adaptive.PenaltyWindowSec == 0 {
+		c.PoW.Adaptive.PenaltyWindowSec = 3600
+	}
+
+	if c.PoW.Adaptive.Enabled && c.PoW.Adaptive.MaxDifficulty != 0 && c.PoW.Adaptive.MaxDifficulty < c.PoW.Adaptive.MinDifficulty {
+		return &ConfigError{Field: "pow.adaptive.max_difficulty", Message: "must be >= min_difficulty"}
+	}
+
 	if c.RateLimits.MaxRequestsPerDayIP == 0 {
 		c.RateLimits.MaxRequestsPerDayIP = 5
 	}
@@ -185,6 +522,122 @@ func (c *Config) Validate() error {
 		c.RateLimits.MaxChallengesPerHour = 10
 	}
 
+	if c.RateLimits.IPv4PrefixLength == 0 {
+		c.RateLimits.IPv4PrefixLength = 32
+	}
+
+	if c.RateLimits.IPv6PrefixLength == 0 {
+		c.RateLimits.IPv6PrefixLength = 64
+	}
+
+	if c.RateLimits.Subnet.IPv4PrefixLength == 0 {
+		c.RateLimits.Subnet.IPv4PrefixLength = 24
+	}
+
+	if c.RateLimits.Subnet.IPv6PrefixLength == 0 {
+		c.RateLimits.Subnet.IPv6PrefixLength = 48
+	}
+
+	if err := validatePrefixLengths("rate_limits", c.RateLimits.IPv4PrefixLength, c.RateLimits.IPv6PrefixLength); err != nil {
+		return err
+	}
+
+	if err := validatePrefixLengths("rate_limits.subnet", c.RateLimits.Subnet.IPv4PrefixLength, c.RateLimits.Subnet.IPv6PrefixLength); err != nil {
+		return err
+	}
+
+	if c.RateLimits.Subnet.MaxRequestsPerDay > 0 &&
+		(c.RateLimits.Subnet.IPv4PrefixLength > c.RateLimits.IPv4PrefixLength || c.RateLimits.Subnet.IPv6PrefixLength > c.RateLimits.IPv6PrefixLength) {
+		return &ConfigError{Field: "rate_limits.subnet", Message: "prefix lengths must not be longer than the per-IP prefix lengths"}
+	}
+
+	if c.Cache.BalanceRefreshSec == 0 {
+		c.Cache.BalanceRefreshSec = 30
+	}
+
+	if c.Cache.BalanceMaxAgeSec == 0 {
+		c.Cache.BalanceMaxAgeSec = 120
+	}
+
+	if c.Cache.InfoMaxAgeSec == 0 {
+		c.Cache.InfoMaxAgeSec = 15
+	}
+
+	switch c.HumanVerification.Provider {
+	case "":
+	case "turnstile", "hcaptcha", "stub":
+		if c.HumanVerificationSecret == "" {
+			return &ConfigError{Field: "HUMAN_VERIFICATION_SECRET", Message: "is required when human_verification.provider is set"}
+		}
+	default:
+		return &ConfigError{Field: "human_verification.provider", Message: "must be turnstile, hcaptcha or stub"}
+	}
+
+	if len(c.HumanVerification.Tiers) == 0 {
+		c.HumanVerification.Tiers = []string{"anonymous"}
+	}
+
+	if c.GitHub.Enabled {
+		if c.GitHub.ClientID == "" {
+			return &ConfigError{Field: "github.client_id", Message: "is required when GitHub sign-in is enabled"}
+		}
+		if len(c.SessionSecret) < identity.MinSessionSecretLength {
+			return &ConfigError{Field: "FAUCET_SESSION_SECRET", Message: fmt.Sprintf("must be at least %d characters when GitHub sign-in is enabled", identity.MinSessionSecretLength)}
+		}
+	}
+
+	if c.GitHub.SessionTTLHours == 0 {
+		c.GitHub.SessionTTLHours = 24 * 7
+	}
+
+	if c.GitHub.MaxRequestsPerDay == 0 {
+		c.GitHub.MaxRequestsPerDay = c.RateLimits.MaxRequestsPerDayIP * 2
+	}
+
+	if c.GRPC.Port != 0 && c.GRPC.Port == c.Server.Port {
+		return &ConfigError{Field: "grpc.port", Message: "must differ from server.port"}
+	}
+
+	names := map[string]bool{}
+	for i := range c.APIKeys {
+		key := &c.APIKeys[i]
+		field := fmt.Sprintf("api_keys[%d]", i)
+		if key.Name == "" || names[key.Name] {
+			return &ConfigError{Field: field + ".name", Message: "must be set and unique"}
+		}
+		names[key.Name] = true
+		if !apiKeyHashPattern.MatchString(key.KeySHA256) {
+			return &ConfigError{Field: field + ".key_sha256", Message: "must be 64 lowercase hex digits"}
+		}
+		for network, tokens := range key.DailyBudget {
+			for token, amount := range tokens {
+				if amount < 0 {
+					return &ConfigError{Field: fmt.Sprintf("%s.daily_budget.%s.%s", field, network, token), Message: "must not be negative"}
+				}
+			}
+		}
+		if key.MaxBatchSize == 0 {
+			key.MaxBatchSize = 100
+		}
+	}
+
+	if c.Subscriptions.CheckIntervalSec == 0 {
+		c.Subscriptions.CheckIntervalSec = 60
+	}
+	if c.Subscriptions.MinIntervalSec == 0 {
+		c.Subscriptions.MinIntervalSec = 3600
+	}
+	if c.Subscriptions.TopUpCooldownSec == 0 {
+		c.Subscriptions.TopUpCooldownSec = 600
+	}
+	if c.Subscriptions.MaxPerKey == 0 {
+		c.Subscriptions.MaxPerKey = 20
+	}
+	if c.Subscriptions.CheckIntervalSec < 0 || c.Subscriptions.MinIntervalSec < 0 ||
+		c.Subscriptions.TopUpCooldownSec < 0 || c.Subscriptions.MaxPerKey < 0 {
+		return &ConfigError{Field: "subscriptions", Message: "settings must not be negative"}
+	}
+
 	return nil
 }
 
@@ -200,6 +653,19 @@ func (e *ConfigError) Error() string {
 
 // Helper functions
 
+func validatePrefixLengths(field string, ipv4Bits, ipv6Bits int) error {
+	if ipv4Bits < 1 || ipv4Bits > 32 {
+		return &ConfigError{Field: field + ".ipv4_prefix_length", Message: "must be between 1 and 32"}
+	}
+	if ipv6Bits < 1 || ipv6Bits > 128 {
+		return &ConfigError{Field: field + ".ipv6_prefix_length", Message: "must be between 1 and 128"}
+	}
+	return nil
+}
+
+// apiKeyHashPattern matches a hex SHA-256, as produced by apikey.Hash
+var apiKeyHashPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -214,16 +680,64 @@ func (c *Config) Port() string {
 	return fmt.Sprintf("%d", c.Server.Port)
 }
 
+// GRPCPort returns the gRPC port, or "" when the gRPC API is disabled
+func (c *Config) GRPCPort() string {
+	if c.GRPC.Port == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d", c.GRPC.Port)
+}
+
+// ConfirmTimeout returns how long a sent transaction is followed until it confirms
+func (c *Config) ConfirmTimeout() time.Duration {
+	return time.Duration(c.Server.ConfirmTimeoutSec) * time.Second
+}
+
 // LogLevel returns the log level
 func (c *Config) LogLevel() string {
 	return c.Server.LogLevel
 }
 
+// RequestTimeout returns the maximum duration of a single API request
+func (c *Config) RequestTimeout() time.Duration {
+	return time.Duration(c.Server.RequestTimeoutSec) * time.Second
+}
+
+// ChainTimeout returns the deadline applied to each chain RPC operation
+func (c *Config) ChainTimeout() time.Duration {
+	return time.Duration(c.Server.ChainTimeoutSec) * time.Second
+}
+
+// ShutdownTimeout returns how long shutdown waits for in-flight transfers
+func (c *Config) ShutdownTimeout() time.Duration {
+	return time.Duration(c.Server.ShutdownTimeoutSec) * time.Second
+}
+
 // PoWDifficulty returns the PoW difficulty
 func (c *Config) PoWDifficulty() int {
 	return c.PoW.Difficulty
 }
 
+// PoWDifficultyFormat returns the unit PoW difficulties are configured in
+func (c *Config) PoWDifficultyFormat() string {
+	return c.PoW.DifficultyFormat
+}
+
+// PoWAlgorithm returns the PoW hash algorithm
+func (c *Config) PoWAlgorithm() string {
+	return c.PoW.Algorithm
+}
+
+// AdaptivePoW returns the adaptive difficulty settings
+func (c *Config) AdaptivePoW() AdaptivePoWConfig {
+	return c.PoW.Adaptive
+}
+
+// PoWPenaltyWindow returns how long invalid solutions and rejections raise an IP's difficulty
+func (c *Config) PoWPenaltyWindow() time.Duration {
+	return time.Duration(c.PoW.Adaptive.PenaltyWindowSec) * time.Second
+}
+
 // ChallengeTTL returns the challenge TTL in seconds
 func (c *Config) ChallengeTTL() int {
 	return c.PoW.ChallengeTTLSec
@@ -238,3 +752,80 @@ func (c *Config) MaxRequestsPerDayIP() int {
 func (c *Config) MaxChallengesPerHour() int {
 	return c.RateLimits.MaxChallengesPerHour
 }
+
+// BalanceRefreshInterval returns how often cached faucet balances are refreshed
+func (c *Config) BalanceRefreshInterval() time.Duration {
+	return time.Duration(c.Cache.BalanceRefreshSec) * time.Second
+}
+
+// BalanceMaxAge returns how long a cached faucet balance stays valid
+func (c *Config) BalanceMaxAge() time.Duration {
+	return time.Duration(c.Cache.BalanceMaxAgeSec) * time.Second
+}
+
+// InfoMaxAge returns the Cache-Control max-age for /info responses, in seconds
+func (c *Config) InfoMaxAge() int {
+	return c.Cache.InfoMaxAgeSec
+}
+
+// SessionTTL returns how long a sign-in session lasts
+func (c *Config) SessionTTL() time.Duration {
+	return time.Duration(c.GitHub.SessionTTLHours) * time.Hour
+}
+
+// GitHubEligibility returns the rules a GitHub account must meet to sign in
+func (c *Config) GitHubEligibility() identity.Eligibility {
+	return identity.Eligibility{
+		MinAccountAge:  time.Duration(c.GitHub.MinAccountAgeDays) * 24 * time.Hour,
+		MinPublicRepos: c.GitHub.MinPublicRepos,
+		MinFollowers:   c.GitHub.MinFollowers,
+	}
+}
+
+// APIKeyRegistry returns the registered API keys
+func (c *Config) APIKeyRegistry() *apikey.Registry {
+	keys := make([]apikey.Key, len(c.APIKeys))
+	for i, key := range c.APIKeys {
+		keys[i] = apikey.Key{Name: key.Name, Hash: key.KeySHA256}
+	}
+	return apikey.NewRegistry(keys)
+}
+
+// APIKey returns the settings of the API key with the given name
+func (c *Config) APIKey(name string) *APIKeyConfig {
+	for i := range c.APIKeys {
+		if c.APIKeys[i].Name == name {
+			return &c.APIKeys[i]
+		}
+	}
+	return nil
+}
+
+// SubscriptionCheckInterval returns how often subscriptions are checked for drips that are due
+func (c *Config) SubscriptionCheckInterval() time.Duration {
+	return time.Duration(c.Subscriptions.CheckIntervalSec) * time.Second
+}
+
+// SubscriptionTopUpCooldown returns the least time between two top-ups of one subscription
+func (c *Config) SubscriptionTopUpCooldown() time.Duration {
+	return time.Duration(c.Subscriptions.TopUpCooldownSec) * time.Second
+}
+
+// RequiresOwnershipProof reports whether requests on network must prove
+// ownership of the recipient address
+func (c *Config) RequiresOwnershipProof(network string) bool {
+	return slices.Contains(c.OwnershipProof.Networks, network)
+}
+
+// RequiresHumanVerification reports whether requests on network from a client
+// in tier must pass human verification
+func (c *Config) RequiresHumanVerification(network, tier string) bool {
+	hv := c.HumanVerification
+	if hv.Provider == "" {
+		return false
+	}
+	if len(hv.Networks) > 0 && !slices.Contains(hv.Networks, network) {
+		return false
+	}
+	return slices.Contains(hv.Tiers, tier)
+}
@@ -0,0 +1,254 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/joho/godotenv"
+)
+
+// liveSettings are the global settings that take effect without a restart,
+// as dotted paths in config.json. A path covers every setting under it.
+// Everything else is read once at startup. The IP prefix lengths are left out
+// on purpose: they name the per-IP counters, so changing them live would
+// reset every client's limits.
+var liveSettings = []string{
+	"pow.difficulty",
+	"pow.adaptive",
+	"rate_limits.max_requests_per_day_ip",
+	"rate_limits.subnet",
+	"cache.info_max_age_seconds",
+	"server.chain_timeout_seconds",
+	"server.confirm_timeout_seconds",
+	"human_verification.networks",
+	"human_verification.tiers",
+	"github.max_requests_per_day",
+	"github.min_account_age_days",
+	"github.min_public_repos",
+	"github.min_followers",
+	"ownership_proof.networks",
+	"api_keys",
+	"subscriptions.min_interval_seconds",
+	"subscriptions.top_up_cooldown_seconds",
+	"subscriptions.max_per_key",
+}
+
+// liveChainSettings are the settings of a chain's config.json that take
+// effect without a restart. A * matches any token.
+var liveChainSettings = []string{
+	"tokens.*.drip_amount",
+	"tokens.*.max_per_hour",
+	"tokens.*.max_per_day",
+	"tokens.*.max_recipient_balance",
+	"min_balance_protect_pct",
+}
+
+// ReloadError lists the changes in a reloaded configuration that only take
+// effect after a restart. None of the reloaded configuration is applied.
+type ReloadError struct {
+	File   string
+	Fields []string
+}
+
+func (e *ReloadError) Error() string {
+	return fmt.Sprintf("%s: %s cannot change without a restart", e.File, strings.Join(e.Fields, ", "))
+}
+
+// Path returns the file the configuration was loaded from
+func (c *Config) Path() string {
+	return c.path
+}
+
+// Reload reads the configuration file again and validates it. Secrets come
+// from the environment, which a running process cannot see change, so the new
+// configuration keeps the current ones and a changed .env is refused.
+func (c *Config) Reload() (*Config, error) {
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", c.path, err)
+	}
+
+	next := &Config{}
+	if err := json.Unmarshal(data, next); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", c.path, err)
+	}
+	next.RedisURL = c.RedisURL
+	next.TestTokenSecret = c.TestTokenSecret
+	next.ChallengeSecret = c.ChallengeSecret
+	next.HumanVerificationSecret = c.HumanVerificationSecret
+	next.SessionSecret = c.SessionSecret
+	next.path = c.path
+	next.env = c.env
+
+	if err := next.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Only name the variables: their values are secrets
+	env, _ := godotenv.Read()
+	var changed []string
+	for _, key := range slices.Sorted(maps.Keys(mergeKeys(c.env, env))) {
+		if c.env[key] != env[key] {
+			changed = append(changed, key)
+		}
+	}
+	if len(changed) > 0 {
+		return nil, &ReloadError{File: ".env", Fields: changed}
+	}
+
+	return next, nil
+}
+
+// CheckReload compares a reloaded configuration with the current one. It
+// returns the changes, each as "setting: old -> new", or a ReloadError if
+// any of them can only take effect after a restart.
+func CheckReload(current, next *Config) ([]string, error) {
+	return checkLive(current.path, current, next, liveSettings)
+}
+
+// CheckChainReload compares a chain's reloaded config.json with the current
+// one like CheckReload, after validating it. Tokens cannot be added or
+// removed, and their contracts and decimals cannot change.
+func CheckChainReload(path string, current, next *ChainConfig) ([]string, error) {
+	if err := next.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return checkLive(path, current, next, liveChainSettings)
+}
+
+// Validate checks the distribution settings of a chain's config.json
+func (c *ChainConfig) Validate() error {
+	for symbol, token := range c.Tokens {
+		amount, err := strconv.ParseFloat(token.DripAmount, 64)
+		if err != nil || amount < 0 || math.IsInf(amount, 0) {
+			return &ConfigError{Field: "tokens." + symbol + ".drip_amount", Message: "must be a non-negative number"}
+		}
+		if token.MaxPerHour < 0 || token.MaxPerDay < 0 || token.MaxRecipientBalance < 0 {
+			return &ConfigError{Field: "tokens." + symbol, Message: "limits must not be negative"}
+		}
+	}
+	if c.MinBalanceProtectPct < 0 || c.MinBalanceProtectPct >= 100 {
+		return &ConfigError{Field: "min_balance_protect_pct", Message: "must be between 0 and 99"}
+	}
+	return nil
+}
+
+// checkLive diffs two configurations and refuses changes outside live
+func checkLive(file string, current, next any, live []string) ([]string, error) {
+	before, err := flatten(current)
+	if err != nil {
+		return nil, err
+	}
+	after, err := flatten(next)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes, restart []string
+	for _, setting := range slices.Sorted(maps.Keys(mergeKeys(before, after))) {
+		old, oldOK := before[setting]
+		value, newOK := after[setting]
+		if old == value && oldOK == newOK {
+			continue
+		}
+		changes = append(changes, fmt.Sprintf("%s: %s -> %s", setting, describe(old, oldOK), describe(value, newOK)))
+		// A setting that appears or disappears under a wildcard is a new or removed entry
+		if !isLive(setting, live, oldOK && newOK) {
+			restart = append(restart, setting)
+		}
+	}
+	if len(restart) > 0 {
+		return nil, &ReloadError{File: file, Fields: restart}
+	}
+	return changes, nil
+}
+
+// isLive reports whether a setting is covered by one of the live paths.
+// Wildcards only match settings that exist on both sides.
+func isLive(setting string, live []string, existed bool) bool {
+	parts := strings.Split(setting, ".")
+	for _, pattern := range live {
+		patternParts := strings.Split(pattern, ".")
+		if len(patternParts) > len(parts) {
+			continue
+		}
+		matched := true
+		for i, p := range patternParts {
+			if p == "*" && !existed {
+				matched = false
+			}
+			if p != "*" && p != parts[i] {
+				matched = false
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// flatten encodes a configuration as its JSON settings, keyed by dotted
+// path. Array elements are keyed by index; secrets are not encoded at all.
+func flatten(v any) (map[string]string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var tree any
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+
+	settings := map[string]string{}
+	var walk func(prefix string, node any)
+	walk = func(prefix string, node any) {
+		join := func(key string) string {
+			if prefix == "" {
+				return key
+			}
+			return prefix + "." + key
+		}
+		switch node := node.(type) {
+		case map[string]any:
+			for key, child := range node {
+				walk(join(key), child)
+			}
+		case []any:
+			for i, child := range node {
+				walk(join(strconv.Itoa(i)), child)
+			}
+		default:
+			value, _ := json.Marshal(node)
+			settings[prefix] = string(value)
+		}
+	}
+	walk("", tree)
+	return settings, nil
+}
+
+// describe formats a setting's value for the change log
+func describe(value string, ok bool) string {
+	if !ok {
+		return "(unset)"
+	}
+	return value
+}
+
+// mergeKeys returns a set of the keys of both maps
+func mergeKeys(a, b map[string]string) map[string]bool {
+	keys := make(map[string]bool, len(a)+len(b))
+	for key := range a {
+		keys[key] = true
+	}
+	for key := range b {
+		keys[key] = true
+	}
+	return keys
+}
@@ -0,0 +1,166 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `{
+  "server": {"port": 8080},
+  "pow": {"difficulty": 4, "challenge_ttl_seconds": 300},
+  "rate_limits": {"max_requests_per_day_ip": 5, "max_challenges_per_hour": 10}
+}`
+
+// loadTestConfig loads body as config.json from a temporary working directory
+func loadTestConfig(t *testing.T, body string) *Config {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.Mkdir("config", 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join("config", "config.json"), []byte(body), 0o644))
+	cfg, err := Load()
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestReloadAppliesLiveSettings(t *testing.T) {
+	t.Setenv("FAUCET_TEST_MODE", "false")
+	cfg := loadTestConfig(t, testConfig)
+
+	require.NoError(t, os.WriteFile(cfg.Path(), []byte(`{
+  "server": {"port": 8080},
+  "pow": {"difficulty": 5, "challenge_ttl_seconds": 300},
+  "rate_limits": {"max_requests_per_day_ip": 3, "max_challenges_per_hour": 10}
+}`), 0o644))
+
+	next, err := cfg.Reload()
+	require.NoError(t, err)
+	changes, err := CheckReload(cfg, next)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"github.max_requests_per_day: 10 -> 6", // Derived from the per-IP limit
+		"pow.difficulty: 4 -> 5",
+		"rate_limits.max_requests_per_day_ip: 5 -> 3",
+	}, changes)
+}
+
+func TestReloadRejectsRestartSettings(t *testing.T) {
+	t.Setenv("FAUCET_TEST_MODE", "false")
+	cfg := loadTestConfig(t, testConfig)
+
+	require.NoError(t, os.WriteFile(cfg.Path(), []byte(`{
+  "server": {"port": 9090},
+  "pow": {"difficulty": 5, "challenge_ttl_seconds": 300},
+  "rate_limits": {"max_requests_per_day_ip": 5, "max_challenges_per_hour": 10}
+}`), 0o644))
+
+	next, err := cfg.Reload()
+	require.NoError(t, err)
+	_, err = CheckReload(cfg, next)
+	var reloadErr *ReloadError
+	require.ErrorAs(t, err, &reloadErr)
+	assert.Equal(t, []string{"server.port"}, reloadErr.Fields, "the live difficulty change is not applied either")
+}
+
+func TestReloadRejectsPrefixLengths(t *testing.T) {
+	t.Setenv("FAUCET_TEST_MODE", "false")
+	cfg := loadTestConfig(t, testConfig)
+
+	require.NoError(t, os.WriteFile(cfg.Path(), []byte(`{
+  "server": {"port": 8080},
+  "pow": {"difficulty": 4, "challenge_ttl_seconds": 300},
+  "rate_limits": {"max_requests_per_day_ip": 5, "max_challenges_per_hour": 10, "ipv4_prefix_length": 24, "ipv6_prefix_length": 48}
+}`), 0o644))
+
+	next, err := cfg.Reload()
+	require.NoError(t, err)
+	_, err = CheckReload(cfg, next)
+	var reloadErr *ReloadError
+	require.ErrorAs(t, err, &reloadErr)
+	assert.Equal(t, []string{"rate_limits.ipv4_prefix_length", "rate_limits.ipv6_prefix_length"}, reloadErr.Fields,
+		"the per-IP counters are keyed by prefix, so a live change would reset them")
+}
+
+func TestReloadRejectsOutOfRangeDifficulty(t *testing.T) {
+	t.Setenv("FAUCET_TEST_MODE", "false")
+	cfg := loadTestConfig(t, testConfig)
+
+	for _, pow := range []string{
+		`{"difficulty": 65}`,
+		`{"difficulty": 257, "difficulty_format": "bits"}`,
+		`{"difficulty": 4, "adaptive": {"enabled": true, "max_difficulty": 80}}`,
+	} {
+		require.NoError(t, os.WriteFile(cfg.Path(), []byte(`{"server": {"port": 8080}, "pow": `+pow+`}`), 0o644))
+		_, err := cfg.Reload()
+		var configErr *ConfigError
+		assert.ErrorAs(t, err, &configErr, pow)
+	}
+}
+
+func TestReloadRejectsChangedEnvFile(t *testing.T) {
+	t.Setenv("FAUCET_TEST_MODE", "false")
+	cfg := loadTestConfig(t, testConfig)
+
+	require.NoError(t, os.WriteFile(".env", []byte("FAUCET_SESSION_SECRET=new\n"), 0o600))
+	_, err := cfg.Reload()
+	var reloadErr *ReloadError
+	require.ErrorAs(t, err, &reloadErr)
+	assert.Equal(t, []string{"FAUCET_SESSION_SECRET"}, reloadErr.Fields)
+	assert.NotContains(t, err.Error(), "new", "secret values are never reported")
+}
+
+func TestCheckChainReload(t *testing.T) {
+	current := &ChainConfig{
+		Tokens: map[string]TokenConfig{
+			"ETH": {Decimals: 18, DripAmount: "0.01", MaxPerHour: 1},
+		},
+		MinBalanceProtectPct: 5,
+	}
+
+	tests := []struct {
+		name    string
+		next    ChainConfig
+		changes []string
+		restart []string
+	}{
+		{
+			name: "drip amount and protection",
+			next: ChainConfig{Tokens: map[string]TokenConfig{
+				"ETH": {Decimals: 18, DripAmount: "0.02", MaxPerHour: 1},
+			}, MinBalanceProtectPct: 10},
+			changes: []string{`min_balance_protect_pct: 5 -> 10`, `tokens.ETH.drip_amount: "0.01" -> "0.02"`},
+		},
+		{
+			name: "decimals",
+			next: ChainConfig{Tokens: map[string]TokenConfig{
+				"ETH": {Decimals: 6, DripAmount: "0.01", MaxPerHour: 1},
+			}, MinBalanceProtectPct: 5},
+			restart: []string{"tokens.ETH.decimals"},
+		},
+		{
+			name: "new token",
+			next: ChainConfig{Tokens: map[string]TokenConfig{
+				"ETH":  {Decimals: 18, DripAmount: "0.01", MaxPerHour: 1},
+				"USDC": {DripAmount: "1"},
+			}, MinBalanceProtectPct: 5},
+			restart: []string{"tokens.USDC.drip_amount", "tokens.USDC.max_per_day", "tokens.USDC.max_per_hour"},
+		},
+	}
+	for _, tt := range tests {
+		changes, err := CheckChainReload("config.json", current, &tt.next)
+		if tt.restart != nil {
+			var reloadErr *ReloadError
+			require.ErrorAs(t, err, &reloadErr, tt.name)
+			assert.Equal(t, tt.restart, reloadErr.Fields, tt.name)
+			continue
+		}
+		require.NoError(t, err, tt.name)
+		assert.Equal(t, tt.changes, changes, tt.name)
+	}
+
+	invalid := &ChainConfig{Tokens: map[string]TokenConfig{"ETH": {DripAmount: "lots"}}}
+	_, err := CheckChainReload("config.json", current, invalid)
+	assert.ErrorContains(t, err, "tokens.ETH.drip_amount")
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchSettle is how long the watched files must stay quiet before onChange
+// runs, so an editor's write, rename and chmod make a single reload
+const watchSettle = 500 * time.Millisecond
+
+// Watch calls onChange after any of the files is written, created or
+// replaced, until ctx is cancelled. Their directories are watched rather than
+// the files, so edits that replace a file by renaming are seen too.
+func Watch(ctx context.Context, files []string, onChange func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	watched := map[string]bool{}
+	for _, file := range files {
+		abs, err := filepath.Abs(file)
+		if err != nil {
+			watcher.Close()
+			return err
+		}
+		watched[abs] = true
+		if err := watcher.Add(filepath.Dir(abs)); err != nil {
+			watcher.Close()
+			return err
+		}
+	}
+
+	go func() {
+		defer watcher.Close()
+
+		settle := time.NewTimer(watchSettle)
+		settle.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				settle.Stop()
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				abs, _ := filepath.Abs(event.Name)
+				if watched[abs] && event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+					settle.Reset(watchSettle)
+				}
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			case <-settle.C:
+				onChange()
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,64 @@
+package human
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSiteVerifier(t *testing.T, handler http.HandlerFunc) *siteVerifier {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	v := NewTurnstile("secret").(*siteVerifier)
+	v.verifyURL = server.URL
+	return v
+}
+
+func TestSiteVerifier(t *testing.T) {
+	v := newTestSiteVerifier(t, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "secret", r.PostForm.Get("secret"))
+		assert.Equal(t, "1.2.3.4", r.PostForm.Get("remoteip"))
+
+		if r.PostForm.Get("response") == "good" {
+			w.Write([]byte(`{"success": true}`))
+			return
+		}
+		w.Write([]byte(`{"success": false, "error-codes": ["invalid-input-response"]}`))
+	})
+
+	assert.NoError(t, v.Verify(context.Background(), "good", "1.2.3.4"))
+
+	err := v.Verify(context.Background(), "bad", "1.2.3.4")
+	assert.ErrorIs(t, err, ErrRejected)
+	assert.Contains(t, err.Error(), "invalid-input-response")
+
+	assert.ErrorIs(t, v.Verify(context.Background(), "", "1.2.3.4"), ErrMissingToken)
+}
+
+func TestSiteVerifierProviderError(t *testing.T) {
+	v := newTestSiteVerifier(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	err := v.Verify(context.Background(), "good", "")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrRejected)
+}
+
+func TestStubVerifier(t *testing.T) {
+	v, err := New(ProviderStub, "pass", "")
+	require.NoError(t, err)
+
+	assert.NoError(t, v.Verify(context.Background(), "pass", ""))
+	assert.ErrorIs(t, v.Verify(context.Background(), "fail", ""), ErrRejected)
+	assert.ErrorIs(t, v.Verify(context.Background(), "", ""), ErrMissingToken)
+
+	_, err = New("recaptcha", "", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,38 @@
+package identity
+
+import (
+	"fmt"
+	"time"
+)
+
+// Eligibility holds the rules a GitHub account must meet to use the faucet.
+// Zero values disable the corresponding rule.
+type Eligibility struct {
+	MinAccountAge  time.Duration
+	MinPublicRepos int
+	MinFollowers   int
+}
+
+// IneligibleError explains why an account may not use the faucet
+type IneligibleError struct {
+	Reason string
+}
+
+func (e *IneligibleError) Error() string {
+	return "account not eligible: " + e.Reason
+}
+
+// Check returns an *IneligibleError if user does not meet the rules at now
+func (e Eligibility) Check(user *User, now time.Time) error {
+	if e.MinAccountAge > 0 && now.Sub(user.CreatedAt) < e.MinAccountAge {
+		days := int(e.MinAccountAge.Hours() / 24)
+		return &IneligibleError{Reason: fmt.Sprintf("GitHub account must be at least %d days old", days)}
+	}
+	if user.PublicRepos < e.MinPublicRepos {
+		return &IneligibleError{Reason: fmt.Sprintf("GitHub account needs at least %d public repositories", e.MinPublicRepos)}
+	}
+	if user.Followers < e.MinFollowers {
+		return &IneligibleError{Reason: fmt.Sprintf("GitHub account needs at least %d followers", e.MinFollowers)}
+	}
+	return nil
+}
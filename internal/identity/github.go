@@ -0,0 +1,180 @@
+// Package identity signs users in with GitHub through the OAuth device flow,
+// decides whether their account is eligible for the faucet, and issues the
+// session tokens that let quotas follow an account instead of an IP address.
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Default GitHub endpoints
+const (
+	DefaultGitHubOAuthURL = "https://github.com"
+	DefaultGitHubAPIURL   = "https://api.github.com"
+)
+
+// deviceGrantType is the OAuth grant type for device flow token polling
+const deviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+var (
+	// ErrAuthorizationPending means the user has not yet entered the code
+	ErrAuthorizationPending = errors.New("authorization pending")
+
+	// ErrSlowDown means the client is polling too often
+	ErrSlowDown = errors.New("slow down")
+
+	// ErrDeviceCodeExpired means the device code expired before the user authorized it
+	ErrDeviceCodeExpired = errors.New("device code expired")
+
+	// ErrAccessDenied means the user declined the authorization
+	ErrAccessDenied = errors.New("access denied")
+)
+
+// DeviceCode is GitHub's answer to a device flow start
+type DeviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+}
+
+// User is the subset of a GitHub user profile used for eligibility
+type User struct {
+	ID          int64     `json:"id"`
+	Login       string    `json:"login"`
+	CreatedAt   time.Time `json:"created_at"`
+	PublicRepos int       `json:"public_repos"`
+	Followers   int       `json:"followers"`
+}
+
+// GitHubClient talks to GitHub's OAuth device flow and user API.
+// The URLs are configurable so tests can point it at a MockGitHub.
+type GitHubClient struct {
+	clientID string
+	oauthURL string
+	apiURL   string
+	client   *http.Client
+}
+
+// NewGitHubClient creates a client for the OAuth app with clientID.
+// Empty URLs default to github.com.
+func NewGitHubClient(clientID, oauthURL, apiURL string) *GitHubClient {
+	if oauthURL == "" {
+		oauthURL = DefaultGitHubOAuthURL
+	}
+	if apiURL == "" {
+		apiURL = DefaultGitHubAPIURL
+	}
+	return &GitHubClient{
+		clientID: clientID,
+		oauthURL: strings.TrimRight(oauthURL, "/"),
+		apiURL:   strings.TrimRight(apiURL, "/"),
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// StartDeviceFlow requests a device and user code. The user enters the user
+// code at the verification URI while the client polls with PollToken.
+func (g *GitHubClient) StartDeviceFlow(ctx context.Context) (*DeviceCode, error) {
+	form := url.Values{}
+	form.Set("client_id", g.clientID)
+	form.Set("scope", "read:user")
+
+	var code DeviceCode
+	if err := g.postForm(ctx, g.oauthURL+"/login/device/code", form, &code); err != nil {
+		return nil, fmt.Errorf("failed to start device flow: %w", err)
+	}
+	if code.DeviceCode == "" {
+		return nil, fmt.Errorf("failed to start device flow: empty device code")
+	}
+	return &code, nil
+}
+
+// PollToken exchanges a device code for an access token. It returns
+// ErrAuthorizationPending until the user has entered the code.
+func (g *GitHubClient) PollToken(ctx context.Context, deviceCode string) (string, error) {
+	form := url.Values{}
+	form.Set("client_id", g.clientID)
+	form.Set("device_code", deviceCode)
+	form.Set("grant_type", deviceGrantType)
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		Error       string `json:"error"`
+	}
+	if err := g.postForm(ctx, g.oauthURL+"/login/oauth/access_token", form, &result); err != nil {
+		return "", fmt.Errorf("failed to poll device token: %w", err)
+	}
+
+	switch result.Error {
+	case "":
+		if result.AccessToken == "" {
+			return "", fmt.Errorf("failed to poll device token: empty access token")
+		}
+		return result.AccessToken, nil
+	case "authorization_pending":
+		return "", ErrAuthorizationPending
+	case "slow_down":
+		return "", ErrSlowDown
+	case "expired_token":
+		return "", ErrDeviceCodeExpired
+	case "access_denied":
+		return "", ErrAccessDenied
+	default:
+		return "", fmt.Errorf("device flow error: %s", result.Error)
+	}
+}
+
+// GetUser fetches the profile of the user an access token belongs to
+func (g *GitHubClient) GetUser(ctx context.Context, accessToken string) (*User, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.apiURL+"/user", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch GitHub user: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch GitHub user: status %d", resp.StatusCode)
+	}
+
+	var user User
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return nil, fmt.Errorf("failed to decode GitHub user: %w", err)
+	}
+	return &user, nil
+}
+
+func (g *GitHubClient) postForm(ctx context.Context, endpoint string, form url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
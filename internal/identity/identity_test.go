@@ -0,0 +1,104 @@
+package identity
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGitHub(t *testing.T) (*MockGitHub, *GitHubClient) {
+	mock := NewMockGitHub()
+	server := httptest.NewServer(mock)
+	t.Cleanup(server.Close)
+	return mock, NewGitHubClient("client-id", server.URL, server.URL)
+}
+
+func TestDeviceFlow(t *testing.T) {
+	mock, client := newTestGitHub(t)
+	ctx := context.Background()
+
+	code, err := client.StartDeviceFlow(ctx)
+	require.NoError(t, err)
+	assert.NotEmpty(t, code.UserCode)
+
+	_, err = client.PollToken(ctx, code.DeviceCode)
+	assert.ErrorIs(t, err, ErrAuthorizationPending)
+
+	octocat := &User{ID: 583231, Login: "octocat", CreatedAt: time.Now().AddDate(-5, 0, 0), PublicRepos: 8}
+	require.True(t, mock.Approve(code.UserCode, octocat))
+
+	token, err := client.PollToken(ctx, code.DeviceCode)
+	require.NoError(t, err)
+
+	user, err := client.GetUser(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, int64(583231), user.ID)
+	assert.Equal(t, "octocat", user.Login)
+
+	// The device code is single use
+	_, err = client.PollToken(ctx, code.DeviceCode)
+	assert.ErrorIs(t, err, ErrDeviceCodeExpired)
+}
+
+func TestDeviceFlowDenied(t *testing.T) {
+	mock, client := newTestGitHub(t)
+	ctx := context.Background()
+
+	code, err := client.StartDeviceFlow(ctx)
+	require.NoError(t, err)
+	require.True(t, mock.Deny(code.UserCode))
+
+	_, err = client.PollToken(ctx, code.DeviceCode)
+	assert.ErrorIs(t, err, ErrAccessDenied)
+}
+
+func TestEligibility(t *testing.T) {
+	now := time.Now()
+	rules := Eligibility{MinAccountAge: 30 * 24 * time.Hour, MinPublicRepos: 1}
+
+	tests := []struct {
+		name     string
+		user     User
+		eligible bool
+	}{
+		{"established", User{CreatedAt: now.AddDate(-1, 0, 0), PublicRepos: 3}, true},
+		{"too new", User{CreatedAt: now.AddDate(0, 0, -3), PublicRepos: 3}, false},
+		{"no activity", User{CreatedAt: now.AddDate(-1, 0, 0)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := rules.Check(&tt.user, now)
+			if tt.eligible {
+				assert.NoError(t, err)
+			} else {
+				var ineligible *IneligibleError
+				assert.ErrorAs(t, err, &ineligible)
+			}
+		})
+	}
+}
+
+func TestSession(t *testing.T) {
+	signer, err := NewSessionSigner([]byte("0123456789abcdef0123456789abcdef"), time.Hour)
+	require.NoError(t, err)
+
+	now := time.Now()
+	token, _, err := signer.Issue(&User{ID: 42, Login: "dev"}, now)
+	require.NoError(t, err)
+
+	session, err := signer.Verify(token, now)
+	require.NoError(t, err)
+	assert.Equal(t, "42", session.Subject())
+	assert.Equal(t, "dev", session.Login)
+
+	_, err = signer.Verify(token, now.Add(2*time.Hour))
+	assert.ErrorIs(t, err, ErrInvalidSession)
+
+	_, err = signer.Verify("x"+token, now)
+	assert.ErrorIs(t, err, ErrInvalidSession)
+}
@@ -0,0 +1,151 @@
+package identity
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// MockGitHub is a local stand-in for GitHub's device flow and user API, for
+// tests and local development. Point GitHubClient's OAuth and API URLs at it.
+type MockGitHub struct {
+	mu sync.Mutex
+
+	// devices maps device codes to their state
+	devices map[string]*mockDevice
+
+	// tokens maps issued access tokens to users
+	tokens map[string]*User
+
+	// autoApprove, when set, authorizes every device code as this user
+	autoApprove *User
+}
+
+type mockDevice struct {
+	userCode string
+	user     *User // nil until approved
+	denied   bool
+}
+
+// NewMockGitHub creates an empty mock provider
+func NewMockGitHub() *MockGitHub {
+	return &MockGitHub{
+		devices: make(map[string]*mockDevice),
+		tokens:  make(map[string]*User),
+	}
+}
+
+// AutoApprove makes every device flow succeed immediately as user
+func (m *MockGitHub) AutoApprove(user *User) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.autoApprove = user
+}
+
+// Approve simulates user entering userCode and authorizing the app
+func (m *MockGitHub) Approve(userCode string, user *User) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, d := range m.devices {
+		if d.userCode == userCode {
+			d.user = user
+			return true
+		}
+	}
+	return false
+}
+
+// Deny simulates the user declining the authorization for userCode
+func (m *MockGitHub) Deny(userCode string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, d := range m.devices {
+		if d.userCode == userCode {
+			d.denied = true
+			return true
+		}
+	}
+	return false
+}
+
+// ServeHTTP implements the subset of GitHub's API that GitHubClient uses
+func (m *MockGitHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/login/device/code":
+		m.deviceCode(w, r)
+	case r.Method == http.MethodPost && r.URL.Path == "/login/oauth/access_token":
+		m.accessToken(w, r)
+	case r.Method == http.MethodGet && r.URL.Path == "/user":
+		m.user(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (m *MockGitHub) deviceCode(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	deviceCode := randomHex(20)
+	userCode := strings.ToUpper(randomHex(2) + "-" + randomHex(2))
+	m.devices[deviceCode] = &mockDevice{userCode: userCode, user: m.autoApprove}
+
+	writeJSON(w, DeviceCode{
+		DeviceCode:      deviceCode,
+		UserCode:        userCode,
+		VerificationURI: "http://" + r.Host + "/login/device",
+		ExpiresIn:       900,
+		Interval:        1,
+	})
+}
+
+func (m *MockGitHub) accessToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	device, ok := m.devices[r.PostForm.Get("device_code")]
+	switch {
+	case !ok:
+		writeJSON(w, map[string]string{"error": "expired_token"})
+	case device.denied:
+		writeJSON(w, map[string]string{"error": "access_denied"})
+	case device.user == nil:
+		writeJSON(w, map[string]string{"error": "authorization_pending"})
+	default:
+		token := "gho_" + randomHex(16)
+		m.tokens[token] = device.user
+		delete(m.devices, r.PostForm.Get("device_code"))
+		writeJSON(w, map[string]string{"access_token": token, "token_type": "bearer"})
+	}
+}
+
+func (m *MockGitHub) user(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	user, ok := m.tokens[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+	m.mu.Unlock()
+
+	if !ok {
+		http.Error(w, `{"message": "Bad credentials"}`, http.StatusUnauthorized)
+		return
+	}
+	writeJSON(w, user)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,72 @@
+package identity
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/signed"
+)
+
+// MinSessionSecretLength is the shortest accepted session signing secret, in bytes
+const MinSessionSecretLength = 32
+
+// ErrInvalidSession is returned for session tokens that are malformed, forged or expired
+var ErrInvalidSession = errors.New("invalid or expired session")
+
+// Session is a signed-in faucet user, carried in a bearer token
+type Session struct {
+	Provider  string `json:"provider"` // Currently always "github"
+	AccountID int64  `json:"account_id"`
+	Login     string `json:"login"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Subject returns the quota subject ID for the session's account
+func (s *Session) Subject() string {
+	return strconv.FormatInt(s.AccountID, 10)
+}
+
+// SessionSigner issues and verifies HMAC-signed session tokens
+type SessionSigner struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// NewSessionSigner creates a signer issuing sessions valid for ttl
+func NewSessionSigner(secret []byte, ttl time.Duration) (*SessionSigner, error) {
+	if len(secret) < MinSessionSecretLength {
+		return nil, fmt.Errorf("session secret must be at least %d bytes", MinSessionSecretLength)
+	}
+	return &SessionSigner{secret: secret, ttl: ttl}, nil
+}
+
+// Issue creates a session token for a GitHub user
+func (s *SessionSigner) Issue(user *User, now time.Time) (string, *Session, error) {
+	session := &Session{
+		Provider:  "github",
+		AccountID: user.ID,
+		Login:     user.Login,
+		ExpiresAt: now.Add(s.ttl).Unix(),
+	}
+
+	token, err := signed.Seal(s.secret, session)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to encode session: %w", err)
+	}
+	return token, session, nil
+}
+
+// Verify checks a session token's signature and expiry
+func (s *SessionSigner) Verify(token string, now time.Time) (*Session, error) {
+	var session Session
+	if err := signed.Open(s.secret, token, &session); err != nil {
+		return nil, ErrInvalidSession
+	}
+
+	if now.Unix() >= session.ExpiresAt {
+		return nil, ErrInvalidSession
+	}
+	return &session, nil
+}
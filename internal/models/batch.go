@@ -0,0 +1,34 @@
+package models
+
+// BatchRequest funds many addresses at once for an API key holder
+type BatchRequest struct {
+	Items []BatchItem `json:"items" validate:"required"`
+}
+
+// BatchItem is one transfer of a batch: the token's drip amount to an address
+type BatchItem struct {
+	Address string `json:"address" validate:"required"`
+	Network string `json:"network"` // Optional: defaults to the server's default network
+	Token   string `json:"token" validate:"required"`
+}
+
+// BatchResponse reports the outcome of every item of a batch, in request order
+type BatchResponse struct {
+	Succeeded int           `json:"succeeded"`
+	Failed    int           `json:"failed"`
+	Results   []BatchResult `json:"results"`
+}
+
+// BatchResult is the outcome of one batch item
+type BatchResult struct {
+	Index       int       `json:"index"` // Position of the item in the request, from 0
+	Address     string    `json:"address"`
+	Network     string    `json:"network"`
+	Token       string    `json:"token"`
+	Success     bool      `json:"success"`
+	Amount      string    `json:"amount,omitempty"`
+	TxHash      string    `json:"tx_hash,omitempty"`
+	ExplorerURL string    `json:"explorer_url,omitempty"`
+	Code        ErrorCode `json:"code,omitempty"`  // Why the item failed
+	Error       string    `json:"error,omitempty"` // Why the item failed
+}
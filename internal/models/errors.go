@@ -0,0 +1,125 @@
+package models
+
+// ErrorCode identifies the kind of error in an ErrorResponse. Codes are part
+// of the API: never change or reuse one, only add new ones.
+type ErrorCode string
+
+// Request errors
+const (
+	ErrCodeInvalidRequest     ErrorCode = "invalid_request"
+	ErrCodeUnsupportedNetwork ErrorCode = "unsupported_network"
+	ErrCodeInvalidAddress     ErrorCode = "invalid_address"
+	ErrCodeUnsupportedToken   ErrorCode = "unsupported_token"
+	ErrCodeNotFound           ErrorCode = "not_found"
+)
+
+// Rate limits
+const (
+	ErrCodeChallengeLimit ErrorCode = "challenge_limit"
+	ErrCodeDailyLimit     ErrorCode = "daily_limit"
+	ErrCodeDailyCooldown  ErrorCode = "daily_cooldown"
+	ErrCodeSubnetLimit    ErrorCode = "subnet_limit"
+	ErrCodeHourlyLimit    ErrorCode = "hourly_limit"
+	ErrCodeFaucetLimit    ErrorCode = "faucet_limit"
+	ErrCodeStreamLimit    ErrorCode = "stream_limit"
+)
+
+// Proof of work, human verification and ownership
+const (
+	ErrCodeInvalidChallenge      ErrorCode = "invalid_challenge"
+	ErrCodeChallengeMismatch     ErrorCode = "challenge_mismatch"
+	ErrCodeInvalidProofOfWork    ErrorCode = "invalid_proof_of_work"
+	ErrCodeInvalidTestToken      ErrorCode = "invalid_test_token"
+	ErrCodeCaptchaRequired       ErrorCode = "captcha_required"
+	ErrCodeCaptchaFailed         ErrorCode = "captcha_failed"
+	ErrCodeSignatureRequired     ErrorCode = "signature_required"
+	ErrCodeInvalidSignature      ErrorCode = "invalid_signature"
+	ErrCodeSignatureUnverifiable ErrorCode = "signature_unverifiable"
+)
+
+// Recipient and faucet state
+const (
+	ErrCodeAlreadyFunded ErrorCode = "already_funded"
+	ErrCodeLowBalance    ErrorCode = "low_balance"
+)
+
+// Sign-in
+const (
+	ErrCodeSignInDisabled  ErrorCode = "sign_in_disabled"
+	ErrCodeSignInExpired   ErrorCode = "sign_in_expired"
+	ErrCodeSignInDenied    ErrorCode = "sign_in_denied"
+	ErrCodeNotEligible     ErrorCode = "not_eligible"
+	ErrCodeInvalidSession  ErrorCode = "invalid_session"
+	ErrCodeUpstreamFailure ErrorCode = "upstream_failure"
+)
+
+// API keys
+const (
+	ErrCodeAPIKeyRequired ErrorCode = "api_key_required"
+	ErrCodeInvalidAPIKey  ErrorCode = "invalid_api_key"
+	ErrCodeBudgetExceeded ErrorCode = "budget_exceeded"
+)
+
+// Transaction outcomes, reported on request event streams
+const (
+	ErrCodeTransactionReverted    ErrorCode = "transaction_reverted"
+	ErrCodeTransactionUnconfirmed ErrorCode = "transaction_unconfirmed"
+)
+
+// Server errors
+const (
+	ErrCodeRequestCancelled ErrorCode = "request_cancelled"
+	ErrCodeUnavailable      ErrorCode = "unavailable"
+	ErrCodeInternal         ErrorCode = "internal_error"
+)
+
+// ErrorCodes lists every code the API can return
+var ErrorCodes = []ErrorCode{
+	ErrCodeInvalidRequest,
+	ErrCodeUnsupportedNetwork,
+	ErrCodeInvalidAddress,
+	ErrCodeUnsupportedToken,
+	ErrCodeNotFound,
+	ErrCodeChallengeLimit,
+	ErrCodeDailyLimit,
+	ErrCodeDailyCooldown,
+	ErrCodeSubnetLimit,
+	ErrCodeHourlyLimit,
+	ErrCodeFaucetLimit,
+	ErrCodeStreamLimit,
+	ErrCodeInvalidChallenge,
+	ErrCodeChallengeMismatch,
+	ErrCodeInvalidProofOfWork,
+	ErrCodeInvalidTestToken,
+	ErrCodeCaptchaRequired,
+	ErrCodeCaptchaFailed,
+	ErrCodeSignatureRequired,
+	ErrCodeInvalidSignature,
+	ErrCodeSignatureUnverifiable,
+	ErrCodeAlreadyFunded,
+	ErrCodeLowBalance,
+	ErrCodeSignInDisabled,
+	ErrCodeSignInExpired,
+	ErrCodeSignInDenied,
+	ErrCodeNotEligible,
+	ErrCodeInvalidSession,
+	ErrCodeUpstreamFailure,
+	ErrCodeAPIKeyRequired,
+	ErrCodeInvalidAPIKey,
+	ErrCodeBudgetExceeded,
+	ErrCodeTransactionReverted,
+	ErrCodeTransactionUnconfirmed,
+	ErrCodeRequestCancelled,
+	ErrCodeUnavailable,
+	ErrCodeInternal,
+}
+
+// IsRateLimit reports whether the code is a limit that lifts with time
+func (c ErrorCode) IsRateLimit() bool {
+	switch c {
+	case ErrCodeChallengeLimit, ErrCodeDailyLimit, ErrCodeDailyCooldown,
+		ErrCodeSubnetLimit, ErrCodeHourlyLimit, ErrCodeFaucetLimit, ErrCodeStreamLimit:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCodesUnique(t *testing.T) {
+	seen := make(map[ErrorCode]bool)
+	for _, code := range ErrorCodes {
+		assert.NotEmpty(t, code)
+		assert.False(t, seen[code], "duplicate error code %q", code)
+		seen[code] = true
+	}
+}
+
+func TestIsRateLimit(t *testing.T) {
+	assert.True(t, ErrCodeHourlyLimit.IsRateLimit())
+	assert.True(t, ErrCodeSubnetLimit.IsRateLimit())
+	assert.False(t, ErrCodeLowBalance.IsRateLimit())
+	assert.False(t, ErrCodeInvalidRequest.IsRateLimit())
+}
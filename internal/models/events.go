@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// RequestStage is a step a faucet request passes through
+type RequestStage string
+
+// Request stages, in the order they happen. Stages after verified are
+// reported per transaction; failed may follow any of them. Refused is sent for
+// an attempt turned away before its challenge was spent, and may be followed
+// by another attempt with the same challenge.
+const (
+	StageVerified  RequestStage = "verified"  // Challenge, captcha, ownership and limits checked
+	StageQueued    RequestStage = "queued"    // Waiting for the faucet account to send
+	StageSigned    RequestStage = "signed"    // Transaction signed; its hash is known
+	StageBroadcast RequestStage = "broadcast" // Transaction accepted by a node
+	StageIncluded  RequestStage = "included"  // Transaction in a block
+	StageConfirmed RequestStage = "confirmed" // Block final enough not to be undone
+	StageFailed    RequestStage = "failed"    // Request refused, or transaction reverted or not confirmed in time
+	StageRefused   RequestStage = "refused"   // Attempt refused; the challenge may be tried again
+)
+
+// RequestStages lists every stage in order
+var RequestStages = []RequestStage{
+	StageVerified,
+	StageQueued,
+	StageSigned,
+	StageBroadcast,
+	StageIncluded,
+	StageConfirmed,
+	StageFailed,
+	StageRefused,
+}
+
+// RequestEvent is a stage change of a faucet request, sent on its event stream
+type RequestEvent struct {
+	Seq         int          `json:"seq"` // Position in the stream, from 1; also the SSE event ID
+	Stage       RequestStage `json:"stage"`
+	Time        time.Time    `json:"time"`
+	Token       string       `json:"token,omitempty"`
+	TxHash      string       `json:"tx_hash,omitempty"`
+	ExplorerURL string       `json:"explorer_url,omitempty"`
+	BlockNumber uint64       `json:"block_number,omitempty"`
+	Code        ErrorCode    `json:"code,omitempty"`  // Set on failed and refused
+	Error       string       `json:"error,omitempty"` // Set on failed and refused
+	Final       bool         `json:"final,omitempty"` // No events follow this one
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// Request outcomes counted in the faucet statistics
+const (
+	OutcomeSucceeded = "succeeded"
+	OutcomeRejected  = "rejected"
+	OutcomeFailed    = "failed"
+)
+
+// StatsResponse summarizes what the faucet has given out. Windows are built
+// from time buckets: the hour covers the last 55 to 60 minutes, and the day
+// and week end with the current, partial hour.
+type StatsResponse struct {
+	Hour        StatsWindow `json:"hour"`
+	Day         StatsWindow `json:"day"`
+	Week        StatsWindow `json:"week"`
+	AllTime     StatsWindow `json:"all_time"`
+	GeneratedAt time.Time   `json:"generated_at"`
+}
+
+// StatsWindow totals the faucet's activity over one time window
+type StatsWindow struct {
+	Distributed      []TokenStats     `json:"distributed"`       // Per network and token, sorted
+	UniqueRecipients int64            `json:"unique_recipients"` // Approximate, within about 1%
+	Requests         RequestCounts    `json:"requests"`
+	Rejections       map[string]int64 `json:"rejections"` // Rejected requests by error code
+}
+
+// TokenStats totals what was sent of one token on one network
+type TokenStats struct {
+	Network   string  `json:"network"`
+	Token     string  `json:"token"`
+	Amount    float64 `json:"amount"` // Whole tokens
+	Transfers int64   `json:"transfers"`
+}
+
+// RequestCounts counts faucet requests by outcome
+type RequestCounts struct {
+	Succeeded int64 `json:"succeeded"`
+	Rejected  int64 `json:"rejected"` // Refused by a limit or check
+	Failed    int64 `json:"failed"`   // Failed by a server error
+}
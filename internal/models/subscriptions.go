@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Subscription modes
+const (
+	SubscriptionTopUp    = "top_up"   // Keep the address at or above min_balance
+	SubscriptionInterval = "interval" // Send amount every interval_seconds
+)
+
+// SubscriptionRequest registers a recurring drip on an API key's budget
+type SubscriptionRequest struct {
+	Mode        string  `json:"mode" validate:"required,oneof=top_up interval"`
+	Address     string  `json:"address" validate:"required"`
+	Network     string  `json:"network"` // Optional: defaults to the server's default network
+	Token       string  `json:"token" validate:"required"`
+	MinBalance  float64 `json:"min_balance,omitempty"`      // top_up: whole tokens to keep the address at
+	Amount      float64 `json:"amount,omitempty"`           // interval: whole tokens sent each time
+	IntervalSec int     `json:"interval_seconds,omitempty"` // interval: seconds between drips
+}
+
+// Subscription is a registered recurring drip and the outcome of its last run
+type Subscription struct {
+	ID          string     `json:"id"`
+	Mode        string     `json:"mode"`
+	Address     string     `json:"address"`
+	Network     string     `json:"network"`
+	Token       string     `json:"token"`
+	MinBalance  float64    `json:"min_balance,omitempty"`
+	Amount      float64    `json:"amount,omitempty"`
+	IntervalSec int        `json:"interval_seconds,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastRunAt   *time.Time `json:"last_run_at,omitempty"`  // Last time tokens were sent or a send failed
+	LastAmount  string     `json:"last_amount,omitempty"`  // Amount of the last successful drip
+	LastTxHash  string     `json:"last_tx_hash,omitempty"` // Transaction of the last successful drip
+	LastCode    ErrorCode  `json:"last_code,omitempty"`    // Why the last run failed
+	LastError   string     `json:"last_error,omitempty"`   // Why the last run failed
+}
+
+// SubscriptionsResponse lists an API key's subscriptions
+type SubscriptionsResponse struct {
+	Subscriptions []Subscription `json:"subscriptions"`
+}
@@ -2,34 +2,63 @@ package models
 
 import "time"
 
-// ChallengeRequest represents a request for a PoW challenge
-type ChallengeRequest struct{}
+// ChallengeRequest represents a request for a PoW challenge.
+// The challenge is bound to these parameters and can only be spent on a matching faucet request.
+type ChallengeRequest struct {
+	Address string `json:"address" validate:"required"`
+	Token   string `json:"token" validate:"required,oneof=ETH STRK BOTH"`
+	Network string `json:"network"` // Optional: starknet, ethereum (defaults to server's default)
+}
 
 // ChallengeResponse represents the response containing a PoW challenge
 type ChallengeResponse struct {
-	ChallengeID string `json:"challenge_id"`
-	Challenge   string `json:"challenge"`
-	Difficulty  int    `json:"difficulty"`
+	ChallengeID      string     `json:"challenge_id"`
+	Challenge        string     `json:"challenge"`
+	Difficulty       int        `json:"difficulty"`                  // Leading zero hex digits, rounded up (for older clients)
+	DifficultyBits   int        `json:"difficulty_bits"`             // Leading zero bits; the exact requirement
+	DifficultyFormat string     `json:"difficulty_format,omitempty"` // hex or bits: the unit the server tunes difficulty in
+	Target           string     `json:"target,omitempty"`            // Hash must be below this 256-bit hex number
+	Algorithm        string     `json:"algorithm,omitempty"`         // sha256 (default), argon2id or scrypt
+	Params           *PoWParams `json:"params,omitempty"`            // Only set for memory-hard algorithms
+	RequestID        string     `json:"request_id,omitempty"`        // ID of the faucet request that spends this challenge
+	SigningChainID   string     `json:"signing_chain_id,omitempty"`  // SNIP-12 chain ID to sign an ownership proof for, on Starknet
+}
+
+// PoWParams holds tuning parameters for memory-hard PoW algorithms
+type PoWParams struct {
+	// Argon2id parameters
+	Time      uint32 `json:"time,omitempty"`
+	MemoryKiB uint32 `json:"memory_kib,omitempty"`
+	Threads   uint8  `json:"threads,omitempty"`
+
+	// scrypt parameters
+	N int `json:"n,omitempty"`
+	R int `json:"r,omitempty"`
+	P int `json:"p,omitempty"`
 }
 
 // FaucetRequest represents a request for tokens from the faucet
 type FaucetRequest struct {
-	Address     string `json:"address" validate:"required"`
-	Token       string `json:"token" validate:"required,oneof=ETH STRK BOTH"`
-	Network     string `json:"network"`                        // Optional: starknet, ethereum (defaults to server's default)
-	ChallengeID string `json:"challenge_id" validate:"required"`
-	Nonce       int64  `json:"nonce" validate:"required"`
+	Address      string `json:"address" validate:"required"`
+	Token        string `json:"token" validate:"required,oneof=ETH STRK BOTH"`
+	Network      string `json:"network"` // Optional: starknet, ethereum (defaults to server's default)
+	ChallengeID  string `json:"challenge_id" validate:"required"`
+	Nonce        int64  `json:"nonce" validate:"required"`
+	TestToken    string `json:"test_token,omitempty"`    // Optional: signed test token, replaces challenge and nonce
+	CaptchaToken string `json:"captcha_token,omitempty"` // Human verification token, when the server requires it
+	Signature    string `json:"signature,omitempty"`     // Recipient's signature over the challenge, proving ownership
 }
 
 // FaucetResponse represents the successful response from a faucet request
 type FaucetResponse struct {
-	Success      bool               `json:"success"`
-	TxHash       string             `json:"tx_hash,omitempty"`        // Single token transaction
-	Amount       string             `json:"amount,omitempty"`         // Single token amount
-	Token        string             `json:"token,omitempty"`          // Single token type
-	ExplorerURL  string             `json:"explorer_url,omitempty"`   // Single token explorer URL
-	Message      string             `json:"message"`
-	Transactions []TransactionInfo  `json:"transactions,omitempty"`   // Multiple tokens (when token=BOTH)
+	Success      bool              `json:"success"`
+	TxHash       string            `json:"tx_hash,omitempty"`      // Single token transaction
+	Amount       string            `json:"amount,omitempty"`       // Single token amount
+	Token        string            `json:"token,omitempty"`        // Single token type
+	ExplorerURL  string            `json:"explorer_url,omitempty"` // Single token explorer URL
+	Message      string            `json:"message"`
+	Transactions []TransactionInfo `json:"transactions,omitempty"` // Multiple tokens (when token=BOTH)
+	RequestID    string            `json:"request_id,omitempty"`   // Follow at /api/v1/requests/{id}/events
 }
 
 // TransactionInfo represents info about a single token transfer
@@ -40,11 +69,44 @@ type TransactionInfo struct {
 	ExplorerURL string `json:"explorer_url"`
 }
 
-// ErrorResponse represents an error response
+// ErrorResponse represents an error response. Clients should branch on Code;
+// Error is a human-readable message that may change.
 type ErrorResponse struct {
-	Error           string     `json:"error"`
-	NextRequestTime *time.Time `json:"next_request_time,omitempty"`
-	RemainingHours  *float64   `json:"remaining_hours,omitempty"`
+	Code            ErrorCode     `json:"code"`
+	Error           string        `json:"error"`
+	Details         *ErrorDetails `json:"details,omitempty"`
+	NextRequestTime *time.Time    `json:"next_request_time,omitempty"`
+	RemainingHours  *float64      `json:"remaining_hours,omitempty"`
+}
+
+// ErrorDetails carries the structured facts behind an error, such as the limit
+// that was hit and the network and token of the request
+type ErrorDetails struct {
+	Limit      int     `json:"limit,omitempty"`
+	Used       int     `json:"used,omitempty"`
+	Network    string  `json:"network,omitempty"`
+	Token      string  `json:"token,omitempty"`
+	Balance    float64 `json:"balance,omitempty"`
+	MaxBalance float64 `json:"max_balance,omitempty"`
+}
+
+// PreflightResponse reports whether a faucet request would be accepted right now
+type PreflightResponse struct {
+	Address        string             `json:"address"`
+	Network        string             `json:"network"`
+	Token          string             `json:"token"`
+	Eligible       bool               `json:"eligible"`
+	Reasons        []string           `json:"reasons,omitempty"` // Why the request would be refused
+	DailyRemaining int                `json:"daily_remaining"`
+	Recipient      []RecipientBalance `json:"recipient_balances,omitempty"` // Only tokens with a balance cap
+}
+
+// RecipientBalance is a recipient's balance of a token against the faucet's cap
+type RecipientBalance struct {
+	Token      string  `json:"token"`
+	Balance    float64 `json:"balance"`
+	MaxBalance float64 `json:"max_balance"`
+	OverCap    bool    `json:"over_cap"`
 }
 
 // StatusResponse represents the status of an address
@@ -56,13 +118,44 @@ type StatusResponse struct {
 	RemainingHours  *float64   `json:"remaining_hours,omitempty"`
 }
 
+// QuotaResponse reports the caller's remaining daily quota and hourly throttles
+type QuotaResponse struct {
+	DailyLimit     DailyQuota                          `json:"daily_limit"`
+	HourlyThrottle map[string]map[string]TokenThrottle `json:"hourly_throttle_by_network"` // network -> lowercase token -> throttle
+}
+
+// DailyQuota is the tightest daily limit that applies to the caller
+type DailyQuota struct {
+	Scope       string     `json:"scope"` // ip, subnet or the sign-in provider
+	Total       int        `json:"total"`
+	Used        int        `json:"used"`
+	Remaining   int        `json:"remaining"`
+	CooldownEnd *time.Time `json:"cooldown_end"`
+	InCooldown  bool       `json:"in_cooldown"`
+}
+
+// TokenThrottle is the hourly throttle on one token
+type TokenThrottle struct {
+	Available     bool       `json:"available"`
+	NextRequestAt *time.Time `json:"next_request_at"`
+}
+
 // InfoResponse represents information about the faucet
 type InfoResponse struct {
-	Network           string         `json:"network"`
-	Limits            LimitInfo      `json:"limits"`
-	PoW               PoWInfo        `json:"pow"`
-	FaucetBalance     BalanceInfo    `json:"faucet_balance"`
-	AvailableNetworks []string       `json:"available_networks,omitempty"`
+	Network           string                 `json:"network"`
+	Limits            LimitInfo              `json:"limits"`
+	PoW               PoWInfo                `json:"pow"`
+	FaucetBalance     BalanceInfo            `json:"faucet_balance"`
+	AvailableNetworks []string               `json:"available_networks,omitempty"`
+	HumanVerification *HumanVerificationInfo `json:"human_verification,omitempty"`
+	OwnershipProof    bool                   `json:"ownership_proof_required,omitempty"` // Requests must be signed by the recipient
+}
+
+// HumanVerificationInfo tells clients which captcha to show
+type HumanVerificationInfo struct {
+	Provider string `json:"provider"`           // turnstile, hcaptcha or stub
+	SiteKey  string `json:"site_key,omitempty"` // Public key for rendering the captcha widget
+	Required bool   `json:"required"`           // Whether anonymous requests on this network need it
 }
 
 // LimitInfo contains information about faucet limits
@@ -75,8 +168,12 @@ type LimitInfo struct {
 
 // PoWInfo contains information about PoW requirements
 type PoWInfo struct {
-	Enabled    bool `json:"enabled"`
-	Difficulty int  `json:"difficulty"`
+	Enabled       bool   `json:"enabled"`
+	Algorithm     string `json:"algorithm,omitempty"`
+	Format        string `json:"difficulty_format,omitempty"` // hex or bits: the unit of the difficulties below
+	Difficulty    int    `json:"difficulty"`                  // Base difficulty
+	MinDifficulty int    `json:"min_difficulty,omitempty"`    // Lowest difficulty issued under light load
+	MaxDifficulty int    `json:"max_difficulty,omitempty"`    // Highest difficulty issued under heavy load or abuse
 }
 
 // BalanceInfo contains information about faucet balances
@@ -90,3 +187,27 @@ type HealthResponse struct {
 	Status    string `json:"status"`
 	Timestamp int64  `json:"timestamp"`
 }
+
+// DeviceLoginResponse starts a GitHub device flow sign-in
+type DeviceLoginResponse struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`        // Code the user enters at VerificationURI
+	VerificationURI string `json:"verification_uri"` // Where the user authorizes the faucet
+	ExpiresIn       int    `json:"expires_in"`       // Seconds until the codes expire
+	Interval        int    `json:"interval"`         // Minimum seconds between polls
+}
+
+// DeviceTokenRequest polls for the result of a device flow sign-in
+type DeviceTokenRequest struct {
+	DeviceCode string `json:"device_code" validate:"required"`
+}
+
+// LoginResponse is the result of polling a device flow sign-in.
+// While the user has not authorized yet, Status is "pending" and no session is returned.
+type LoginResponse struct {
+	Status       string `json:"status"`                  // pending or complete
+	Interval     int    `json:"interval,omitempty"`      // Seconds to wait before polling again
+	SessionToken string `json:"session_token,omitempty"` // Send as "Authorization: Bearer <token>"
+	Login        string `json:"login,omitempty"`
+	ExpiresAt    int64  `json:"expires_at,omitempty"`
+}
@@ -0,0 +1,81 @@
+package models
+
+// API v2 models. Unlike v1 they describe every network and token the faucet
+// serves instead of fixed STRK and ETH fields.
+
+// InfoResponseV2 describes the faucet and every network it serves
+type InfoResponseV2 struct {
+	DefaultNetwork     string        `json:"default_network"`
+	DailyRequestsPerIP int           `json:"daily_requests_per_ip"`
+	PoW                PoWInfo       `json:"pow"`
+	Networks           []NetworkInfo `json:"networks"`
+}
+
+// NetworkInfo describes one network and the tokens the faucet sends on it
+type NetworkInfo struct {
+	Network           string                 `json:"network"` // Name to pass as the network parameter
+	Chain             string                 `json:"chain"`   // starknet, ethereum, ...
+	Name              string                 `json:"name"`    // sepolia, mainnet, ...
+	Default           bool                   `json:"default"`
+	FaucetAddress     string                 `json:"faucet_address"`
+	OwnershipProof    bool                   `json:"ownership_proof_required"`
+	HumanVerification *HumanVerificationInfo `json:"human_verification,omitempty"`
+	Tokens            []TokenInfo            `json:"tokens"`
+}
+
+// TokenInfo describes one token: how much the faucet sends, its limits and
+// the faucet's current balance
+type TokenInfo struct {
+	Symbol                string  `json:"symbol"`
+	Decimals              int     `json:"decimals"`
+	ContractAddress       string  `json:"contract_address,omitempty"` // Empty for the chain's native token
+	DripAmount            string  `json:"drip_amount"`
+	MaxPerHour            float64 `json:"max_per_hour"`                    // Faucet-wide hourly cap
+	MaxPerDay             float64 `json:"max_per_day"`                     // Faucet-wide daily cap
+	MaxRecipientBalance   float64 `json:"max_recipient_balance,omitempty"` // Recipients holding this much are refused
+	ThrottleWindowSeconds int     `json:"throttle_window_seconds"`         // Minimum time between requests for the token
+	Balance               string  `json:"balance,omitempty"`               // Exact decimal; omitted when it could not be read
+}
+
+// LimitsResponse lists the limits of every token on every network
+type LimitsResponse struct {
+	DailyRequestsPerIP int             `json:"daily_requests_per_ip"`
+	Networks           []NetworkLimits `json:"networks"`
+}
+
+// NetworkLimits lists the limits of the tokens on one network
+type NetworkLimits struct {
+	Network string        `json:"network"`
+	Tokens  []TokenLimits `json:"tokens"`
+}
+
+// TokenLimits is how much the faucet sends of a token and how often
+type TokenLimits struct {
+	Symbol                string  `json:"symbol"`
+	Decimals              int     `json:"decimals"`
+	DripAmount            string  `json:"drip_amount"`
+	MaxPerHour            float64 `json:"max_per_hour"`
+	MaxPerDay             float64 `json:"max_per_day"`
+	MaxRecipientBalance   float64 `json:"max_recipient_balance,omitempty"`
+	ThrottleWindowSeconds int     `json:"throttle_window_seconds"`
+}
+
+// BalancesResponse lists the faucet's balance of every token on every network
+type BalancesResponse struct {
+	Networks []NetworkBalances `json:"networks"`
+}
+
+// NetworkBalances lists the faucet's balances on one network
+type NetworkBalances struct {
+	Network       string         `json:"network"`
+	FaucetAddress string         `json:"faucet_address"`
+	Tokens        []TokenBalance `json:"tokens"`
+}
+
+// TokenBalance is the faucet's balance of one token
+type TokenBalance struct {
+	Symbol          string `json:"symbol"`
+	Decimals        int    `json:"decimals"`
+	ContractAddress string `json:"contract_address,omitempty"`
+	Balance         string `json:"balance,omitempty"` // Exact decimal; omitted when it could not be read
+}
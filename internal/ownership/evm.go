@@ -0,0 +1,66 @@
+package ownership
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// EVMVerifier checks EIP-191 personal_sign signatures
+type EVMVerifier struct {
+	network string
+}
+
+// NewEVMVerifier creates a verifier for an EVM network
+func NewEVMVerifier(network string) *EVMVerifier {
+	return &EVMVerifier{network: network}
+}
+
+// Verify recovers the signer of the personal_sign message for challenge and
+// compares it with address. signature is the 65-byte hex signature.
+func (v *EVMVerifier) Verify(ctx context.Context, address, challenge, signature string) error {
+	if signature == "" {
+		return ErrMissingSignature
+	}
+	if !common.IsHexAddress(address) {
+		return ErrInvalidSignature
+	}
+
+	sig, err := hexutil.Decode(signature)
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return ErrInvalidSignature
+	}
+	// Wallets return v as 27/28; recovery expects 0/1
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	hash := accounts.TextHash([]byte(Message(strings.ToLower(address), v.network, challenge)))
+	pub, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if crypto.PubkeyToAddress(*pub) != common.HexToAddress(address) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// SignEVM signs the ownership message for challenge with key, the way
+// personal_sign does. It returns the hex signature with v as 27/28.
+func SignEVM(key *ecdsa.PrivateKey, network, challenge string) (string, error) {
+	address := crypto.PubkeyToAddress(key.PublicKey).Hex()
+	hash := accounts.TextHash([]byte(Message(strings.ToLower(address), network, challenge)))
+	sig, err := crypto.Sign(hash, key)
+	if err != nil {
+		return "", err
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return hexutil.Encode(sig), nil
+}
@@ -0,0 +1,42 @@
+// Package ownership verifies that a faucet request comes from the owner of the
+// recipient address: the client signs the PoW challenge with the recipient's
+// key, as an EIP-191 personal_sign message on EVM networks or as SNIP-12
+// typed data on Starknet, where the account contract checks the signature.
+package ownership
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrMissingSignature is returned when a proof is required but none was sent
+	ErrMissingSignature = errors.New("ownership signature required")
+
+	// ErrInvalidSignature is returned when the signature is malformed or was
+	// not made by the recipient
+	ErrInvalidSignature = errors.New("signature does not prove ownership of the recipient")
+
+	// ErrAccountUnverifiable is returned when the recipient account answered
+	// but could not check the signature, e.g. because it is not deployed.
+	// Other verification errors are failures to reach the chain.
+	ErrAccountUnverifiable = errors.New("recipient account could not check the signature")
+)
+
+// Verifier checks a signature over a challenge for a recipient address
+type Verifier interface {
+	Verify(ctx context.Context, address, challenge, signature string) error
+}
+
+// ChainScoped is implemented by verifiers whose signatures are bound to a
+// chain ID, which clients must be told so they sign for the right chain
+type ChainScoped interface {
+	ChainID() string
+}
+
+// Message is the text signed with personal_sign on EVM networks. The
+// challenge ties the signature to one faucet request.
+func Message(address, network, challenge string) string {
+	return fmt.Sprintf("Request testnet tokens for %s on %s.\n\nChallenge: %s", address, network, challenge)
+}
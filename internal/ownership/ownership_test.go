@@ -0,0 +1,89 @@
+package ownership
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/starknet.go/curve"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEVMVerifier(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey).Hex()
+
+	sig, err := SignEVM(key, "ethereum", "abc123")
+	require.NoError(t, err)
+
+	v := NewEVMVerifier("ethereum")
+	ctx := context.Background()
+
+	assert.NoError(t, v.Verify(ctx, address, "abc123", sig))
+	assert.NoError(t, v.Verify(ctx, strings.ToLower(address), "abc123", sig))
+	assert.ErrorIs(t, v.Verify(ctx, address, "other", sig), ErrInvalidSignature)
+	assert.ErrorIs(t, v.Verify(ctx, "0x000000000000000000000000000000000000dEaD", "abc123", sig), ErrInvalidSignature)
+	assert.ErrorIs(t, v.Verify(ctx, address, "abc123", "0x1234"), ErrInvalidSignature)
+	assert.ErrorIs(t, v.Verify(ctx, address, "abc123", ""), ErrMissingSignature)
+
+	// A signature for one network cannot be replayed on another
+	assert.ErrorIs(t, NewEVMVerifier("arbitrum").Verify(ctx, address, "abc123", sig), ErrInvalidSignature)
+}
+
+func TestStarknetVerifier(t *testing.T) {
+	privateKey, publicKey, _, err := curve.GetRandomKeys()
+	require.NoError(t, err)
+	address := "0x0123456789abcdef"
+
+	checker := NewStubAccountChecker()
+	require.NoError(t, checker.Register(address, new(felt.Felt).SetBigInt(publicKey)))
+
+	sig, err := SignStarknet(privateKey, address, StarknetSepoliaChainID, "abc123")
+	require.NoError(t, err)
+
+	v := NewStarknetVerifier(checker, StarknetSepoliaChainID)
+	ctx := context.Background()
+
+	assert.NoError(t, v.Verify(ctx, address, "abc123", sig))
+	assert.ErrorIs(t, v.Verify(ctx, address, "other", sig), ErrInvalidSignature)
+	assert.ErrorIs(t, v.Verify(ctx, address, "abc123", "not-a-felt"), ErrInvalidSignature)
+	assert.ErrorIs(t, v.Verify(ctx, address, "abc123", ""), ErrMissingSignature)
+
+	// Signed for another chain
+	mainnetSig, err := SignStarknet(privateKey, address, StarknetMainnetChainID, "abc123")
+	require.NoError(t, err)
+	assert.ErrorIs(t, v.Verify(ctx, address, "abc123", mainnetSig), ErrInvalidSignature)
+
+	// Undeployed account
+	err = v.Verify(ctx, "0x42", "abc123", sig)
+	assert.ErrorIs(t, err, ErrAccountUnverifiable)
+	assert.NotErrorIs(t, err, ErrInvalidSignature)
+
+	// Clients learn the chain to sign for from the verifier
+	mainnet := NewStarknetVerifier(checker, StarknetMainnetChainID)
+	assert.Equal(t, StarknetMainnetChainID, mainnet.ChainID())
+	assert.NoError(t, mainnet.Verify(ctx, address, "abc123", mainnetSig))
+}
+
+func TestIsValidResult(t *testing.T) {
+	assert.True(t, isValidResult([]*felt.Felt{validSignature}))
+	assert.True(t, isValidResult([]*felt.Felt{new(felt.Felt).SetUint64(1)}))
+	assert.False(t, isValidResult([]*felt.Felt{new(felt.Felt)}))
+	assert.False(t, isValidResult(nil))
+}
+
+func TestStarknetMessageHashStable(t *testing.T) {
+	a, err := StarknetMessageHash("0x1", StarknetSepoliaChainID, "abc")
+	require.NoError(t, err)
+	b, err := StarknetMessageHash("0x1", StarknetSepoliaChainID, "abc")
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	c, err := StarknetMessageHash("0x2", StarknetSepoliaChainID, "abc")
+	require.NoError(t, err)
+	assert.NotEqual(t, a, c, "hash must commit to the account")
+}
@@ -0,0 +1,216 @@
+package ownership
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/Giri-Aayush/starknet-faucet/chains"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/starknet.go/curve"
+	"github.com/NethermindEth/starknet.go/typeddata"
+	"github.com/NethermindEth/starknet.go/utils"
+)
+
+// Starknet chain IDs used in the SNIP-12 domain
+const (
+	StarknetSepoliaChainID = "SN_SEPOLIA"
+	StarknetMainnetChainID = "SN_MAIN"
+)
+
+// StarknetChainID returns the SNIP-12 chain ID for a Starknet network name
+func StarknetChainID(network string) string {
+	if network == "mainnet" {
+		return StarknetMainnetChainID
+	}
+	return StarknetSepoliaChainID
+}
+
+// validSignature is what SRC-6 accounts return from is_valid_signature ('VALID')
+var validSignature = new(felt.Felt).SetBytes([]byte("VALID"))
+
+// AccountChecker calls a Starknet account contract's is_valid_signature entry
+// point for a signature over a message hash and returns the raw result. An
+// account that answered but could not check the signature, e.g. because it is
+// not deployed, is reported as an error wrapping chains.ErrRejected.
+type AccountChecker interface {
+	IsValidSignature(ctx context.Context, address string, hash *felt.Felt, signature []*felt.Felt) ([]*felt.Felt, error)
+}
+
+// isValidResult interprets the return value of is_valid_signature. SRC-6
+// accounts return 'VALID'; older accounts return 1.
+func isValidResult(result []*felt.Felt) bool {
+	if len(result) == 0 {
+		return false
+	}
+	return result[0].Equal(validSignature) || result[0].IsOne()
+}
+
+// StarknetVerifier checks SNIP-12 typed data signatures through the account contract
+type StarknetVerifier struct {
+	checker AccountChecker
+	chainID string
+}
+
+// NewStarknetVerifier creates a verifier for the Starknet network with chainID
+func NewStarknetVerifier(checker AccountChecker, chainID string) *StarknetVerifier {
+	return &StarknetVerifier{checker: checker, chainID: chainID}
+}
+
+// ChainID returns the SNIP-12 chain ID signatures are verified for
+func (v *StarknetVerifier) ChainID() string {
+	return v.chainID
+}
+
+// Verify hashes the SNIP-12 ownership message for challenge and asks the
+// recipient account whether signature is valid for it. signature is a
+// comma-separated list of hex felts, usually r,s.
+func (v *StarknetVerifier) Verify(ctx context.Context, address, challenge, signature string) error {
+	if signature == "" {
+		return ErrMissingSignature
+	}
+
+	sig, err := ParseStarknetSignature(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	hash, err := StarknetMessageHash(address, v.chainID, challenge)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	result, err := v.checker.IsValidSignature(ctx, address, hash, sig)
+	if errors.Is(err, chains.ErrRejected) {
+		return fmt.Errorf("%w: %w", ErrAccountUnverifiable, err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check signature with account: %w", err)
+	}
+	if !isValidResult(result) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// StarknetTypedData builds the SNIP-12 (revision 1) typed data a recipient
+// signs to prove ownership
+func StarknetTypedData(chainID, challenge string) (*typeddata.TypedData, error) {
+	doc := map[string]any{
+		"types": map[string]any{
+			"StarknetDomain": []map[string]string{
+				{"name": "name", "type": "shortstring"},
+				{"name": "version", "type": "shortstring"},
+				{"name": "chainId", "type": "shortstring"},
+				{"name": "revision", "type": "shortstring"},
+			},
+			"FaucetRequest": []map[string]string{
+				{"name": "challenge", "type": "string"},
+			},
+		},
+		"primaryType": "FaucetRequest",
+		"domain": map[string]any{
+			"name":     "Faucet Terminal",
+			"version":  "1",
+			"chainId":  chainID,
+			"revision": "1",
+		},
+		"message": map[string]any{
+			"challenge": challenge,
+		},
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var td typeddata.TypedData
+	if err := json.Unmarshal(data, &td); err != nil {
+		return nil, fmt.Errorf("failed to build typed data: %w", err)
+	}
+	return &td, nil
+}
+
+// StarknetMessageHash returns the SNIP-12 message hash of the ownership
+// message for the account at address
+func StarknetMessageHash(address, chainID, challenge string) (*felt.Felt, error) {
+	td, err := StarknetTypedData(chainID, challenge)
+	if err != nil {
+		return nil, err
+	}
+	return td.GetMessageHash(address)
+}
+
+// SignStarknet signs the ownership message for the account at address with a
+// Stark private key, returning the signature in the form Verify expects
+func SignStarknet(privateKey *big.Int, address, chainID, challenge string) (string, error) {
+	hash, err := StarknetMessageHash(address, chainID, challenge)
+	if err != nil {
+		return "", err
+	}
+	r, s, err := curve.Sign(hash.BigInt(new(big.Int)), privateKey)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("0x%x,0x%x", r, s), nil
+}
+
+// ParseStarknetSignature parses a comma-separated list of hex felts
+func ParseStarknetSignature(signature string) ([]*felt.Felt, error) {
+	parts := strings.Split(signature, ",")
+	sig := make([]*felt.Felt, 0, len(parts))
+	for _, part := range parts {
+		f, err := utils.HexToFelt(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		sig = append(sig, f)
+	}
+	return sig, nil
+}
+
+// StubAccountChecker stands in for deployed accounts in tests and local
+// development: it checks r,s signatures against registered public keys, the
+// way an OpenZeppelin account's is_valid_signature does
+type StubAccountChecker struct {
+	keys map[string]*felt.Felt
+}
+
+// NewStubAccountChecker creates a checker with no registered accounts
+func NewStubAccountChecker() *StubAccountChecker {
+	return &StubAccountChecker{keys: make(map[string]*felt.Felt)}
+}
+
+// Register makes the account at address accept signatures by publicKey
+func (s *StubAccountChecker) Register(address string, publicKey *felt.Felt) error {
+	addr, err := utils.HexToFelt(address)
+	if err != nil {
+		return err
+	}
+	s.keys[addr.String()] = publicKey
+	return nil
+}
+
+// IsValidSignature implements AccountChecker
+func (s *StubAccountChecker) IsValidSignature(ctx context.Context, address string, hash *felt.Felt, signature []*felt.Felt) ([]*felt.Felt, error) {
+	addr, err := utils.HexToFelt(address)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, ok := s.keys[addr.String()]
+	if !ok {
+		return nil, chains.Rejected(fmt.Errorf("account %s is not deployed", address))
+	}
+	if len(signature) != 2 {
+		return []*felt.Felt{new(felt.Felt)}, nil
+	}
+	valid, err := curve.VerifyFelts(hash, signature[0], signature[1], publicKey)
+	if err != nil || !valid {
+		return []*felt.Felt{new(felt.Felt)}, err
+	}
+	return []*felt.Felt{validSignature}, nil
+}
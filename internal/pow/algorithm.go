@@ -0,0 +1,104 @@
+package pow
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Supported PoW hash algorithms
+const (
+	// AlgorithmSHA256 is the original puzzle: cheap per hash, so GPU farms have a large edge
+	AlgorithmSHA256 = "sha256"
+
+	// AlgorithmArgon2id is memory-hard; each hash needs Params.MemoryKiB of RAM
+	AlgorithmArgon2id = "argon2id"
+
+	// AlgorithmScrypt is memory-hard; each hash needs roughly 128 * N * R bytes of RAM
+	AlgorithmScrypt = "scrypt"
+)
+
+// hashLength is the output length of the memory-hard KDFs
+const hashLength = 32
+
+// MaxBits is the highest difficulty in leading zero bits: every bit of a 256-bit hash
+const MaxBits = 256
+
+// Hash computes the PoW hash of a challenge and nonce with the given algorithm.
+// An empty algorithm means SHA-256, for clients that predate algorithm selection.
+func Hash(algorithm string, params models.PoWParams, challenge string, nonce int64) ([]byte, error) {
+	data := []byte(fmt.Sprintf("%s%d", challenge, nonce))
+
+	switch algorithm {
+	case AlgorithmSHA256, "":
+		hash := sha256.Sum256(data)
+		return hash[:], nil
+	case AlgorithmArgon2id:
+		return argon2.IDKey(data, []byte(challenge), params.Time, params.MemoryKiB, params.Threads, hashLength), nil
+	case AlgorithmScrypt:
+		return scrypt.Key(data, []byte(challenge), params.N, params.R, params.P, hashLength)
+	default:
+		return nil, fmt.Errorf("unsupported PoW algorithm: %s", algorithm)
+	}
+}
+
+// ValidateParams checks that an algorithm is supported and its parameters are usable
+func ValidateParams(algorithm string, params models.PoWParams) error {
+	switch algorithm {
+	case AlgorithmSHA256, "":
+		return nil
+	case AlgorithmArgon2id:
+		if params.Time == 0 || params.MemoryKiB == 0 || params.Threads == 0 {
+			return fmt.Errorf("argon2id requires time, memory_kib and threads")
+		}
+		return nil
+	case AlgorithmScrypt:
+		if params.N < 2 || params.N&(params.N-1) != 0 {
+			return fmt.Errorf("scrypt n must be a power of two greater than 1")
+		}
+		if params.R <= 0 || params.P <= 0 {
+			return fmt.Errorf("scrypt requires positive r and p")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported PoW algorithm: %s", algorithm)
+	}
+}
+
+// MeetsDifficulty reports whether a hash has the given number of leading zero hex digits
+func MeetsDifficulty(hash []byte, difficulty int) bool {
+	return strings.HasPrefix(hex.EncodeToString(hash), strings.Repeat("0", difficulty))
+}
+
+// MeetsDifficultyBits reports whether a hash has at least the given number of
+// leading zero bits, i.e. whether it is below Target(bits) as a big-endian number
+func MeetsDifficultyBits(hash []byte, bits int) bool {
+	if bits > len(hash)*8 {
+		return false
+	}
+	for i := 0; i < bits/8; i++ {
+		if hash[i] != 0 {
+			return false
+		}
+	}
+	if rem := bits % 8; rem != 0 {
+		return hash[bits/8]>>(8-rem) == 0
+	}
+	return true
+}
+
+// Target returns the numeric target for a bit difficulty as a 64-digit hex
+// string: a 256-bit hash solves the puzzle when it is strictly below it
+func Target(bits int) (string, error) {
+	if bits < 1 || bits > MaxBits {
+		return "", fmt.Errorf("difficulty of %d bits is outside 1-%d", bits, MaxBits)
+	}
+	target := new(big.Int).Lsh(big.NewInt(1), uint(MaxBits-bits))
+	return fmt.Sprintf("%064x", target), nil
+}
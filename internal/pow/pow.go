@@ -1,44 +1,262 @@
 package pow
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
-	"os"
+	"runtime"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/Giri-Aayush/starknet-faucet/internal/models"
 )
 
-// TestModeNonce is the magic nonce that bypasses PoW in test mode
+// TestModeNonce is the nonce accepted without verification by a generator in test mode
 const TestModeNonce int64 = -1
 
+// Difficulty formats. Every difficulty the generator is configured with
+// (base, adaptive min and max) is counted in this unit.
+const (
+	// DifficultyFormatHex counts leading zero hex digits: each step is 16x harder
+	DifficultyFormatHex = "hex"
+
+	// DifficultyFormatBits counts leading zero bits: each step is 2x harder
+	DifficultyFormatBits = "bits"
+)
+
 // Challenge represents a PoW challenge
 type Challenge struct {
 	ID         string
 	Challenge  string
-	Difficulty int
+	Difficulty int // In the generator's difficulty format
+	Bits       int // Difficulty as leading zero bits, which is what verification checks
+	Algorithm  string
+	Params     models.PoWParams
 	CreatedAt  time.Time
 }
 
+// AdaptiveConfig controls how challenge difficulty follows load and client behavior
+type AdaptiveConfig struct {
+	MinDifficulty int
+	MaxDifficulty int
+
+	// HighLoadPerMinute raises difficulty by one when this many challenges
+	// have been issued in the current minute
+	HighLoadPerMinute int
+
+	// LowLoadPerMinute lowers difficulty by one while fewer challenges than this
+	// have been issued in the current minute
+	LowLoadPerMinute int
+
+	// PenaltyStep raises difficulty by one for every PenaltyStep recent invalid
+	// solutions or rejected requests from the same IP
+	PenaltyStep int
+}
+
+// Signals are the inputs used to pick the difficulty of a new challenge
+type Signals struct {
+	// ChallengesThisMinute is the number of challenges issued globally in the current minute
+	ChallengesThisMinute int
+
+	// IPPenalties is the number of recent invalid solutions or rejections from the client's IP
+	IPPenalties int
+}
+
 // Generator handles PoW challenge generation and verification
 type Generator struct {
+	levels    atomic.Pointer[difficultyLevels]
+	ttl       time.Duration
+	algorithm string
+	params    models.PoWParams
+	format    string
+	testMode  bool
+	secret    []byte // Signs stateless challenge IDs; nil when challenges are stored
+
+	// verifySlots bounds concurrent memory-hard verifications, each of which
+	// holds a full hash's worth of memory
+	verifySlots chan struct{}
+}
+
+// difficultyLevels are the base difficulty and, when adaptive, its bounds.
+// They are replaced together, so a challenge never sees a mix of old and new.
+type difficultyLevels struct {
 	difficulty int
-	ttl        time.Duration
+	adaptive   *AdaptiveConfig
 }
 
-// NewGenerator creates a new PoW generator
+// NewGenerator creates a new PoW generator using SHA-256
 func NewGenerator(difficulty int, ttlSeconds int) *Generator {
-	return &Generator{
-		difficulty: difficulty,
-		ttl:        time.Duration(ttlSeconds) * time.Second,
+	g := &Generator{
+		ttl:         time.Duration(ttlSeconds) * time.Second,
+		algorithm:   AlgorithmSHA256,
+		format:      DifficultyFormatHex,
+		verifySlots: make(chan struct{}, runtime.GOMAXPROCS(0)),
+	}
+	// Not checked yet: the difficulty may be in a format set afterwards
+	g.levels.Store(newLevels(difficulty, nil))
+	return g
+}
+
+// SetDifficulty changes the base difficulty and the adaptive settings; nil
+// turns adaptive difficulty off. It is safe to call while challenges are
+// issued. Difficulties outside 1-MaxDifficulty(format) are refused.
+func (g *Generator) SetDifficulty(difficulty int, adaptive *AdaptiveConfig) error {
+	levels := newLevels(difficulty, adaptive)
+	if err := checkLevels(g.format, levels); err != nil {
+		return err
+	}
+	g.levels.Store(levels)
+	return nil
+}
+
+// newLevels fills in the adaptive bounds around the base difficulty
+func newLevels(difficulty int, adaptive *AdaptiveConfig) *difficultyLevels {
+	levels := &difficultyLevels{difficulty: difficulty}
+	if adaptive != nil {
+		cfg := *adaptive
+		if cfg.MinDifficulty <= 0 || cfg.MinDifficulty > difficulty {
+			cfg.MinDifficulty = difficulty
+		}
+		if cfg.MaxDifficulty < difficulty {
+			cfg.MaxDifficulty = difficulty
+		}
+		levels.adaptive = &cfg
+	}
+	return levels
+}
+
+// checkLevels reports difficulties that cannot be issued in a format
+func checkLevels(format string, levels *difficultyLevels) error {
+	difficulties := []int{levels.difficulty}
+	if levels.adaptive != nil {
+		difficulties = append(difficulties, levels.adaptive.MinDifficulty, levels.adaptive.MaxDifficulty)
+	}
+	for _, difficulty := range difficulties {
+		if difficulty < 1 || difficulty > MaxDifficulty(format) {
+			return fmt.Errorf("difficulty %d is outside 1-%d in the %s format", difficulty, MaxDifficulty(format), format)
+		}
+	}
+	return nil
+}
+
+// MaxDifficulty returns the highest difficulty that can be issued in a format
+func MaxDifficulty(format string) int {
+	if format == DifficultyFormatBits {
+		return MaxBits
+	}
+	return MaxBits / 4
+}
+
+// EnableTestMode makes the generator accept TestModeNonce for any challenge.
+// It is for in-process unit tests only and cannot be turned on by configuration;
+// deployed servers use signed test tokens instead.
+func (g *Generator) EnableTestMode() {
+	g.testMode = true
+}
+
+// SetDifficultyFormat sets the unit the generator's difficulties are counted in
+func (g *Generator) SetDifficultyFormat(format string) error {
+	switch format {
+	case DifficultyFormatHex, DifficultyFormatBits:
+		if err := checkLevels(format, g.levels.Load()); err != nil {
+			return err
+		}
+		g.format = format
+		return nil
+	default:
+		return fmt.Errorf("unsupported difficulty format: %s", format)
+	}
+}
+
+// DifficultyFormat returns the unit the generator's difficulties are counted in
+func (g *Generator) DifficultyFormat() string {
+	return g.format
+}
+
+// Bits converts a difficulty in the generator's format to leading zero bits
+func (g *Generator) Bits(difficulty int) int {
+	if g.format == DifficultyFormatBits {
+		return difficulty
+	}
+	return difficulty * 4
+}
+
+// SetAlgorithm switches the hash algorithm new challenges are issued with
+func (g *Generator) SetAlgorithm(algorithm string, params models.PoWParams) error {
+	if err := ValidateParams(algorithm, params); err != nil {
+		return err
+	}
+	g.algorithm = algorithm
+	g.params = params
+	return nil
+}
+
+// Algorithm returns the hash algorithm new challenges are issued with
+func (g *Generator) Algorithm() string {
+	return g.algorithm
+}
+
+// NewAdaptiveGenerator creates a PoW generator whose difficulty is chosen per
+// challenge, starting from the base difficulty and bounded by cfg
+func NewAdaptiveGenerator(difficulty int, ttlSeconds int, cfg AdaptiveConfig) *Generator {
+	g := NewGenerator(difficulty, ttlSeconds)
+	g.levels.Store(newLevels(difficulty, &cfg))
+	return g
+}
+
+// Difficulty returns the base difficulty
+func (g *Generator) Difficulty() int {
+	return g.levels.Load().difficulty
+}
+
+// DifficultyRange returns the lowest and highest difficulty a challenge may be issued at
+func (g *Generator) DifficultyRange() (int, int) {
+	levels := g.levels.Load()
+	if levels.adaptive == nil {
+		return levels.difficulty, levels.difficulty
+	}
+	return levels.adaptive.MinDifficulty, levels.adaptive.MaxDifficulty
+}
+
+// DifficultyFor picks the difficulty for a new challenge given current signals.
+// Without adaptive config the base difficulty is always returned.
+func (g *Generator) DifficultyFor(signals Signals) int {
+	levels := g.levels.Load()
+	if levels.adaptive == nil {
+		return levels.difficulty
+	}
+	cfg := levels.adaptive
+
+	difficulty := levels.difficulty
+	if cfg.HighLoadPerMinute > 0 && signals.ChallengesThisMinute >= cfg.HighLoadPerMinute {
+		difficulty++
+	} else if cfg.LowLoadPerMinute > 0 && signals.ChallengesThisMinute < cfg.LowLoadPerMinute {
+		difficulty--
+	}
+
+	if cfg.PenaltyStep > 0 {
+		difficulty += signals.IPPenalties / cfg.PenaltyStep
+	}
+
+	if difficulty < cfg.MinDifficulty {
+		difficulty = cfg.MinDifficulty
 	}
+	if difficulty > cfg.MaxDifficulty {
+		difficulty = cfg.MaxDifficulty
+	}
+	return difficulty
 }
 
-// GenerateChallenge creates a new PoW challenge
+// GenerateChallenge creates a new PoW challenge at the base difficulty
 func (g *Generator) GenerateChallenge() (*models.ChallengeResponse, *Challenge, error) {
+	return g.GenerateChallengeWithDifficulty(g.Difficulty())
+}
+
+// GenerateChallengeWithDifficulty creates a new PoW challenge at the given difficulty
+func (g *Generator) GenerateChallengeWithDifficulty(difficulty int) (*models.ChallengeResponse, *Challenge, error) {
 	// Generate random challenge string
 	challengeBytes := make([]byte, 32)
 	if _, err := rand.Read(challengeBytes); err != nil {
@@ -54,39 +272,80 @@ func (g *Generator) GenerateChallenge() (*models.ChallengeResponse, *Challenge,
 	challenge := &Challenge{
 		ID:         hex.EncodeToString(idBytes),
 		Challenge:  hex.EncodeToString(challengeBytes),
-		Difficulty: g.difficulty,
+		Difficulty: difficulty,
+		Bits:       g.Bits(difficulty),
+		Algorithm:  g.algorithm,
+		Params:     g.params,
 		CreatedAt:  time.Now(),
 	}
 
+	target, err := Target(challenge.Bits)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Clients that predate bit-level difficulty only read Difficulty as leading hex
+	// zeros, so it is rounded up: any such solution also has enough zero bits.
 	response := &models.ChallengeResponse{
-		ChallengeID: challenge.ID,
-		Challenge:   challenge.Challenge,
-		Difficulty:  challenge.Difficulty,
+		ChallengeID:      challenge.ID,
+		Challenge:        challenge.Challenge,
+		Difficulty:       (challenge.Bits + 3) / 4,
+		DifficultyBits:   challenge.Bits,
+		DifficultyFormat: g.format,
+		Target:           target,
+		Algorithm:        challenge.Algorithm,
+	}
+	if challenge.Algorithm != AlgorithmSHA256 {
+		params := challenge.Params
+		response.Params = &params
 	}
 
 	return response, challenge, nil
 }
 
-// VerifyPoW verifies a PoW solution
+// VerifyPoW verifies a PoW solution using the generator's current algorithm,
+// with difficulty in the generator's format
 func (g *Generator) VerifyPoW(challenge string, nonce int64, difficulty int) bool {
-	// Test mode bypass: if FAUCET_TEST_MODE=1 and nonce is -1, skip verification
-	if nonce == TestModeNonce && os.Getenv("FAUCET_TEST_MODE") == "1" {
+	return g.VerifyPoWWith(g.algorithm, g.params, challenge, nonce, g.Bits(difficulty))
+}
+
+// VerifyPoWWith verifies a PoW solution against the algorithm, parameters and
+// difficulty (in leading zero bits) the challenge was issued with. The bits
+// come from the stored or sealed challenge, so they are checked only against
+// what a hash can hold, not the current difficulty range: a reload must not
+// invalidate challenges already issued.
+func (g *Generator) VerifyPoWWith(algorithm string, params models.PoWParams, challenge string, nonce int64, bits int) bool {
+	// In-process test mode bypass
+	if g.testMode && nonce == TestModeNonce {
 		return true
 	}
 
-	// Ensure difficulty matches
-	if difficulty != g.difficulty {
+	if bits < 1 || bits > MaxBits {
 		return false
 	}
 
-	// Compute hash
-	data := fmt.Sprintf("%s%d", challenge, nonce)
-	hash := sha256.Sum256([]byte(data))
-	hashHex := hex.EncodeToString(hash[:])
+	// Compute hash and check leading zero bits
+	hash, err := Hash(algorithm, params, challenge, nonce)
+	if err != nil {
+		return false
+	}
+	return MeetsDifficultyBits(hash, bits)
+}
 
-	// Check leading zeros
-	prefix := strings.Repeat("0", difficulty)
-	return strings.HasPrefix(hashHex, prefix)
+// VerifyPoWContext verifies a solution like VerifyPoWWith. Memory-hard
+// algorithms first wait for a verification slot, so a burst of submissions
+// cannot exhaust the server's memory; ctx's error is returned if it is
+// cancelled while waiting.
+func (g *Generator) VerifyPoWContext(ctx context.Context, algorithm string, params models.PoWParams, challenge string, nonce int64, bits int) (bool, error) {
+	if algorithm == AlgorithmArgon2id || algorithm == AlgorithmScrypt {
+		select {
+		case g.verifySlots <- struct{}{}:
+			defer func() { <-g.verifySlots }()
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+	}
+	return g.VerifyPoWWith(algorithm, params, challenge, nonce, bits), nil
 }
 
 // IsExpired checks if a challenge has expired
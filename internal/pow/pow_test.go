@@ -1,9 +1,11 @@
 package pow
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -15,7 +17,7 @@ func TestNewGenerator(t *testing.T) {
 	gen := NewGenerator(difficulty, ttl)
 
 	assert.NotNil(t, gen)
-	assert.Equal(t, difficulty, gen.difficulty)
+	assert.Equal(t, difficulty, gen.Difficulty())
 	assert.Equal(t, time.Duration(ttl)*time.Second, gen.ttl)
 }
 
@@ -87,6 +89,52 @@ func TestVerifyPoW(t *testing.T) {
 	}
 }
 
+func TestDifficultyFor(t *testing.T) {
+	gen := NewAdaptiveGenerator(4, 300, AdaptiveConfig{
+		MinDifficulty:     3,
+		MaxDifficulty:     6,
+		HighLoadPerMinute: 100,
+		LowLoadPerMinute:  10,
+		PenaltyStep:       3,
+	})
+
+	tests := []struct {
+		name    string
+		signals Signals
+		want    int
+	}{
+		{"normal load", Signals{ChallengesThisMinute: 50}, 4},
+		{"high load", Signals{ChallengesThisMinute: 150}, 5},
+		{"quiet", Signals{ChallengesThisMinute: 2}, 3},
+		{"penalized IP", Signals{ChallengesThisMinute: 50, IPPenalties: 3}, 5},
+		{"quiet but penalized", Signals{ChallengesThisMinute: 2, IPPenalties: 6}, 5},
+		{"capped at max", Signals{ChallengesThisMinute: 150, IPPenalties: 30}, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, gen.DifficultyFor(tt.signals))
+		})
+	}
+}
+
+func TestDifficultyForNonAdaptive(t *testing.T) {
+	gen := NewGenerator(4, 300)
+
+	assert.Equal(t, 4, gen.DifficultyFor(Signals{ChallengesThisMinute: 1000, IPPenalties: 100}))
+}
+
+func TestVerifyPoWAfterReload(t *testing.T) {
+	gen := NewAdaptiveGenerator(2, 300, AdaptiveConfig{MinDifficulty: 1, MaxDifficulty: 3})
+	_, challenge, err := gen.GenerateChallengeWithDifficulty(1)
+	require.NoError(t, err)
+	nonce := findValidNonce(challenge.Challenge, 1)
+
+	// A challenge issued before the range was raised is still honoured
+	require.NoError(t, gen.SetDifficulty(5, &AdaptiveConfig{MinDifficulty: 4, MaxDifficulty: 6}))
+	assert.True(t, gen.VerifyPoWWith(AlgorithmSHA256, models.PoWParams{}, challenge.Challenge, nonce, challenge.Bits))
+}
+
 func TestIsExpired(t *testing.T) {
 	gen := NewGenerator(4, 1) // 1 second TTL
 
@@ -206,3 +254,193 @@ func BenchmarkSolveChallenge(b *testing.B) {
 		SolveChallenge(challenge, difficulty, nil)
 	}
 }
+
+func TestVerifyPoWMemoryHard(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm string
+		params    models.PoWParams
+	}{
+		{"argon2id", AlgorithmArgon2id, models.PoWParams{Time: 1, MemoryKiB: 64, Threads: 1}},
+		{"scrypt", AlgorithmScrypt, models.PoWParams{N: 16, R: 1, P: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := NewGenerator(1, 300)
+			require.NoError(t, gen.SetAlgorithm(tt.algorithm, tt.params))
+
+			resp, challenge, err := gen.GenerateChallengeWithDifficulty(1)
+			assert.Equal(t, 4, challenge.Bits)
+			require.NoError(t, err)
+			assert.Equal(t, tt.algorithm, resp.Algorithm)
+			require.NotNil(t, resp.Params)
+
+			var nonce int64
+			for ; nonce < 10000; nonce++ {
+				hash, err := Hash(tt.algorithm, tt.params, challenge.Challenge, nonce)
+				require.NoError(t, err)
+				if MeetsDifficulty(hash, 1) {
+					break
+				}
+			}
+
+			assert.True(t, gen.VerifyPoWWith(tt.algorithm, tt.params, challenge.Challenge, nonce, challenge.Bits))
+
+			// The memory-hard hash must not collapse to the SHA-256 one
+			sha, err := Hash(AlgorithmSHA256, models.PoWParams{}, challenge.Challenge, nonce)
+			require.NoError(t, err)
+			hash, err := Hash(tt.algorithm, tt.params, challenge.Challenge, nonce)
+			require.NoError(t, err)
+			assert.NotEqual(t, sha, hash)
+		})
+	}
+}
+
+func TestVerifyPoWContextBoundsMemoryHard(t *testing.T) {
+	gen := NewGenerator(1, 300)
+	params := models.PoWParams{Time: 1, MemoryKiB: 64, Threads: 1}
+	for i := 0; i < cap(gen.verifySlots); i++ {
+		gen.verifySlots <- struct{}{}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := gen.VerifyPoWContext(ctx, AlgorithmArgon2id, params, "seed", 0, 4)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "waits for a free slot")
+
+	_, err = gen.VerifyPoWContext(ctx, AlgorithmSHA256, models.PoWParams{}, "seed", 0, 4)
+	assert.NoError(t, err, "SHA-256 is not limited")
+
+	<-gen.verifySlots
+	_, err = gen.VerifyPoWContext(context.Background(), AlgorithmArgon2id, params, "seed", 0, 4)
+	assert.NoError(t, err)
+}
+
+func TestSetAlgorithmRejectsBadParams(t *testing.T) {
+	gen := NewGenerator(4, 300)
+	assert.Error(t, gen.SetAlgorithm("md5", models.PoWParams{}))
+	assert.Error(t, gen.SetAlgorithm(AlgorithmScrypt, models.PoWParams{N: 1000, R: 8, P: 1}))
+	assert.Error(t, gen.SetAlgorithm(AlgorithmArgon2id, models.PoWParams{}))
+}
+
+func TestMeetsDifficultyBits(t *testing.T) {
+	tests := []struct {
+		hash []byte
+		bits int
+		want bool
+	}{
+		{[]byte{0x00, 0x00, 0xff}, 16, true},
+		{[]byte{0x00, 0x00, 0xff}, 17, false},
+		{[]byte{0x00, 0x1f, 0xff}, 11, true},
+		{[]byte{0x00, 0x1f, 0xff}, 12, false},
+		{[]byte{0x80}, 0, true},
+		{[]byte{0x00}, 9, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, MeetsDifficultyBits(tt.hash, tt.bits), "%x with %d bits", tt.hash, tt.bits)
+	}
+}
+
+func TestTarget(t *testing.T) {
+	target := func(bits int) string {
+		target, err := Target(bits)
+		require.NoError(t, err)
+		return target
+	}
+	assert.Equal(t, "0000100000000000000000000000000000000000000000000000000000000000", target(20))
+	assert.Equal(t, "0000200000000000000000000000000000000000000000000000000000000000", target(19))
+	assert.Len(t, target(1), 64)
+	assert.Equal(t, "0000000000000000000000000000000000000000000000000000000000000001", target(256))
+
+	for _, bits := range []int{0, -1, 257, 260} {
+		_, err := Target(bits)
+		assert.Error(t, err, "%d bits", bits)
+	}
+}
+
+func TestSetDifficultyRange(t *testing.T) {
+	gen := NewGenerator(4, 300)
+	assert.NoError(t, gen.SetDifficulty(64, nil))
+	assert.Error(t, gen.SetDifficulty(65, nil), "65 hex digits is more than a 256-bit hash")
+	assert.Error(t, gen.SetDifficulty(0, nil))
+	assert.Error(t, gen.SetDifficulty(4, &AdaptiveConfig{MaxDifficulty: 100}))
+	assert.Equal(t, 64, gen.Difficulty(), "a refused difficulty is not applied")
+
+	require.NoError(t, gen.SetDifficultyFormat(DifficultyFormatBits))
+	assert.NoError(t, gen.SetDifficulty(256, nil))
+	assert.Error(t, gen.SetDifficultyFormat(DifficultyFormatHex), "256 is out of range in hex")
+}
+
+func TestBitDifficultyFormat(t *testing.T) {
+	gen := NewAdaptiveGenerator(10, 300, AdaptiveConfig{MinDifficulty: 9, MaxDifficulty: 12})
+	require.NoError(t, gen.SetDifficultyFormat(DifficultyFormatBits))
+	assert.Error(t, gen.SetDifficultyFormat("decimal"))
+
+	resp, challenge, err := gen.GenerateChallengeWithDifficulty(10)
+	require.NoError(t, err)
+	assert.Equal(t, 10, challenge.Bits)
+	assert.Equal(t, 10, resp.DifficultyBits)
+	assert.Equal(t, DifficultyFormatBits, resp.DifficultyFormat)
+	// Older clients solve for whole hex digits, rounded up
+	assert.Equal(t, 3, resp.Difficulty)
+
+	// A solution found by an older client (3 hex zeros = 12 bits) is accepted
+	nonce := findValidNonce(challenge.Challenge, resp.Difficulty)
+	assert.True(t, gen.VerifyPoWWith(AlgorithmSHA256, models.PoWParams{}, challenge.Challenge, nonce, challenge.Bits))
+
+	// Bit difficulties no hash can meet, or that need no work, are rejected
+	assert.False(t, gen.VerifyPoWWith(AlgorithmSHA256, models.PoWParams{}, challenge.Challenge, nonce, 0))
+	assert.False(t, gen.VerifyPoWWith(AlgorithmSHA256, models.PoWParams{}, challenge.Challenge, nonce, MaxBits+1))
+}
+
+func TestTestModeNonce(t *testing.T) {
+	gen := NewGenerator(4, 300)
+	t.Setenv("FAUCET_TEST_MODE", "1")
+
+	// The environment no longer disables verification
+	assert.False(t, gen.VerifyPoW("test123", TestModeNonce, 4))
+
+	gen.EnableTestMode()
+	assert.True(t, gen.VerifyPoW("test123", TestModeNonce, 4))
+}
+
+func TestStatelessEnvelope(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	gen := NewGenerator(4, 300)
+	assert.Error(t, gen.EnableStateless([]byte("short")))
+	require.NoError(t, gen.EnableStateless(secret))
+	assert.True(t, gen.Stateless())
+
+	envelope := Envelope{
+		Seed:      "abc123",
+		Bits:      16,
+		Algorithm: AlgorithmSHA256,
+		Address:   "0x1",
+		Network:   "starknet",
+		Token:     "STRK",
+		ExpiresAt: time.Now().Add(time.Minute).Unix(),
+	}
+	id, err := gen.Seal(envelope)
+	require.NoError(t, err)
+
+	// Another instance with the same secret can open it
+	other := NewGenerator(4, 300)
+	require.NoError(t, other.EnableStateless(secret))
+	opened, err := other.Open(id, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, envelope, *opened)
+
+	// Tampering, a different secret, or expiry are rejected
+	_, err = gen.Open("x"+id, time.Now())
+	assert.ErrorIs(t, err, ErrInvalidEnvelope)
+
+	stranger := NewGenerator(4, 300)
+	require.NoError(t, stranger.EnableStateless([]byte("ffffffffffffffffffffffffffffffff")))
+	_, err = stranger.Open(id, time.Now())
+	assert.ErrorIs(t, err, ErrInvalidEnvelope)
+
+	_, err = gen.Open(id, time.Now().Add(2*time.Minute))
+	assert.ErrorIs(t, err, ErrEnvelopeExpired)
+}
@@ -0,0 +1,81 @@
+package pow
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/Giri-Aayush/starknet-faucet/internal/signed"
+)
+
+// MinChallengeSecretLength is the shortest accepted secret for stateless challenges, in bytes
+const MinChallengeSecretLength = 32
+
+var (
+	// ErrInvalidEnvelope is returned for challenge IDs that are malformed or not signed by this server
+	ErrInvalidEnvelope = errors.New("invalid challenge")
+
+	// ErrEnvelopeExpired is returned for challenge IDs past their expiry
+	ErrEnvelopeExpired = errors.New("challenge expired")
+)
+
+// Envelope is the signed content of a stateless challenge ID. It carries
+// everything needed to verify a solution, so the challenge need not be stored.
+type Envelope struct {
+	Seed       string           `json:"s"`
+	Difficulty int              `json:"d"`
+	Bits       int              `json:"b"`
+	Algorithm  string           `json:"a"`
+	Params     models.PoWParams `json:"p"`
+	Address    string           `json:"addr"`
+	Network    string           `json:"net"`
+	Token      string           `json:"tok"`
+	ExpiresAt  int64            `json:"exp"`
+}
+
+// EnableStateless makes the generator issue challenges whose ID is an
+// HMAC-signed Envelope. Every instance sharing the secret can verify them.
+func (g *Generator) EnableStateless(secret []byte) error {
+	if len(secret) < MinChallengeSecretLength {
+		return fmt.Errorf("challenge secret must be at least %d bytes", MinChallengeSecretLength)
+	}
+	g.secret = secret
+	return nil
+}
+
+// Stateless reports whether challenges are issued as signed envelopes
+func (g *Generator) Stateless() bool {
+	return g.secret != nil
+}
+
+// Seal signs an envelope and returns it encoded as a challenge ID
+func (g *Generator) Seal(envelope Envelope) (string, error) {
+	if !g.Stateless() {
+		return "", fmt.Errorf("stateless challenges are not enabled")
+	}
+
+	challengeID, err := signed.Seal(g.secret, envelope)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode challenge: %w", err)
+	}
+	return challengeID, nil
+}
+
+// Open checks a challenge ID's signature and expiry and returns its envelope
+func (g *Generator) Open(challengeID string, now time.Time) (*Envelope, error) {
+	if !g.Stateless() {
+		return nil, fmt.Errorf("stateless challenges are not enabled")
+	}
+
+	var envelope Envelope
+	if err := signed.Open(g.secret, challengeID, &envelope); err != nil {
+		return nil, ErrInvalidEnvelope
+	}
+
+	if now.Unix() >= envelope.ExpiresAt {
+		return nil, ErrEnvelopeExpired
+	}
+
+	return &envelope, nil
+}
@@ -0,0 +1,43 @@
+package signed
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type value struct {
+	Name string `json:"name"`
+	N    int    `json:"n"`
+}
+
+func TestSealOpen(t *testing.T) {
+	key := []byte(strings.Repeat("k", 32))
+	token, err := Seal(key, value{Name: "faucet", N: 3})
+	require.NoError(t, err)
+
+	var got value
+	require.NoError(t, Open(key, token, &got))
+	assert.Equal(t, value{Name: "faucet", N: 3}, got)
+
+	encoded, signature, _ := strings.Cut(token, ".")
+	forged, err := Seal([]byte(strings.Repeat("x", 32)), value{Name: "faucet", N: 3})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{"no signature", encoded, ErrMalformed},
+		{"other key", forged, ErrBadSignature},
+		{"tampered payload", encoded + "x." + signature, ErrBadSignature},
+		{"signed garbage", "!!." + sign(key, "!!"), ErrMalformed},
+		{"signed non-JSON", "bm90IGpzb24." + sign(key, "bm90IGpzb24"), ErrMalformed},
+	}
+	for _, tt := range tests {
+		assert.ErrorIs(t, Open(key, tt.token, &got), tt.err, tt.name)
+	}
+}
@@ -0,0 +1,105 @@
+// Package testtoken issues and verifies short-lived test credentials.
+//
+// A test token lets an automated test request tokens without solving a PoW
+// challenge. Tokens are HMAC-SHA256 signed with a server secret, scoped to a
+// single network and valid for at most MaxTTL, so a leaked token is of little
+// use and there is no switch that disables PoW for everyone.
+package testtoken
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/signed"
+)
+
+// MaxTTL is the longest lifetime a test token may have
+const MaxTTL = time.Hour
+
+// MinSecretLength is the shortest accepted signing secret, in bytes
+const MinSecretLength = 32
+
+var (
+	// ErrDisabled is returned when no signing secret is configured
+	ErrDisabled = errors.New("test tokens are not enabled")
+
+	// ErrMalformed is returned for tokens that cannot be decoded
+	ErrMalformed = errors.New("malformed test token")
+
+	// ErrBadSignature is returned when the token was not signed with the server secret
+	ErrBadSignature = errors.New("invalid test token signature")
+
+	// ErrExpired is returned for tokens past their expiry, or issued with too long a lifetime
+	ErrExpired = errors.New("test token expired")
+
+	// ErrWrongNetwork is returned when the token is scoped to another network
+	ErrWrongNetwork = errors.New("test token not valid for this network")
+)
+
+// Claims are the signed contents of a test token
+type Claims struct {
+	ID        string `json:"id"`
+	Network   string `json:"network"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Issue creates a test token for network valid for ttl (at most MaxTTL)
+func Issue(secret []byte, network string, ttl time.Duration) (string, *Claims, error) {
+	if len(secret) < MinSecretLength {
+		return "", nil, fmt.Errorf("test token secret must be at least %d bytes", MinSecretLength)
+	}
+	if network == "" {
+		return "", nil, fmt.Errorf("network is required")
+	}
+	if ttl <= 0 || ttl > MaxTTL {
+		return "", nil, fmt.Errorf("ttl must be between 0 and %s", MaxTTL)
+	}
+
+	idBytes := make([]byte, 8)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", nil, fmt.Errorf("failed to generate token ID: %w", err)
+	}
+
+	now := time.Now()
+	claims := &Claims{
+		ID:        hex.EncodeToString(idBytes),
+		Network:   network,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+	}
+
+	token, err := signed.Seal(secret, claims)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to encode claims: %w", err)
+	}
+	return token, claims, nil
+}
+
+// Verify checks a test token's signature, expiry and network scope
+func Verify(secret []byte, token string, network string, now time.Time) (*Claims, error) {
+	if len(secret) < MinSecretLength {
+		return nil, ErrDisabled
+	}
+
+	var claims Claims
+	if err := signed.Open(secret, token, &claims); err != nil {
+		if errors.Is(err, signed.ErrBadSignature) {
+			return nil, ErrBadSignature
+		}
+		return nil, ErrMalformed
+	}
+
+	if now.Unix() >= claims.ExpiresAt || claims.ExpiresAt-claims.IssuedAt > int64(MaxTTL/time.Second) {
+		return nil, ErrExpired
+	}
+
+	if claims.Network != network {
+		return nil, ErrWrongNetwork
+	}
+
+	return &claims, nil
+}
@@ -0,0 +1,61 @@
+package testtoken
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var secret = []byte("0123456789abcdef0123456789abcdef")
+
+func TestIssueAndVerify(t *testing.T) {
+	token, issued, err := Issue(secret, "starknet", 10*time.Minute)
+	require.NoError(t, err)
+
+	claims, err := Verify(secret, token, "starknet", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, issued.ID, claims.ID)
+	assert.Equal(t, "starknet", claims.Network)
+}
+
+func TestVerifyRejects(t *testing.T) {
+	token, _, err := Issue(secret, "starknet", 10*time.Minute)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		secret  []byte
+		token   string
+		network string
+		now     time.Time
+		want    error
+	}{
+		{"wrong network", secret, token, "ethereum", time.Now(), ErrWrongNetwork},
+		{"expired", secret, token, "starknet", time.Now().Add(11 * time.Minute), ErrExpired},
+		{"wrong secret", []byte("ffffffffffffffffffffffffffffffff"), token, "starknet", time.Now(), ErrBadSignature},
+		{"tampered", secret, "e30" + token, "starknet", time.Now(), ErrBadSignature},
+		{"disabled", nil, token, "starknet", time.Now(), ErrDisabled},
+		{"malformed", secret, strings.ReplaceAll(token, ".", ""), "starknet", time.Now(), ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Verify(tt.secret, tt.token, tt.network, tt.now)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestIssueValidation(t *testing.T) {
+	_, _, err := Issue([]byte("short"), "starknet", time.Minute)
+	assert.Error(t, err)
+
+	_, _, err = Issue(secret, "starknet", 2*MaxTTL)
+	assert.Error(t, err)
+
+	_, _, err = Issue(secret, "", time.Minute)
+	assert.Error(t, err)
+}
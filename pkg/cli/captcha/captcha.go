@@ -0,0 +1,70 @@
+// Package captcha asks a simple question in the terminal before a request.
+//
+// This check runs only on the client and is a speed bump for casual scripting,
+// not a security control: anyone calling the API directly skips it. When the
+// faucet requires human verification it is enforced server-side, and the CLI
+// forwards a provider token given with --captcha-token.
+package captcha
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// question is a single arithmetic question and its answer
+type question struct {
+	text   string
+	answer int
+}
+
+// newQuestion returns a random addition, subtraction or multiplication question
+func newQuestion() question {
+	a := rand.Intn(9) + 1
+	b := rand.Intn(9) + 1
+
+	switch rand.Intn(3) {
+	case 0:
+		return question{fmt.Sprintf("What is %d + %d?", a, b), a + b}
+	case 1:
+		if a < b {
+			a, b = b, a
+		}
+		return question{fmt.Sprintf("What is %d - %d?", a, b), a - b}
+	default:
+		return question{fmt.Sprintf("What is %d × %d?", a, b), a * b}
+	}
+}
+
+// AskQuestionWithRetries asks random questions until one is answered correctly
+// or attempts run out. Returns false if every attempt was wrong.
+func AskQuestionWithRetries(attempts int) (bool, error) {
+	reader := bufio.NewReader(os.Stdin)
+	dim := color.New(color.Faint).SprintFunc()
+
+	for i := 1; i <= attempts; i++ {
+		q := newQuestion()
+		fmt.Printf("  %s %s ", color.CyanString("?"), q.text)
+
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return false, fmt.Errorf("failed to read answer: %w", err)
+		}
+
+		answer, err := strconv.Atoi(strings.TrimSpace(line))
+		if err == nil && answer == q.answer {
+			return true, nil
+		}
+
+		if i < attempts {
+			fmt.Printf("  %s\n", dim(fmt.Sprintf("Incorrect, try again (%d attempts left)", attempts-i)))
+		}
+	}
+
+	return false, nil
+}
@@ -1,7 +1,12 @@
 package cli
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -14,20 +19,25 @@ type APIClient struct {
 	client  *resty.Client
 }
 
-// NewAPIClient creates a new API client
+// NewAPIClient creates a new API client. If the user has signed in to this
+// faucet, requests carry the session so quotas follow their account.
 func NewAPIClient(baseURL string) *APIClient {
 	client := resty.New()
 	client.SetTimeout(5 * time.Minute) // Long timeout for transaction waiting
 	client.SetHeader("Content-Type", "application/json")
 
+	if session, err := LoadSession(baseURL); err == nil && session != nil {
+		client.SetAuthToken(session.Token)
+	}
+
 	return &APIClient{
 		baseURL: baseURL,
 		client:  client,
 	}
 }
 
-// GetChallenge fetches a new PoW challenge with retry on server wake-up
-func (c *APIClient) GetChallenge() (*models.ChallengeResponse, error) {
+// GetChallenge fetches a new PoW challenge bound to the given request, with retry on server wake-up
+func (c *APIClient) GetChallenge(req models.ChallengeRequest) (*models.ChallengeResponse, error) {
 	var response models.ChallengeResponse
 	var errResponse models.ErrorResponse
 
@@ -36,6 +46,7 @@ func (c *APIClient) GetChallenge() (*models.ChallengeResponse, error) {
 
 	for attempt := 1; attempt <= maxRetries; attempt++ {
 		resp, err := c.client.R().
+			SetBody(req).
 			SetResult(&response).
 			SetError(&errResponse).
 			Post(fmt.Sprintf("%s/api/v1/challenge", c.baseURL))
@@ -44,8 +55,8 @@ func (c *APIClient) GetChallenge() (*models.ChallengeResponse, error) {
 			return nil, fmt.Errorf("failed to get challenge: %w", err)
 		}
 
-		// Check if server is waking up (502/503)
-		if resp.StatusCode() == 502 || resp.StatusCode() == 503 {
+		// Check if server is waking up (502/503 from the host, not the faucet itself)
+		if (resp.StatusCode() == 502 || resp.StatusCode() == 503) && errResponse.Code == "" {
 			if attempt < maxRetries {
 				fmt.Printf("\n⏳ Server is waking up... (attempt %d/%d, waiting %ds)\n", attempt, maxRetries, int(retryDelay.Seconds()))
 				time.Sleep(retryDelay)
@@ -55,10 +66,7 @@ func (c *APIClient) GetChallenge() (*models.ChallengeResponse, error) {
 		}
 
 		if resp.IsError() {
-			if errResponse.Error != "" {
-				return nil, fmt.Errorf("API error: %s", errResponse.Error)
-			}
-			return nil, fmt.Errorf("API returned status %d", resp.StatusCode())
+			return nil, apiError(resp, errResponse)
 		}
 
 		return &response, nil
@@ -83,14 +91,7 @@ func (c *APIClient) RequestTokens(req models.FaucetRequest) (*models.FaucetRespo
 	}
 
 	if resp.IsError() {
-		if errResponse.Error != "" {
-			msg := errResponse.Error
-			if errResponse.RemainingHours != nil {
-				msg = fmt.Sprintf("%s (%.1f hours remaining)", msg, *errResponse.RemainingHours)
-			}
-			return nil, fmt.Errorf("API error: %s", msg)
-		}
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode())
+		return nil, apiError(resp, errResponse)
 	}
 
 	return &response, nil
@@ -111,10 +112,7 @@ func (c *APIClient) GetStatus(address string) (*models.StatusResponse, error) {
 	}
 
 	if resp.IsError() {
-		if errResponse.Error != "" {
-			return nil, fmt.Errorf("API error: %s", errResponse.Error)
-		}
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode())
+		return nil, apiError(resp, errResponse)
 	}
 
 	return &response, nil
@@ -135,10 +133,51 @@ func (c *APIClient) GetInfo() (*models.InfoResponse, error) {
 	}
 
 	if resp.IsError() {
-		if errResponse.Error != "" {
-			return nil, fmt.Errorf("API error: %s", errResponse.Error)
-		}
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode())
+		return nil, apiError(resp, errResponse)
+	}
+
+	return &response, nil
+}
+
+// StartLogin begins a GitHub device flow sign-in
+func (c *APIClient) StartLogin() (*models.DeviceLoginResponse, error) {
+	var response models.DeviceLoginResponse
+	var errResponse models.ErrorResponse
+
+	resp, err := c.client.R().
+		SetResult(&response).
+		SetError(&errResponse).
+		Post(fmt.Sprintf("%s/api/v1/auth/github/device", c.baseURL))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to start sign-in: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, apiError(resp, errResponse)
+	}
+
+	return &response, nil
+}
+
+// PollLogin checks whether a device flow sign-in has been authorized.
+// The response status is "pending" until it has.
+func (c *APIClient) PollLogin(deviceCode string) (*models.LoginResponse, error) {
+	var response models.LoginResponse
+	var errResponse models.ErrorResponse
+
+	resp, err := c.client.R().
+		SetBody(models.DeviceTokenRequest{DeviceCode: deviceCode}).
+		SetResult(&response).
+		SetError(&errResponse).
+		Post(fmt.Sprintf("%s/api/v1/auth/github/token", c.baseURL))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete sign-in: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, apiError(resp, errResponse)
 	}
 
 	return &response, nil
@@ -157,11 +196,78 @@ func (c *APIClient) Get(path string) ([]byte, error) {
 	}
 
 	if resp.IsError() {
-		if errResponse.Error != "" {
-			return nil, fmt.Errorf("API error: %s", errResponse.Error)
-		}
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode())
+		return nil, apiError(resp, errResponse)
 	}
 
 	return resp.Body(), nil
 }
+
+// FollowRequest reads the progress events of a faucet request, calling onEvent
+// for each, until the server ends the stream after the final event or ctx is done.
+// The stream replays earlier events, so it may be opened before the request is sent.
+func (c *APIClient) FollowRequest(ctx context.Context, requestID string, onEvent func(models.RequestEvent)) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf("%s/api/v1/requests/%s/events", c.baseURL, requestID), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	// Not the resty client: its timeout would cut off a stream waiting for confirmation
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to follow request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResponse models.ErrorResponse
+		json.NewDecoder(resp.Body).Decode(&errResponse)
+		return &APIError{Status: resp.StatusCode, Code: errResponse.Code, Message: errResponse.Error}
+	}
+
+	// Events are "data: <json>" lines; the id and event lines repeat what the JSON holds
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		var event models.RequestEvent
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			return fmt.Errorf("failed to decode request event: %w", err)
+		}
+		onEvent(event)
+		if event.Final {
+			return nil
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return scanner.Err()
+}
+
+// RequestBatch funds a list of addresses on an API key's budget. The server
+// answers once every item has been sent, so this can take a while.
+func (c *APIClient) RequestBatch(apiKey string, req models.BatchRequest) (*models.BatchResponse, error) {
+	var response models.BatchResponse
+	var errResponse models.ErrorResponse
+
+	resp, err := c.client.R().
+		SetHeader("X-API-Key", apiKey).
+		SetBody(req).
+		SetResult(&response).
+		SetError(&errResponse).
+		Post(fmt.Sprintf("%s/api/v1/batch", c.baseURL))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to request batch: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, apiError(resp, errResponse)
+	}
+
+	return &response, nil
+}
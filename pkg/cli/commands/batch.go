@@ -0,0 +1,158 @@
+package commands
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/Giri-Aayush/starknet-faucet/pkg/cli"
+	"github.com/Giri-Aayush/starknet-faucet/pkg/cli/ui"
+	"github.com/spf13/cobra"
+)
+
+var batchAPIKey string
+
+var batchCmd = &cobra.Command{
+	Use:   "batch <file.csv>",
+	Short: "Fund a list of addresses with an API key",
+	Long: `Fund every address in a CSV file on an API key's budget.
+
+Each row is: address[,network[,token]]. Missing networks default to -n and
+missing tokens to --token (or the network's default token). A header row
+starting with "address" is skipped.
+
+USAGE
+  faucet-terminal batch <file.csv> -n <network> --api-key <key>
+
+EXAMPLES
+  faucet-terminal batch accounts.csv -n sn
+  faucet-terminal batch accounts.csv -n eth --json
+
+FLAGS
+  --api-key   API key from the faucet operator (or FAUCET_API_KEY)
+  --token     Default token for rows without one`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBatch,
+}
+
+func init() {
+	batchCmd.Flags().StringVar(&batchAPIKey, "api-key", "", "API key from the faucet operator")
+	batchCmd.Flags().StringVar(&token, "token", "", "Default token for rows without one")
+}
+
+func runBatch(cmd *cobra.Command, args []string) error {
+	if err := ValidateNetwork(); err != nil {
+		return err
+	}
+	if batchAPIKey == "" {
+		batchAPIKey = os.Getenv("FAUCET_API_KEY")
+	}
+	if batchAPIKey == "" {
+		return fmt.Errorf("an API key is required: pass --api-key or set FAUCET_API_KEY")
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	items, err := readBatchCSV(file, GetNetwork(), token)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", args[0], err)
+	}
+
+	client := cli.NewAPIClient(GetAPIURL())
+
+	var resp *models.BatchResponse
+	if jsonOut {
+		resp, err = client.RequestBatch(batchAPIKey, models.BatchRequest{Items: items})
+		if err != nil {
+			return err
+		}
+		jsonBytes, _ := json.MarshalIndent(resp, "", "  ")
+		fmt.Println(string(jsonBytes))
+		return nil
+	}
+
+	ui.PrintBanner()
+	s := ui.NewSpinner(fmt.Sprintf("Funding %d addresses...", len(items)))
+	s.Start()
+	resp, err = client.RequestBatch(batchAPIKey, models.BatchRequest{Items: items})
+	s.Stop()
+	if err != nil {
+		printRequestError("Batch refused", err)
+		return err
+	}
+
+	fmt.Println()
+	for _, result := range resp.Results {
+		if result.Success {
+			ui.PrintSuccess(fmt.Sprintf("%s %s %s on %s: %s", result.Address, result.Amount, result.Token, result.Network, result.ExplorerURL))
+		} else {
+			ui.PrintError(fmt.Sprintf("%s %s on %s: %s (%s)", result.Address, result.Token, result.Network, result.Error, result.Code))
+		}
+	}
+	fmt.Println()
+	ui.PrintInfo(fmt.Sprintf("%d funded, %d failed", resp.Succeeded, resp.Failed))
+
+	if resp.Failed > 0 {
+		return fmt.Errorf("%d of %d items failed", resp.Failed, len(resp.Results))
+	}
+	return nil
+}
+
+// readBatchCSV reads address[,network[,token]] rows into batch items
+func readBatchCSV(r io.Reader, defaultNetwork, defaultToken string) ([]models.BatchItem, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var items []models.BatchItem
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if row == 1 && strings.EqualFold(record[0], "address") {
+			continue
+		}
+		if len(record) > 3 || strings.TrimSpace(record[0]) == "" {
+			return nil, fmt.Errorf("row %d: expected address[,network[,token]]", row)
+		}
+
+		item := models.BatchItem{Address: strings.TrimSpace(record[0]), Network: defaultNetwork, Token: defaultToken}
+		if len(record) > 1 && strings.TrimSpace(record[1]) != "" {
+			item.Network = resolveNetwork(strings.TrimSpace(record[1]))
+		}
+		if len(record) > 2 && strings.TrimSpace(record[2]) != "" {
+			item.Token = strings.ToUpper(strings.TrimSpace(record[2]))
+		}
+		if item.Token == "" {
+			item.Token = defaultNetworkToken(item.Network)
+		}
+		items = append(items, item)
+	}
+
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no addresses")
+	}
+	return items, nil
+}
+
+// defaultNetworkToken is the token requested on a network when none is given
+func defaultNetworkToken(network string) string {
+	if network == "starknet" {
+		return "STRK"
+	}
+	return "ETH"
+}
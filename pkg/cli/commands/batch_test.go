@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadBatchCSV(t *testing.T) {
+	input := `address,network,token
+# funded last week
+0xaaa
+0xbbb, eth
+0xccc,sn,eth
+0xddd,,
+`
+	items, err := readBatchCSV(strings.NewReader(input), "starknet", "")
+	require.NoError(t, err)
+	assert.Equal(t, []models.BatchItem{
+		{Address: "0xaaa", Network: "starknet", Token: "STRK"},
+		{Address: "0xbbb", Network: "ethereum", Token: "ETH"},
+		{Address: "0xccc", Network: "starknet", Token: "ETH"},
+		{Address: "0xddd", Network: "starknet", Token: "STRK"},
+	}, items)
+
+	items, err = readBatchCSV(strings.NewReader("0xaaa\n"), "ethereum", "usdc")
+	require.NoError(t, err)
+	assert.Equal(t, "usdc", items[0].Token, "the default token is used as given")
+}
+
+func TestReadBatchCSVErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"missing address", "0xaaa\n,starknet\n"},
+		{"too many columns", "0xaaa,starknet,STRK,10\n"},
+		{"header only", "address,network,token\n"},
+		{"comments only", "# nothing yet\n"},
+	}
+	for _, tt := range tests {
+		_, err := readBatchCSV(strings.NewReader(tt.input), "starknet", "")
+		assert.Error(t, err, tt.name)
+	}
+}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/pkg/cli"
+	"github.com/Giri-Aayush/starknet-faucet/pkg/cli/ui"
+	"github.com/spf13/cobra"
+)
+
+var loginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Sign in with GitHub for a larger quota",
+	Long: `Sign in with GitHub so your quota follows your account instead of your IP.
+
+Opens a GitHub device flow: visit the URL shown and enter the code.
+
+USAGE
+  faucet-terminal login`,
+	Args: cobra.NoArgs,
+	RunE: runLogin,
+}
+
+var logoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Forget the stored GitHub sign-in",
+	Args:  cobra.NoArgs,
+	RunE:  runLogout,
+}
+
+func runLogin(cmd *cobra.Command, args []string) error {
+	baseURL := GetAPIURL()
+	client := cli.NewAPIClient(baseURL)
+
+	device, err := client.StartLogin()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Printf("  Open:  %s\n", device.VerificationURI)
+	fmt.Printf("  Code:  %s\n", device.UserCode)
+	fmt.Println()
+
+	spinner := ui.NewSpinner("Waiting for GitHub authorization...")
+	spinner.Start()
+	defer spinner.Stop()
+
+	interval := time.Duration(device.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	deadline := time.Now().Add(time.Duration(device.ExpiresIn) * time.Second)
+
+	for time.Now().Before(deadline) {
+		time.Sleep(interval)
+
+		resp, err := client.PollLogin(device.DeviceCode)
+		if err != nil {
+			return err
+		}
+		if resp.Status == "pending" {
+			if resp.Interval > 0 {
+				interval = time.Duration(resp.Interval) * time.Second
+			}
+			continue
+		}
+
+		spinner.Stop()
+		if err := cli.SaveSession(&cli.Session{
+			APIURL:    baseURL,
+			Token:     resp.SessionToken,
+			Login:     resp.Login,
+			ExpiresAt: resp.ExpiresAt,
+		}); err != nil {
+			return err
+		}
+		ui.PrintSuccess(fmt.Sprintf("Signed in as %s until %s",
+			resp.Login, time.Unix(resp.ExpiresAt, 0).Format("2006-01-02 15:04")))
+		return nil
+	}
+
+	return fmt.Errorf("sign-in code expired. Run 'faucet-terminal login' again")
+}
+
+func runLogout(cmd *cobra.Command, args []string) error {
+	if err := cli.ClearSession(); err != nil {
+		return err
+	}
+	ui.PrintSuccess("Signed out")
+	return nil
+}
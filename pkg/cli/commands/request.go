@@ -1,9 +1,12 @@
 package commands
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -11,14 +14,20 @@ import (
 	"github.com/Giri-Aayush/starknet-faucet/pkg/cli"
 	"github.com/Giri-Aayush/starknet-faucet/pkg/cli/captcha"
 	clipow "github.com/Giri-Aayush/starknet-faucet/pkg/cli/pow"
+	"github.com/Giri-Aayush/starknet-faucet/pkg/cli/signer"
 	"github.com/Giri-Aayush/starknet-faucet/pkg/cli/ui"
+	"github.com/briandowns/spinner"
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/sha3"
 )
 
 var (
-	token            string
-	skipVerification bool
+	token        string
+	testToken    string
+	captchaToken string
+	signKey      string
+	keystorePath string
+	waitConfirm  bool
 )
 
 var requestCmd = &cobra.Command{
@@ -38,18 +47,41 @@ EXAMPLES
   faucet-terminal req 0x123...abc -n eth
   faucet-terminal req 0x123...abc -n sn
   faucet-terminal req 0x123...abc -n sn --token ETH
+  faucet-terminal req 0x123...abc -n eth --wait
 
 FLAGS
-  --token    Token to request (ETH, STRK)`,
+  --token         Token to request (ETH, STRK)
+  --captcha-token Captcha token, when the faucet requires human verification
+  --test-token    Signed test token from the faucet operator (skips verification)
+  --sign-key      Recipient's private key, to prove ownership (or FAUCET_SIGNING_KEY)
+  --keystore      Recipient's JSON keystore, to prove ownership (password from FAUCET_KEYSTORE_PASSWORD)
+  --wait          Wait until the transfer is confirmed on chain`,
 	Args: cobra.ExactArgs(1),
 	RunE: runRequest,
 }
 
 func init() {
 	requestCmd.Flags().StringVar(&token, "token", "", "Token to request (ETH, STRK)")
-	// Hidden test flag - not shown in help, unique name to prevent exploitation
-	requestCmd.Flags().BoolVar(&skipVerification, "skip-verification8922", false, "")
-	requestCmd.Flags().MarkHidden("skip-verification8922")
+	requestCmd.Flags().StringVar(&captchaToken, "captcha-token", "", "Captcha token, when the faucet requires human verification")
+	requestCmd.Flags().StringVar(&testToken, "test-token", "", "Signed test token from the faucet operator (skips verification)")
+	requestCmd.Flags().StringVar(&signKey, "sign-key", "", "Recipient's private key, to prove ownership")
+	requestCmd.Flags().StringVar(&keystorePath, "keystore", "", "Recipient's JSON keystore, to prove ownership")
+	requestCmd.Flags().BoolVar(&waitConfirm, "wait", false, "Wait until the transfer is confirmed on chain")
+}
+
+// ownershipSigner returns the signer selected with --sign-key or --keystore,
+// or nil if the request is not signed
+func ownershipSigner(network string) (signer.Signer, error) {
+	if keystorePath != "" {
+		return signer.FromKeystore(network, keystorePath, os.Getenv("FAUCET_KEYSTORE_PASSWORD"))
+	}
+	if signKey == "" {
+		signKey = os.Getenv("FAUCET_SIGNING_KEY")
+	}
+	if signKey != "" {
+		return signer.FromKey(network, signKey)
+	}
+	return nil, nil
 }
 
 func runRequest(cmd *cobra.Command, args []string) error {
@@ -111,6 +143,12 @@ Address format rules:
 		return err
 	}
 
+	// Load the ownership signer before doing any work, so a bad key fails fast
+	sign, err := ownershipSigner(selectedNetwork)
+	if err != nil {
+		return err
+	}
+
 	// Create API client
 	client := cli.NewAPIClient(GetAPIURL())
 
@@ -119,8 +157,8 @@ Address format rules:
 		ui.PrintBanner()
 		ui.PrintNetworkInfo(selectedNetwork)
 
-		// Skip captcha if --skip-verification is set
-		if !skipVerification {
+		// Skip captcha if a test token is given
+		if testToken == "" {
 			// Ask verification question (3 attempts)
 			correct, err := captcha.AskQuestionWithRetries(3)
 			if err != nil {
@@ -134,7 +172,7 @@ Address format rules:
 	}
 
 	// Request tokens
-	if err := requestSingleToken(client, address, token); err != nil {
+	if err := requestSingleToken(client, address, token, sign); err != nil {
 		return err
 	}
 
@@ -375,91 +413,66 @@ Usage:
 	return nil
 }
 
-func requestSingleToken(client *cli.APIClient, address, token string) error {
+func requestSingleToken(client *cli.APIClient, address, token string, sign signer.Signer) error {
 	if !jsonOut {
 		ui.PrintInfo(fmt.Sprintf("Requesting %s for %s", token, address))
 		fmt.Println()
 	}
 
-	// Step 1: Get challenge
-	var challengeResp *models.ChallengeResponse
-	if !jsonOut {
-		s := ui.NewSpinner("Fetching challenge...")
-		s.Start()
-		var err error
-		challengeResp, err = client.GetChallenge()
-		s.Stop()
-		if err != nil {
-			ui.PrintError(fmt.Sprintf("Failed to get challenge: %v", err))
-			return err
-		}
-		ui.PrintSuccess("Challenge received")
-		fmt.Println()
-	} else {
-		var err error
-		challengeResp, err = client.GetChallenge()
-		if err != nil {
-			return err
-		}
-	}
-
-	// Step 2: Solve PoW
+	// Steps 1-2: Get and solve a challenge, unless a signed test token stands in for it
+	var challengeID, signature, requestID string
 	var nonce int64
 	var solveDuration time.Duration
-
-	if skipVerification {
-		nonce = -1
-		solveDuration = 0
-	} else if !jsonOut {
-		s := ui.NewSpinner(fmt.Sprintf("Solving proof of work (difficulty: %d)...", challengeResp.Difficulty))
-		s.Start()
-
-		solver := clipow.NewSolver()
-		result, err := solver.Solve(challengeResp.Challenge, challengeResp.Difficulty, func(n int64, d time.Duration) {
-			// Update spinner suffix with progress
-			s.Suffix = fmt.Sprintf(" Solving proof of work (attempts: %d, time: %.1fs)...",
-				n, d.Seconds())
+	if testToken == "" {
+		challengeResp, n, d, err := solveChallenge(client, models.ChallengeRequest{
+			Address: address,
+			Token:   token,
+			Network: GetNetwork(),
 		})
-
-		s.Stop()
-
 		if err != nil {
-			ui.PrintError(fmt.Sprintf("Failed to solve challenge: %v", err))
 			return err
 		}
+		challengeID, nonce, solveDuration = challengeResp.ChallengeID, n, d
+		requestID = challengeResp.RequestID
 
-		nonce = result.Nonce
-		solveDuration = result.Duration
-		ui.PrintSuccess(fmt.Sprintf("Challenge solved in %.1fs (nonce: %d)", solveDuration.Seconds(), nonce))
-		fmt.Println()
-	} else {
-		solver := clipow.NewSolver()
-		result, err := solver.Solve(challengeResp.Challenge, challengeResp.Difficulty, nil)
-		if err != nil {
-			return err
+		// Prove ownership of the recipient by signing the challenge
+		if sign != nil {
+			signature, err = sign.Sign(address, GetNetwork(), challengeResp.SigningChainID, challengeResp.Challenge)
+			if err != nil {
+				return fmt.Errorf("failed to sign challenge: %w", err)
+			}
 		}
-		nonce = result.Nonce
-		solveDuration = result.Duration
 	}
 
 	// Step 3: Request tokens
 	req := models.FaucetRequest{
-		Address:     address,
-		Token:       token,
-		Network:     GetNetwork(),
-		ChallengeID: challengeResp.ChallengeID,
-		Nonce:       nonce,
+		Address:      address,
+		Token:        token,
+		Network:      GetNetwork(),
+		ChallengeID:  challengeID,
+		Nonce:        nonce,
+		TestToken:    testToken,
+		CaptchaToken: captchaToken,
+		Signature:    signature,
 	}
 
 	var faucetResp *models.FaucetResponse
 	if !jsonOut {
 		s := ui.NewSpinner("Submitting request...")
 		s.Start()
+
+		// Show the server's progress while the request is handled
+		stopProgress := func() {}
+		if requestID != "" {
+			stopProgress = showProgress(client, requestID, s)
+		}
+
 		var err error
 		faucetResp, err = client.RequestTokens(req)
+		stopProgress()
 		s.Stop()
 		if err != nil {
-			ui.PrintError(fmt.Sprintf("Failed to request tokens: %v", err))
+			printRequestError("Failed to request tokens", err)
 			return err
 		}
 		ui.PrintSuccess("Transaction submitted!")
@@ -479,13 +492,178 @@ func requestSingleToken(client *cli.APIClient, address, token string) error {
 			"amount":         faucetResp.Amount,
 			"token":          faucetResp.Token,
 			"explorer_url":   faucetResp.ExplorerURL,
+			"request_id":     faucetResp.RequestID,
 			"solve_duration": solveDuration.Seconds(),
 		}
+		if waitConfirm && faucetResp.RequestID != "" {
+			outcomes, err := waitForConfirmation(client, faucetResp.RequestID, nil)
+			if err != nil {
+				return err
+			}
+			output["outcomes"] = outcomes
+		}
 		jsonBytes, _ := json.MarshalIndent(output, "", "  ")
 		fmt.Println(string(jsonBytes))
-	} else {
-		ui.PrintFaucetResponse(faucetResp)
+		return nil
+	}
+
+	ui.PrintFaucetResponse(faucetResp)
+
+	if waitConfirm && faucetResp.RequestID != "" {
+		fmt.Println()
+		s := ui.NewSpinner("Waiting for confirmation...")
+		s.Start()
+		outcomes, err := waitForConfirmation(client, faucetResp.RequestID, s)
+		s.Stop()
+		if err != nil {
+			printRequestError("Failed to follow the transfer", err)
+			return err
+		}
+		for _, outcome := range outcomes {
+			if outcome.Stage == models.StageConfirmed {
+				ui.PrintSuccess(stageMessage(outcome))
+			} else {
+				ui.PrintError(stageMessage(outcome))
+			}
+		}
 	}
 
 	return nil
 }
+
+// showProgress follows a request's events in the background, showing each
+// stage on the spinner. Call the returned function to stop following.
+func showProgress(client *cli.APIClient, requestID string, s *spinner.Spinner) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// Progress is cosmetic: the response reports the outcome
+		client.FollowRequest(ctx, requestID, func(event models.RequestEvent) {
+			s.Suffix = " " + stageMessage(event) + "..."
+		})
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// waitForConfirmation follows a request until every transaction has confirmed
+// or failed, returning the last event of each. The spinner, if any, shows progress.
+func waitForConfirmation(client *cli.APIClient, requestID string, s *spinner.Spinner) ([]models.RequestEvent, error) {
+	var outcomes []models.RequestEvent
+	err := client.FollowRequest(context.Background(), requestID, func(event models.RequestEvent) {
+		if s != nil {
+			s.Suffix = " " + stageMessage(event) + "..."
+		}
+		if event.Stage == models.StageConfirmed || (event.Stage == models.StageFailed && event.TxHash != "") {
+			outcomes = append(outcomes, event)
+		}
+	})
+	return outcomes, err
+}
+
+// stageMessage describes a request event for the terminal
+func stageMessage(event models.RequestEvent) string {
+	switch event.Stage {
+	case models.StageVerified:
+		return "Request verified"
+	case models.StageQueued:
+		return fmt.Sprintf("Queued %s transfer", event.Token)
+	case models.StageSigned:
+		return fmt.Sprintf("Signed %s transaction", event.Token)
+	case models.StageBroadcast:
+		return fmt.Sprintf("Broadcast %s transaction", event.Token)
+	case models.StageIncluded:
+		return fmt.Sprintf("%s transaction included in block %d", event.Token, event.BlockNumber)
+	case models.StageConfirmed:
+		return fmt.Sprintf("%s transaction confirmed in block %d", event.Token, event.BlockNumber)
+	case models.StageFailed:
+		if event.Token != "" {
+			return fmt.Sprintf("%s transfer failed: %s", event.Token, event.Error)
+		}
+		return "Request failed: " + event.Error
+	case models.StageRefused:
+		return "Attempt refused: " + event.Error
+	}
+	return string(event.Stage)
+}
+
+// printRequestError reports a failed API call, with what to do next for the
+// error codes the user can act on
+func printRequestError(prefix string, err error) {
+	ui.PrintError(fmt.Sprintf("%s: %v", prefix, err))
+
+	var apiErr *cli.APIError
+	if !errors.As(err, &apiErr) {
+		return
+	}
+
+	switch {
+	case apiErr.IsRateLimit() && apiErr.NextRequestTime != nil:
+		ui.PrintCooldownError(apiErr.NextRequestTime, apiErr.RemainingHours)
+	case apiErr.Code == models.ErrCodeSignatureRequired:
+		ui.PrintInfo("Sign the request with --sign-key or --keystore")
+	case apiErr.Code == models.ErrCodeInvalidSession:
+		ui.PrintInfo("Run 'faucet-terminal login' to sign in again")
+	case apiErr.Code == models.ErrCodeSignInDisabled:
+		ui.PrintInfo("Run 'faucet-terminal logout' to continue without signing in")
+	}
+}
+
+// solveChallenge fetches a PoW challenge bound to the request and solves it
+func solveChallenge(client *cli.APIClient, challengeReq models.ChallengeRequest) (*models.ChallengeResponse, int64, time.Duration, error) {
+	// Step 1: Get challenge (bound to this address, network and token)
+	var challengeResp *models.ChallengeResponse
+	if !jsonOut {
+		s := ui.NewSpinner("Fetching challenge...")
+		s.Start()
+		var err error
+		challengeResp, err = client.GetChallenge(challengeReq)
+		s.Stop()
+		if err != nil {
+			printRequestError("Failed to get challenge", err)
+			return nil, 0, 0, err
+		}
+		ui.PrintSuccess("Challenge received")
+		fmt.Println()
+	} else {
+		var err error
+		challengeResp, err = client.GetChallenge(challengeReq)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+	}
+
+	// Step 2: Solve PoW
+	if !jsonOut {
+		s := ui.NewSpinner(fmt.Sprintf("Solving proof of work (difficulty: %d)...", challengeResp.Difficulty))
+		s.Start()
+
+		solver := clipow.NewSolver()
+		result, err := solver.SolveChallenge(challengeResp, func(n int64, d time.Duration) {
+			// Update spinner suffix with progress
+			s.Suffix = fmt.Sprintf(" Solving proof of work (attempts: %d, time: %.1fs)...",
+				n, d.Seconds())
+		})
+
+		s.Stop()
+
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("Failed to solve challenge: %v", err))
+			return nil, 0, 0, err
+		}
+
+		ui.PrintSuccess(fmt.Sprintf("Challenge solved in %.1fs (nonce: %d)", result.Duration.Seconds(), result.Nonce))
+		fmt.Println()
+		return challengeResp, result.Nonce, result.Duration, nil
+	}
+
+	solver := clipow.NewSolver()
+	result, err := solver.SolveChallenge(challengeResp, nil)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return challengeResp, result.Nonce, result.Duration, nil
+}
@@ -1,10 +1,14 @@
 package commands
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/Giri-Aayush/starknet-faucet/pkg/cli"
 	"github.com/spf13/cobra"
 )
 
@@ -65,6 +69,9 @@ COMMANDS
   info            View faucet information
   quota           Check your remaining quota
   limits          Show rate limit rules
+  login           Sign in with GitHub for a larger quota
+  logout          Forget the stored sign-in
+  batch           Fund a list of addresses with an API key
 
 FLAGS
   -n, --network   Network to use (required)
@@ -77,11 +84,28 @@ https://github.com/Giri-Aayush/faucet-terminal`,
 // Execute runs the root command
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
+		var apiErr *cli.APIError
+		if jsonOut && errors.As(err, &apiErr) {
+			printJSONError(apiErr)
+		} else {
+			fmt.Fprintln(os.Stderr, err)
+		}
 		os.Exit(1)
 	}
 }
 
+// printJSONError writes an API error as JSON so scripts can branch on its code
+func printJSONError(apiErr *cli.APIError) {
+	jsonBytes, _ := json.MarshalIndent(models.ErrorResponse{
+		Code:            apiErr.Code,
+		Error:           apiErr.Message,
+		Details:         apiErr.Details,
+		NextRequestTime: apiErr.NextRequestTime,
+		RemainingHours:  apiErr.RemainingHours,
+	}, "", "  ")
+	fmt.Println(string(jsonBytes))
+}
+
 func init() {
 	// Global flags with short versions
 	rootCmd.PersistentFlags().StringVarP(&network, "network", "n", "", "Network: starknet|sn-sep, ethereum|eth-sep")
@@ -101,6 +125,9 @@ func init() {
 	rootCmd.AddCommand(infoCmd)
 	rootCmd.AddCommand(limitsCmd)
 	rootCmd.AddCommand(quotaCmd)
+	rootCmd.AddCommand(loginCmd)
+	rootCmd.AddCommand(logoutCmd)
+	rootCmd.AddCommand(batchCmd)
 }
 
 // resolveNetwork converts network aliases to full network names
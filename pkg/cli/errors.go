@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/go-resty/resty/v2"
+)
+
+// APIError is an error response from the faucet. Callers should branch on
+// Code rather than the message.
+type APIError struct {
+	Status          int
+	Code            models.ErrorCode
+	Message         string
+	Details         *models.ErrorDetails
+	NextRequestTime *time.Time
+	RemainingHours  *float64
+	RetryAfter      time.Duration
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("API returned status %d", e.Status)
+	}
+	return "API error: " + e.Message
+}
+
+// IsRateLimit reports whether the request may succeed if retried after RetryAfter
+func (e *APIError) IsRateLimit() bool {
+	return e.Code.IsRateLimit()
+}
+
+// apiError builds an APIError from a failed response, reading the retry hints
+// from the body and the Retry-After header
+func apiError(resp *resty.Response, errResponse models.ErrorResponse) *APIError {
+	err := &APIError{
+		Status:          resp.StatusCode(),
+		Code:            errResponse.Code,
+		Message:         errResponse.Error,
+		Details:         errResponse.Details,
+		NextRequestTime: errResponse.NextRequestTime,
+		RemainingHours:  errResponse.RemainingHours,
+	}
+
+	if seconds, convErr := strconv.Atoi(resp.Header().Get("Retry-After")); convErr == nil {
+		err.RetryAfter = time.Duration(seconds) * time.Second
+	} else if err.NextRequestTime != nil {
+		err.RetryAfter = time.Until(*err.NextRequestTime)
+	}
+	if err.NextRequestTime == nil && err.RetryAfter > 0 {
+		next := time.Now().Add(err.RetryAfter)
+		err.NextRequestTime = &next
+	}
+	if err.RemainingHours == nil && err.RetryAfter > 0 {
+		hours := err.RetryAfter.Hours()
+		err.RemainingHours = &hours
+	}
+	return err
+}
@@ -1,11 +1,11 @@
 package pow
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
 	"fmt"
-	"strings"
 	"time"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/models"
+	"github.com/Giri-Aayush/starknet-faucet/internal/pow"
 )
 
 // SolveResult contains the result of solving a PoW challenge
@@ -22,20 +22,44 @@ func NewSolver() *Solver {
 	return &Solver{}
 }
 
-// Solve solves a PoW challenge with progress updates
+// Solve solves a SHA-256 PoW challenge with difficulty in leading hex zeros, with progress updates
 func (s *Solver) Solve(challenge string, difficulty int, progressCallback func(int64, time.Duration)) (*SolveResult, error) {
-	prefix := strings.Repeat("0", difficulty)
+	return s.SolveWith(pow.AlgorithmSHA256, models.PoWParams{}, challenge, difficulty*4, progressCallback)
+}
+
+// SolveChallenge solves a challenge using the algorithm and parameters the server issued it with
+func (s *Solver) SolveChallenge(resp *models.ChallengeResponse, progressCallback func(int64, time.Duration)) (*SolveResult, error) {
+	var params models.PoWParams
+	if resp.Params != nil {
+		params = *resp.Params
+	}
+	// Servers that predate bit-level difficulty only send leading hex zeros
+	bits := resp.DifficultyBits
+	if bits == 0 {
+		bits = resp.Difficulty * 4
+	}
+	return s.SolveWith(resp.Algorithm, params, resp.Challenge, bits, progressCallback)
+}
+
+// SolveWith solves a PoW challenge with the given algorithm and difficulty in
+// leading zero bits, with progress updates
+func (s *Solver) SolveWith(algorithm string, params models.PoWParams, challenge string, bits int, progressCallback func(int64, time.Duration)) (*SolveResult, error) {
+	if err := pow.ValidateParams(algorithm, params); err != nil {
+		return nil, err
+	}
+
 	startTime := time.Now()
 
 	var nonce int64
 	var lastUpdate time.Time
 
 	for {
-		data := fmt.Sprintf("%s%d", challenge, nonce)
-		hash := sha256.Sum256([]byte(data))
-		hashHex := hex.EncodeToString(hash[:])
+		hash, err := pow.Hash(algorithm, params, challenge, nonce)
+		if err != nil {
+			return nil, err
+		}
 
-		if strings.HasPrefix(hashHex, prefix) {
+		if pow.MeetsDifficultyBits(hash, bits) {
 			// Found solution!
 			duration := time.Since(startTime)
 			return &SolveResult{
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Session is a stored faucet sign-in
+type Session struct {
+	APIURL    string `json:"api_url"`
+	Token     string `json:"token"`
+	Login     string `json:"login"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+// Expired reports whether the session can no longer be used
+func (s *Session) Expired() bool {
+	return time.Now().Unix() >= s.ExpiresAt
+}
+
+// sessionPath returns where the sign-in for the faucet is stored
+func sessionPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "faucet-terminal", "session.json"), nil
+}
+
+// LoadSession returns the stored sign-in for apiURL, or nil if there is none
+func LoadSession(apiURL string) (*Session, error) {
+	path, err := sessionPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to parse session: %w", err)
+	}
+	if session.APIURL != apiURL || session.Expired() {
+		return nil, nil
+	}
+	return &session, nil
+}
+
+// SaveSession stores a sign-in, readable only by the current user
+func SaveSession(session *Session) error {
+	path, err := sessionPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	return nil
+}
+
+// ClearSession removes the stored sign-in, if any
+func ClearSession() error {
+	path, err := sessionPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove session: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Package signer signs faucet challenges with the recipient's key, for
+// faucets that require proof of ownership of the recipient address.
+package signer
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/Giri-Aayush/starknet-faucet/internal/ownership"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Signer signs the ownership message for a challenge. chainID is the
+// signing_chain_id of the challenge response; Starknet signatures are bound
+// to it, and an empty one means Sepolia.
+type Signer interface {
+	Sign(address, network, chainID, challenge string) (string, error)
+}
+
+// FromKey creates a signer for network from a hex private key: a secp256k1
+// key on Ethereum, a Stark key on Starknet
+func FromKey(network, key string) (Signer, error) {
+	key = strings.TrimSpace(key)
+	switch network {
+	case "ethereum":
+		privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(key, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid Ethereum private key: %w", err)
+		}
+		return &evmSigner{key: privateKey}, nil
+	case "starknet":
+		privateKey, ok := new(big.Int).SetString(strings.TrimPrefix(key, "0x"), 16)
+		if !ok || privateKey.Sign() == 0 {
+			return nil, fmt.Errorf("invalid Starknet private key")
+		}
+		return &starknetSigner{key: privateKey}, nil
+	default:
+		return nil, fmt.Errorf("signing is not supported on %s", network)
+	}
+}
+
+// FromKeystore creates a signer for network from an encrypted JSON (v3)
+// keystore file, as written by geth, Foundry or starkli
+func FromKeystore(network, path, password string) (Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore: %w", err)
+	}
+	key, err := keystore.DecryptKey(data, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore: %w", err)
+	}
+
+	switch network {
+	case "ethereum":
+		return &evmSigner{key: key.PrivateKey}, nil
+	case "starknet":
+		privateKey := new(big.Int).SetBytes(crypto.FromECDSA(key.PrivateKey))
+		return &starknetSigner{key: privateKey}, nil
+	default:
+		return nil, fmt.Errorf("signing is not supported on %s", network)
+	}
+}
+
+type evmSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+// Sign refuses to sign for an address the key does not control, since the
+// faucet would reject the signature anyway
+func (s *evmSigner) Sign(address, network, chainID, challenge string) (string, error) {
+	if crypto.PubkeyToAddress(s.key.PublicKey) != common.HexToAddress(address) {
+		return "", fmt.Errorf("signing key is for %s, not %s", crypto.PubkeyToAddress(s.key.PublicKey).Hex(), address)
+	}
+	return ownership.SignEVM(s.key, network, challenge)
+}
+
+type starknetSigner struct {
+	key *big.Int
+}
+
+// Sign signs for the chain the faucet verifies against. Faucets that do not
+// send a chain ID predate mainnet support and only run on Sepolia.
+func (s *starknetSigner) Sign(address, network, chainID, challenge string) (string, error) {
+	if chainID == "" {
+		chainID = ownership.StarknetSepoliaChainID
+	}
+	return ownership.SignStarknet(s.key, address, chainID, challenge)
+}
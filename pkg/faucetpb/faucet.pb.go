@@ -0,0 +1,1808 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        (unknown)
+// source: faucet/v1/faucet.proto
+
+package faucetpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FollowRequestResponse_Stage int32
+
+const (
+	FollowRequestResponse_STAGE_UNSPECIFIED FollowRequestResponse_Stage = 0
+	// Transactions were broadcast; result lists them
+	FollowRequestResponse_STAGE_SUBMITTED FollowRequestResponse_Stage = 1
+	// A transaction was accepted on chain
+	FollowRequestResponse_STAGE_CONFIRMED FollowRequestResponse_Stage = 2
+	// A transaction reverted or could not be confirmed in time
+	FollowRequestResponse_STAGE_FAILED FollowRequestResponse_Stage = 3
+)
+
+// Enum value maps for FollowRequestResponse_Stage.
+var (
+	FollowRequestResponse_Stage_name = map[int32]string{
+		0: "STAGE_UNSPECIFIED",
+		1: "STAGE_SUBMITTED",
+		2: "STAGE_CONFIRMED",
+		3: "STAGE_FAILED",
+	}
+	FollowRequestResponse_Stage_value = map[string]int32{
+		"STAGE_UNSPECIFIED": 0,
+		"STAGE_SUBMITTED":   1,
+		"STAGE_CONFIRMED":   2,
+		"STAGE_FAILED":      3,
+	}
+)
+
+func (x FollowRequestResponse_Stage) Enum() *FollowRequestResponse_Stage {
+	p := new(FollowRequestResponse_Stage)
+	*p = x
+	return p
+}
+
+func (x FollowRequestResponse_Stage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FollowRequestResponse_Stage) Descriptor() protoreflect.EnumDescriptor {
+	return file_faucet_v1_faucet_proto_enumTypes[0].Descriptor()
+}
+
+func (FollowRequestResponse_Stage) Type() protoreflect.EnumType {
+	return &file_faucet_v1_faucet_proto_enumTypes[0]
+}
+
+func (x FollowRequestResponse_Stage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FollowRequestResponse_Stage.Descriptor instead.
+func (FollowRequestResponse_Stage) EnumDescriptor() ([]byte, []int) {
+	return file_faucet_v1_faucet_proto_rawDescGZIP(), []int{20, 0}
+}
+
+type GetChallengeRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Address string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// ETH, STRK or BOTH
+	Token string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	// starknet, ethereum, ...; empty for the server's default network
+	Network       string `protobuf:"bytes,3,opt,name=network,proto3" json:"network,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChallengeRequest) Reset() {
+	*x = GetChallengeRequest{}
+	mi := &file_faucet_v1_faucet_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChallengeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChallengeRequest) ProtoMessage() {}
+
+func (x *GetChallengeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_faucet_v1_faucet_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChallengeRequest.ProtoReflect.Descriptor instead.
+func (*GetChallengeRequest) Descriptor() ([]byte, []int) {
+	return file_faucet_v1_faucet_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetChallengeRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *GetChallengeRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetChallengeRequest) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+type GetChallengeResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ChallengeId string                 `protobuf:"bytes,1,opt,name=challenge_id,json=challengeId,proto3" json:"challenge_id,omitempty"`
+	Challenge   string                 `protobuf:"bytes,2,opt,name=challenge,proto3" json:"challenge,omitempty"`
+	// Leading zero hex digits, rounded up
+	Difficulty int32 `protobuf:"varint,3,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	// Leading zero bits; the exact requirement
+	DifficultyBits int32 `protobuf:"varint,4,opt,name=difficulty_bits,json=difficultyBits,proto3" json:"difficulty_bits,omitempty"`
+	// hex or bits: the unit the server tunes difficulty in
+	DifficultyFormat string `protobuf:"bytes,5,opt,name=difficulty_format,json=difficultyFormat,proto3" json:"difficulty_format,omitempty"`
+	// Hash must be below this 256-bit hex number
+	Target string `protobuf:"bytes,6,opt,name=target,proto3" json:"target,omitempty"`
+	// sha256, argon2id or scrypt
+	Algorithm string `protobuf:"bytes,7,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	// Only set for memory-hard algorithms
+	Params        *PoWParams `protobuf:"bytes,8,opt,name=params,proto3" json:"params,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChallengeResponse) Reset() {
+	*x = GetChallengeResponse{}
+	mi := &file_faucet_v1_faucet_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChallengeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChallengeResponse) ProtoMessage() {}
+
+func (x *GetChallengeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_faucet_v1_faucet_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChallengeResponse.ProtoReflect.Descriptor instead.
+func (*GetChallengeResponse) Descriptor() ([]byte, []int) {
+	return file_faucet_v1_faucet_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetChallengeResponse) GetChallengeId() string {
+	if x != nil {
+		return x.ChallengeId
+	}
+	return ""
+}
+
+func (x *GetChallengeResponse) GetChallenge() string {
+	if x != nil {
+		return x.Challenge
+	}
+	return ""
+}
+
+func (x *GetChallengeResponse) GetDifficulty() int32 {
+	if x != nil {
+		return x.Difficulty
+	}
+	return 0
+}
+
+func (x *GetChallengeResponse) GetDifficultyBits() int32 {
+	if x != nil {
+		return x.DifficultyBits
+	}
+	return 0
+}
+
+func (x *GetChallengeResponse) GetDifficultyFormat() string {
+	if x != nil {
+		return x.DifficultyFormat
+	}
+	return ""
+}
+
+func (x *GetChallengeResponse) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *GetChallengeResponse) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *GetChallengeResponse) GetParams() *PoWParams {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+// PoWParams holds tuning parameters for memory-hard PoW algorithms
+type PoWParams struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Argon2id parameters
+	Time      uint32 `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	MemoryKib uint32 `protobuf:"varint,2,opt,name=memory_kib,json=memoryKib,proto3" json:"memory_kib,omitempty"`
+	Threads   uint32 `protobuf:"varint,3,opt,name=threads,proto3" json:"threads,omitempty"`
+	// scrypt parameters
+	N             int32 `protobuf:"varint,4,opt,name=n,proto3" json:"n,omitempty"`
+	R             int32 `protobuf:"varint,5,opt,name=r,proto3" json:"r,omitempty"`
+	P             int32 `protobuf:"varint,6,opt,name=p,proto3" json:"p,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PoWParams) Reset() {
+	*x = PoWParams{}
+	mi := &file_faucet_v1_faucet_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PoWParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoWParams) ProtoMessage() {}
+
+func (x *PoWParams) ProtoReflect() protoreflect.Message {
+	mi := &file_faucet_v1_faucet_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoWParams.ProtoReflect.Descriptor instead.
+func (*PoWParams) Descriptor() ([]byte, []int) {
+	return file_faucet_v1_faucet_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PoWParams) GetTime() uint32 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *PoWParams) GetMemoryKib() uint32 {
+	if x != nil {
+		return x.MemoryKib
+	}
+	return 0
+}
+
+func (x *PoWParams) GetThreads() uint32 {
+	if x != nil {
+		return x.Threads
+	}
+	return 0
+}
+
+func (x *PoWParams) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *PoWParams) GetR() int32 {
+	if x != nil {
+		return x.R
+	}
+	return 0
+}
+
+func (x *PoWParams) GetP() int32 {
+	if x != nil {
+		return x.P
+	}
+	return 0
+}
+
+type RequestTokensRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Address string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// ETH, STRK or BOTH
+	Token string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	// starknet, ethereum, ...; empty for the server's default network
+	Network     string `protobuf:"bytes,3,opt,name=network,proto3" json:"network,omitempty"`
+	ChallengeId string `protobuf:"bytes,4,opt,name=challenge_id,json=challengeId,proto3" json:"challenge_id,omitempty"`
+	Nonce       int64  `protobuf:"varint,5,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// Signed test token, replaces challenge_id and nonce
+	TestToken string `protobuf:"bytes,6,opt,name=test_token,json=testToken,proto3" json:"test_token,omitempty"`
+	// Human verification token, when the server requires it
+	CaptchaToken string `protobuf:"bytes,7,opt,name=captcha_token,json=captchaToken,proto3" json:"captcha_token,omitempty"`
+	// Recipient's signature over the challenge, proving ownership
+	Signature     string `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestTokensRequest) Reset() {
+	*x = RequestTokensRequest{}
+	mi := &file_faucet_v1_faucet_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestTokensRequest) ProtoMessage() {}
+
+func (x *RequestTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_faucet_v1_faucet_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestTokensRequest.ProtoReflect.Descriptor instead.
+func (*RequestTokensRequest) Descriptor() ([]byte, []int) {
+	return file_faucet_v1_faucet_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RequestTokensRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *RequestTokensRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RequestTokensRequest) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *RequestTokensRequest) GetChallengeId() string {
+	if x != nil {
+		return x.ChallengeId
+	}
+	return ""
+}
+
+func (x *RequestTokensRequest) GetNonce() int64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *RequestTokensRequest) GetTestToken() string {
+	if x != nil {
+		return x.TestToken
+	}
+	return ""
+}
+
+func (x *RequestTokensRequest) GetCaptchaToken() string {
+	if x != nil {
+		return x.CaptchaToken
+	}
+	return ""
+}
+
+func (x *RequestTokensRequest) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+type RequestTokensResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Message string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// One transaction per token sent
+	Transactions  []*Transaction `protobuf:"bytes,2,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestTokensResponse) Reset() {
+	*x = RequestTokensResponse{}
+	mi := &file_faucet_v1_faucet_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestTokensResponse) ProtoMessage() {}
+
+func (x *RequestTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_faucet_v1_faucet_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestTokensResponse.ProtoReflect.Descriptor instead.
+func (*RequestTokensResponse) Descriptor() ([]byte, []int) {
+	return file_faucet_v1_faucet_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RequestTokensResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RequestTokensResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+// Transaction is a single token transfer made by the faucet
+type Transaction struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// Decimal amount of the token
+	Amount        string `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	TxHash        string `protobuf:"bytes,3,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	ExplorerUrl   string `protobuf:"bytes,4,opt,name=explorer_url,json=explorerUrl,proto3" json:"explorer_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_faucet_v1_faucet_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_faucet_v1_faucet_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_faucet_v1_faucet_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Transaction) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *Transaction) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Transaction) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *Transaction) GetExplorerUrl() string {
+	if x != nil {
+		return x.ExplorerUrl
+	}
+	return ""
+}
+
+type GetStatusRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Address string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Empty for the server's default network
+	Network       string `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusRequest) Reset() {
+	*x = GetStatusRequest{}
+	mi := &file_faucet_v1_faucet_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusRequest) ProtoMessage() {}
+
+func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_faucet_v1_faucet_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_faucet_v1_faucet_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetStatusRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *GetStatusRequest) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+type GetStatusResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Address         string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	CanRequest      bool                   `protobuf:"varint,2,opt,name=can_request,json=canRequest,proto3" json:"can_request,omitempty"`
+	LastRequest     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_request,json=lastRequest,proto3" json:"last_request,omitempty"`
+	NextRequestTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=next_request_time,json=nextRequestTime,proto3" json:"next_request_time,omitempty"`
+	RemainingHours  *float64               `protobuf:"fixed64,5,opt,name=remaining_hours,json=remainingHours,proto3,oneof" json:"remaining_hours,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetStatusResponse) Reset() {
+	*x = GetStatusResponse{}
+	mi := &file_faucet_v1_faucet_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusResponse) ProtoMessage() {}
+
+func (x *GetStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_faucet_v1_faucet_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetStatusResponse) Descriptor() ([]byte, []int) {
+	return file_faucet_v1_faucet_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetStatusResponse) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *GetStatusResponse) GetCanRequest() bool {
+	if x != nil {
+		return x.CanRequest
+	}
+	return false
+}
+
+func (x *GetStatusResponse) GetLastRequest() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastRequest
+	}
+	return nil
+}
+
+func (x *GetStatusResponse) GetNextRequestTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextRequestTime
+	}
+	return nil
+}
+
+func (x *GetStatusResponse) GetRemainingHours() float64 {
+	if x != nil && x.RemainingHours != nil {
+		return *x.RemainingHours
+	}
+	return 0
+}
+
+type GetInfoRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only describe this network; empty for every network
+	Network       string `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInfoRequest) Reset() {
+	*x = GetInfoRequest{}
+	mi := &file_faucet_v1_faucet_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInfoRequest) ProtoMessage() {}
+
+func (x *GetInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_faucet_v1_faucet_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetInfoRequest) Descriptor() ([]byte, []int) {
+	return file_faucet_v1_faucet_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetInfoRequest) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+type GetInfoResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	DefaultNetwork     string                 `protobuf:"bytes,1,opt,name=default_network,json=defaultNetwork,proto3" json:"default_network,omitempty"`
+	DailyRequestsPerIp int32                  `protobuf:"varint,2,opt,name=daily_requests_per_ip,json=dailyRequestsPerIp,proto3" json:"daily_requests_per_ip,omitempty"`
+	Pow                *PoWInfo               `protobuf:"bytes,3,opt,name=pow,proto3" json:"pow,omitempty"`
+	Networks           []*NetworkInfo         `protobuf:"bytes,4,rep,name=networks,proto3" json:"networks,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetInfoResponse) Reset() {
+	*x = GetInfoResponse{}
+	mi := &file_faucet_v1_faucet_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInfoResponse) ProtoMessage() {}
+
+func (x *GetInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_faucet_v1_faucet_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetInfoResponse) Descriptor() ([]byte, []int) {
+	return file_faucet_v1_faucet_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetInfoResponse) GetDefaultNetwork() string {
+	if x != nil {
+		return x.DefaultNetwork
+	}
+	return ""
+}
+
+func (x *GetInfoResponse) GetDailyRequestsPerIp() int32 {
+	if x != nil {
+		return x.DailyRequestsPerIp
+	}
+	return 0
+}
+
+func (x *GetInfoResponse) GetPow() *PoWInfo {
+	if x != nil {
+		return x.Pow
+	}
+	return nil
+}
+
+func (x *GetInfoResponse) GetNetworks() []*NetworkInfo {
+	if x != nil {
+		return x.Networks
+	}
+	return nil
+}
+
+// PoWInfo describes the proof-of-work challenges the faucet issues
+type PoWInfo struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Enabled   bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Algorithm string                 `protobuf:"bytes,2,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	// hex or bits: the unit of the difficulties below
+	DifficultyFormat string `protobuf:"bytes,3,opt,name=difficulty_format,json=difficultyFormat,proto3" json:"difficulty_format,omitempty"`
+	Difficulty       int32  `protobuf:"varint,4,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	// Lowest difficulty issued under light load
+	MinDifficulty int32 `protobuf:"varint,5,opt,name=min_difficulty,json=minDifficulty,proto3" json:"min_difficulty,omitempty"`
+	// Highest difficulty issued under heavy load or abuse
+	MaxDifficulty int32 `protobuf:"varint,6,opt,name=max_difficulty,json=maxDifficulty,proto3" json:"max_difficulty,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PoWInfo) Reset() {
+	*x = PoWInfo{}
+	mi := &file_faucet_v1_faucet_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PoWInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoWInfo) ProtoMessage() {}
+
+func (x *PoWInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_faucet_v1_faucet_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoWInfo.ProtoReflect.Descriptor instead.
+func (*PoWInfo) Descriptor() ([]byte, []int) {
+	return file_faucet_v1_faucet_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PoWInfo) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *PoWInfo) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *PoWInfo) GetDifficultyFormat() string {
+	if x != nil {
+		return x.DifficultyFormat
+	}
+	return ""
+}
+
+func (x *PoWInfo) GetDifficulty() int32 {
+	if x != nil {
+		return x.Difficulty
+	}
+	return 0
+}
+
+func (x *PoWInfo) GetMinDifficulty() int32 {
+	if x != nil {
+		return x.MinDifficulty
+	}
+	return 0
+}
+
+func (x *PoWInfo) GetMaxDifficulty() int32 {
+	if x != nil {
+		return x.MaxDifficulty
+	}
+	return 0
+}
+
+// NetworkInfo describes one network and the tokens the faucet sends on it
+type NetworkInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name to pass as the network field of other calls
+	Network string `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	// starknet, ethereum, ...
+	Chain string `protobuf:"bytes,2,opt,name=chain,proto3" json:"chain,omitempty"`
+	// sepolia, mainnet, ...
+	Name                   string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Default                bool                   `protobuf:"varint,4,opt,name=default,proto3" json:"default,omitempty"`
+	FaucetAddress          string                 `protobuf:"bytes,5,opt,name=faucet_address,json=faucetAddress,proto3" json:"faucet_address,omitempty"`
+	OwnershipProofRequired bool                   `protobuf:"varint,6,opt,name=ownership_proof_required,json=ownershipProofRequired,proto3" json:"ownership_proof_required,omitempty"`
+	HumanVerification      *HumanVerificationInfo `protobuf:"bytes,7,opt,name=human_verification,json=humanVerification,proto3" json:"human_verification,omitempty"`
+	Tokens                 []*TokenInfo           `protobuf:"bytes,8,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *NetworkInfo) Reset() {
+	*x = NetworkInfo{}
+	mi := &file_faucet_v1_faucet_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetworkInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkInfo) ProtoMessage() {}
+
+func (x *NetworkInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_faucet_v1_faucet_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkInfo.ProtoReflect.Descriptor instead.
+func (*NetworkInfo) Descriptor() ([]byte, []int) {
+	return file_faucet_v1_faucet_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *NetworkInfo) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *NetworkInfo) GetChain() string {
+	if x != nil {
+		return x.Chain
+	}
+	return ""
+}
+
+func (x *NetworkInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NetworkInfo) GetDefault() bool {
+	if x != nil {
+		return x.Default
+	}
+	return false
+}
+
+func (x *NetworkInfo) GetFaucetAddress() string {
+	if x != nil {
+		return x.FaucetAddress
+	}
+	return ""
+}
+
+func (x *NetworkInfo) GetOwnershipProofRequired() bool {
+	if x != nil {
+		return x.OwnershipProofRequired
+	}
+	return false
+}
+
+func (x *NetworkInfo) GetHumanVerification() *HumanVerificationInfo {
+	if x != nil {
+		return x.HumanVerification
+	}
+	return nil
+}
+
+func (x *NetworkInfo) GetTokens() []*TokenInfo {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+// HumanVerificationInfo tells clients which captcha to show
+type HumanVerificationInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// turnstile, hcaptcha or stub
+	Provider string `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	SiteKey  string `protobuf:"bytes,2,opt,name=site_key,json=siteKey,proto3" json:"site_key,omitempty"`
+	// Whether anonymous requests on this network need it
+	Required      bool `protobuf:"varint,3,opt,name=required,proto3" json:"required,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HumanVerificationInfo) Reset() {
+	*x = HumanVerificationInfo{}
+	mi := &file_faucet_v1_faucet_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HumanVerificationInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HumanVerificationInfo) ProtoMessage() {}
+
+func (x *HumanVerificationInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_faucet_v1_faucet_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HumanVerificationInfo.ProtoReflect.Descriptor instead.
+func (*HumanVerificationInfo) Descriptor() ([]byte, []int) {
+	return file_faucet_v1_faucet_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *HumanVerificationInfo) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *HumanVerificationInfo) GetSiteKey() string {
+	if x != nil {
+		return x.SiteKey
+	}
+	return ""
+}
+
+func (x *HumanVerificationInfo) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+// TokenInfo describes how much of a token the faucet sends, its limits and
+// the faucet's current balance
+type TokenInfo struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Symbol   string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Decimals int32                  `protobuf:"varint,2,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	// Empty for the chain's native token
+	ContractAddress       string  `protobuf:"bytes,3,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	DripAmount            string  `protobuf:"bytes,4,opt,name=drip_amount,json=dripAmount,proto3" json:"drip_amount,omitempty"`
+	MaxPerHour            float64 `protobuf:"fixed64,5,opt,name=max_per_hour,json=maxPerHour,proto3" json:"max_per_hour,omitempty"`
+	MaxPerDay             float64 `protobuf:"fixed64,6,opt,name=max_per_day,json=maxPerDay,proto3" json:"max_per_day,omitempty"`
+	MaxRecipientBalance   float64 `protobuf:"fixed64,7,opt,name=max_recipient_balance,json=maxRecipientBalance,proto3" json:"max_recipient_balance,omitempty"`
+	ThrottleWindowSeconds int32   `protobuf:"varint,8,opt,name=throttle_window_seconds,json=throttleWindowSeconds,proto3" json:"throttle_window_seconds,omitempty"`
+	// Exact decimal; empty when it could not be read
+	Balance       string `protobuf:"bytes,9,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenInfo) Reset() {
+	*x = TokenInfo{}
+	mi := &file_faucet_v1_faucet_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenInfo) ProtoMessage() {}
+
+func (x *TokenInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_faucet_v1_faucet_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenInfo.ProtoReflect.Descriptor instead.
+func (*TokenInfo) Descriptor() ([]byte, []int) {
+	return file_faucet_v1_faucet_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TokenInfo) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *TokenInfo) GetDecimals() int32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *TokenInfo) GetContractAddress() string {
+	if x != nil {
+		return x.ContractAddress
+	}
+	return ""
+}
+
+func (x *TokenInfo) GetDripAmount() string {
+	if x != nil {
+		return x.DripAmount
+	}
+	return ""
+}
+
+func (x *TokenInfo) GetMaxPerHour() float64 {
+	if x != nil {
+		return x.MaxPerHour
+	}
+	return 0
+}
+
+func (x *TokenInfo) GetMaxPerDay() float64 {
+	if x != nil {
+		return x.MaxPerDay
+	}
+	return 0
+}
+
+func (x *TokenInfo) GetMaxRecipientBalance() float64 {
+	if x != nil {
+		return x.MaxRecipientBalance
+	}
+	return 0
+}
+
+func (x *TokenInfo) GetThrottleWindowSeconds() int32 {
+	if x != nil {
+		return x.ThrottleWindowSeconds
+	}
+	return 0
+}
+
+func (x *TokenInfo) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+type GetQuotaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuotaRequest) Reset() {
+	*x = GetQuotaRequest{}
+	mi := &file_faucet_v1_faucet_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuotaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuotaRequest) ProtoMessage() {}
+
+func (x *GetQuotaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_faucet_v1_faucet_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuotaRequest.ProtoReflect.Descriptor instead.
+func (*GetQuotaRequest) Descriptor() ([]byte, []int) {
+	return file_faucet_v1_faucet_proto_rawDescGZIP(), []int{14}
+}
+
+type GetQuotaResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	DailyLimit *DailyQuota            `protobuf:"bytes,1,opt,name=daily_limit,json=dailyLimit,proto3" json:"daily_limit,omitempty"`
+	// Keyed by network
+	HourlyThrottleByNetwork map[string]*NetworkThrottle `protobuf:"bytes,2,rep,name=hourly_throttle_by_network,json=hourlyThrottleByNetwork,proto3" json:"hourly_throttle_by_network,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *GetQuotaResponse) Reset() {
+	*x = GetQuotaResponse{}
+	mi := &file_faucet_v1_faucet_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuotaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuotaResponse) ProtoMessage() {}
+
+func (x *GetQuotaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_faucet_v1_faucet_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuotaResponse.ProtoReflect.Descriptor instead.
+func (*GetQuotaResponse) Descriptor() ([]byte, []int) {
+	return file_faucet_v1_faucet_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetQuotaResponse) GetDailyLimit() *DailyQuota {
+	if x != nil {
+		return x.DailyLimit
+	}
+	return nil
+}
+
+func (x *GetQuotaResponse) GetHourlyThrottleByNetwork() map[string]*NetworkThrottle {
+	if x != nil {
+		return x.HourlyThrottleByNetwork
+	}
+	return nil
+}
+
+// DailyQuota is the tightest daily limit that applies to the caller
+type DailyQuota struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ip, subnet or the sign-in provider
+	Scope         string                 `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Used          int32                  `protobuf:"varint,3,opt,name=used,proto3" json:"used,omitempty"`
+	Remaining     int32                  `protobuf:"varint,4,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	CooldownEnd   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=cooldown_end,json=cooldownEnd,proto3" json:"cooldown_end,omitempty"`
+	InCooldown    bool                   `protobuf:"varint,6,opt,name=in_cooldown,json=inCooldown,proto3" json:"in_cooldown,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DailyQuota) Reset() {
+	*x = DailyQuota{}
+	mi := &file_faucet_v1_faucet_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyQuota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyQuota) ProtoMessage() {}
+
+func (x *DailyQuota) ProtoReflect() protoreflect.Message {
+	mi := &file_faucet_v1_faucet_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyQuota.ProtoReflect.Descriptor instead.
+func (*DailyQuota) Descriptor() ([]byte, []int) {
+	return file_faucet_v1_faucet_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DailyQuota) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *DailyQuota) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *DailyQuota) GetUsed() int32 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *DailyQuota) GetRemaining() int32 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+func (x *DailyQuota) GetCooldownEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CooldownEnd
+	}
+	return nil
+}
+
+func (x *DailyQuota) GetInCooldown() bool {
+	if x != nil {
+		return x.InCooldown
+	}
+	return false
+}
+
+// NetworkThrottle holds the hourly throttles of the tokens on one network
+type NetworkThrottle struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Keyed by lowercase token symbol
+	Tokens        map[string]*TokenThrottle `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NetworkThrottle) Reset() {
+	*x = NetworkThrottle{}
+	mi := &file_faucet_v1_faucet_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetworkThrottle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkThrottle) ProtoMessage() {}
+
+func (x *NetworkThrottle) ProtoReflect() protoreflect.Message {
+	mi := &file_faucet_v1_faucet_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkThrottle.ProtoReflect.Descriptor instead.
+func (*NetworkThrottle) Descriptor() ([]byte, []int) {
+	return file_faucet_v1_faucet_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *NetworkThrottle) GetTokens() map[string]*TokenThrottle {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+// TokenThrottle is the hourly throttle on one token
+type TokenThrottle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Available     bool                   `protobuf:"varint,1,opt,name=available,proto3" json:"available,omitempty"`
+	NextRequestAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=next_request_at,json=nextRequestAt,proto3" json:"next_request_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenThrottle) Reset() {
+	*x = TokenThrottle{}
+	mi := &file_faucet_v1_faucet_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenThrottle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenThrottle) ProtoMessage() {}
+
+func (x *TokenThrottle) ProtoReflect() protoreflect.Message {
+	mi := &file_faucet_v1_faucet_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenThrottle.ProtoReflect.Descriptor instead.
+func (*TokenThrottle) Descriptor() ([]byte, []int) {
+	return file_faucet_v1_faucet_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TokenThrottle) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *TokenThrottle) GetNextRequestAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextRequestAt
+	}
+	return nil
+}
+
+type FollowRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Request       *RequestTokensRequest  `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FollowRequestRequest) Reset() {
+	*x = FollowRequestRequest{}
+	mi := &file_faucet_v1_faucet_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowRequestRequest) ProtoMessage() {}
+
+func (x *FollowRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_faucet_v1_faucet_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowRequestRequest.ProtoReflect.Descriptor instead.
+func (*FollowRequestRequest) Descriptor() ([]byte, []int) {
+	return file_faucet_v1_faucet_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *FollowRequestRequest) GetRequest() *RequestTokensRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+type FollowRequestResponse struct {
+	state protoimpl.MessageState      `protogen:"open.v1"`
+	Stage FollowRequestResponse_Stage `protobuf:"varint,1,opt,name=stage,proto3,enum=faucet.v1.FollowRequestResponse_Stage" json:"stage,omitempty"`
+	// Set on STAGE_SUBMITTED
+	Result *RequestTokensResponse `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	// The transaction that settled, on STAGE_CONFIRMED and STAGE_FAILED
+	Transaction *Transaction `protobuf:"bytes,3,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	// Why the transaction failed, on STAGE_FAILED
+	Error         string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FollowRequestResponse) Reset() {
+	*x = FollowRequestResponse{}
+	mi := &file_faucet_v1_faucet_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowRequestResponse) ProtoMessage() {}
+
+func (x *FollowRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_faucet_v1_faucet_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowRequestResponse.ProtoReflect.Descriptor instead.
+func (*FollowRequestResponse) Descriptor() ([]byte, []int) {
+	return file_faucet_v1_faucet_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *FollowRequestResponse) GetStage() FollowRequestResponse_Stage {
+	if x != nil {
+		return x.Stage
+	}
+	return FollowRequestResponse_STAGE_UNSPECIFIED
+}
+
+func (x *FollowRequestResponse) GetResult() *RequestTokensResponse {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *FollowRequestResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *FollowRequestResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_faucet_v1_faucet_proto protoreflect.FileDescriptor
+
+const file_faucet_v1_faucet_proto_rawDesc = "" +
+	"\n" +
+	"\x16faucet/v1/faucet.proto\x12\tfaucet.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"_\n" +
+	"\x13GetChallengeRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x12\x18\n" +
+	"\anetwork\x18\x03 \x01(\tR\anetwork\"\xb1\x02\n" +
+	"\x14GetChallengeResponse\x12!\n" +
+	"\fchallenge_id\x18\x01 \x01(\tR\vchallengeId\x12\x1c\n" +
+	"\tchallenge\x18\x02 \x01(\tR\tchallenge\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x03 \x01(\x05R\n" +
+	"difficulty\x12'\n" +
+	"\x0fdifficulty_bits\x18\x04 \x01(\x05R\x0edifficultyBits\x12+\n" +
+	"\x11difficulty_format\x18\x05 \x01(\tR\x10difficultyFormat\x12\x16\n" +
+	"\x06target\x18\x06 \x01(\tR\x06target\x12\x1c\n" +
+	"\talgorithm\x18\a \x01(\tR\talgorithm\x12,\n" +
+	"\x06params\x18\b \x01(\v2\x14.faucet.v1.PoWParamsR\x06params\"\x82\x01\n" +
+	"\tPoWParams\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\rR\x04time\x12\x1d\n" +
+	"\n" +
+	"memory_kib\x18\x02 \x01(\rR\tmemoryKib\x12\x18\n" +
+	"\athreads\x18\x03 \x01(\rR\athreads\x12\f\n" +
+	"\x01n\x18\x04 \x01(\x05R\x01n\x12\f\n" +
+	"\x01r\x18\x05 \x01(\x05R\x01r\x12\f\n" +
+	"\x01p\x18\x06 \x01(\x05R\x01p\"\xfb\x01\n" +
+	"\x14RequestTokensRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x12\x18\n" +
+	"\anetwork\x18\x03 \x01(\tR\anetwork\x12!\n" +
+	"\fchallenge_id\x18\x04 \x01(\tR\vchallengeId\x12\x14\n" +
+	"\x05nonce\x18\x05 \x01(\x03R\x05nonce\x12\x1d\n" +
+	"\n" +
+	"test_token\x18\x06 \x01(\tR\ttestToken\x12#\n" +
+	"\rcaptcha_token\x18\a \x01(\tR\fcaptchaToken\x12\x1c\n" +
+	"\tsignature\x18\b \x01(\tR\tsignature\"m\n" +
+	"\x15RequestTokensResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12:\n" +
+	"\ftransactions\x18\x02 \x03(\v2\x16.faucet.v1.TransactionR\ftransactions\"w\n" +
+	"\vTransaction\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\tR\x06amount\x12\x17\n" +
+	"\atx_hash\x18\x03 \x01(\tR\x06txHash\x12!\n" +
+	"\fexplorer_url\x18\x04 \x01(\tR\vexplorerUrl\"F\n" +
+	"\x10GetStatusRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x18\n" +
+	"\anetwork\x18\x02 \x01(\tR\anetwork\"\x97\x02\n" +
+	"\x11GetStatusResponse\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x1f\n" +
+	"\vcan_request\x18\x02 \x01(\bR\n" +
+	"canRequest\x12=\n" +
+	"\flast_request\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vlastRequest\x12F\n" +
+	"\x11next_request_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0fnextRequestTime\x12,\n" +
+	"\x0fremaining_hours\x18\x05 \x01(\x01H\x00R\x0eremainingHours\x88\x01\x01B\x12\n" +
+	"\x10_remaining_hours\"*\n" +
+	"\x0eGetInfoRequest\x12\x18\n" +
+	"\anetwork\x18\x01 \x01(\tR\anetwork\"\xc7\x01\n" +
+	"\x0fGetInfoResponse\x12'\n" +
+	"\x0fdefault_network\x18\x01 \x01(\tR\x0edefaultNetwork\x121\n" +
+	"\x15daily_requests_per_ip\x18\x02 \x01(\x05R\x12dailyRequestsPerIp\x12$\n" +
+	"\x03pow\x18\x03 \x01(\v2\x12.faucet.v1.PoWInfoR\x03pow\x122\n" +
+	"\bnetworks\x18\x04 \x03(\v2\x16.faucet.v1.NetworkInfoR\bnetworks\"\xdc\x01\n" +
+	"\aPoWInfo\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1c\n" +
+	"\talgorithm\x18\x02 \x01(\tR\talgorithm\x12+\n" +
+	"\x11difficulty_format\x18\x03 \x01(\tR\x10difficultyFormat\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x04 \x01(\x05R\n" +
+	"difficulty\x12%\n" +
+	"\x0emin_difficulty\x18\x05 \x01(\x05R\rminDifficulty\x12%\n" +
+	"\x0emax_difficulty\x18\x06 \x01(\x05R\rmaxDifficulty\"\xcb\x02\n" +
+	"\vNetworkInfo\x12\x18\n" +
+	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x14\n" +
+	"\x05chain\x18\x02 \x01(\tR\x05chain\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x18\n" +
+	"\adefault\x18\x04 \x01(\bR\adefault\x12%\n" +
+	"\x0efaucet_address\x18\x05 \x01(\tR\rfaucetAddress\x128\n" +
+	"\x18ownership_proof_required\x18\x06 \x01(\bR\x16ownershipProofRequired\x12O\n" +
+	"\x12human_verification\x18\a \x01(\v2 .faucet.v1.HumanVerificationInfoR\x11humanVerification\x12,\n" +
+	"\x06tokens\x18\b \x03(\v2\x14.faucet.v1.TokenInfoR\x06tokens\"j\n" +
+	"\x15HumanVerificationInfo\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x19\n" +
+	"\bsite_key\x18\x02 \x01(\tR\asiteKey\x12\x1a\n" +
+	"\brequired\x18\x03 \x01(\bR\brequired\"\xd3\x02\n" +
+	"\tTokenInfo\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x1a\n" +
+	"\bdecimals\x18\x02 \x01(\x05R\bdecimals\x12)\n" +
+	"\x10contract_address\x18\x03 \x01(\tR\x0fcontractAddress\x12\x1f\n" +
+	"\vdrip_amount\x18\x04 \x01(\tR\n" +
+	"dripAmount\x12 \n" +
+	"\fmax_per_hour\x18\x05 \x01(\x01R\n" +
+	"maxPerHour\x12\x1e\n" +
+	"\vmax_per_day\x18\x06 \x01(\x01R\tmaxPerDay\x122\n" +
+	"\x15max_recipient_balance\x18\a \x01(\x01R\x13maxRecipientBalance\x126\n" +
+	"\x17throttle_window_seconds\x18\b \x01(\x05R\x15throttleWindowSeconds\x12\x18\n" +
+	"\abalance\x18\t \x01(\tR\abalance\"\x11\n" +
+	"\x0fGetQuotaRequest\"\xa9\x02\n" +
+	"\x10GetQuotaResponse\x126\n" +
+	"\vdaily_limit\x18\x01 \x01(\v2\x15.faucet.v1.DailyQuotaR\n" +
+	"dailyLimit\x12u\n" +
+	"\x1ahourly_throttle_by_network\x18\x02 \x03(\v28.faucet.v1.GetQuotaResponse.HourlyThrottleByNetworkEntryR\x17hourlyThrottleByNetwork\x1af\n" +
+	"\x1cHourlyThrottleByNetworkEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x120\n" +
+	"\x05value\x18\x02 \x01(\v2\x1a.faucet.v1.NetworkThrottleR\x05value:\x028\x01\"\xca\x01\n" +
+	"\n" +
+	"DailyQuota\x12\x14\n" +
+	"\x05scope\x18\x01 \x01(\tR\x05scope\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04used\x18\x03 \x01(\x05R\x04used\x12\x1c\n" +
+	"\tremaining\x18\x04 \x01(\x05R\tremaining\x12=\n" +
+	"\fcooldown_end\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vcooldownEnd\x12\x1f\n" +
+	"\vin_cooldown\x18\x06 \x01(\bR\n" +
+	"inCooldown\"\xa6\x01\n" +
+	"\x0fNetworkThrottle\x12>\n" +
+	"\x06tokens\x18\x01 \x03(\v2&.faucet.v1.NetworkThrottle.TokensEntryR\x06tokens\x1aS\n" +
+	"\vTokensEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12.\n" +
+	"\x05value\x18\x02 \x01(\v2\x18.faucet.v1.TokenThrottleR\x05value:\x028\x01\"q\n" +
+	"\rTokenThrottle\x12\x1c\n" +
+	"\tavailable\x18\x01 \x01(\bR\tavailable\x12B\n" +
+	"\x0fnext_request_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\rnextRequestAt\"Q\n" +
+	"\x14FollowRequestRequest\x129\n" +
+	"\arequest\x18\x01 \x01(\v2\x1f.faucet.v1.RequestTokensRequestR\arequest\"\xbb\x02\n" +
+	"\x15FollowRequestResponse\x12<\n" +
+	"\x05stage\x18\x01 \x01(\x0e2&.faucet.v1.FollowRequestResponse.StageR\x05stage\x128\n" +
+	"\x06result\x18\x02 \x01(\v2 .faucet.v1.RequestTokensResponseR\x06result\x128\n" +
+	"\vtransaction\x18\x03 \x01(\v2\x16.faucet.v1.TransactionR\vtransaction\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"Z\n" +
+	"\x05Stage\x12\x15\n" +
+	"\x11STAGE_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fSTAGE_SUBMITTED\x10\x01\x12\x13\n" +
+	"\x0fSTAGE_CONFIRMED\x10\x02\x12\x10\n" +
+	"\fSTAGE_FAILED\x10\x032\xd9\x03\n" +
+	"\rFaucetService\x12O\n" +
+	"\fGetChallenge\x12\x1e.faucet.v1.GetChallengeRequest\x1a\x1f.faucet.v1.GetChallengeResponse\x12R\n" +
+	"\rRequestTokens\x12\x1f.faucet.v1.RequestTokensRequest\x1a .faucet.v1.RequestTokensResponse\x12F\n" +
+	"\tGetStatus\x12\x1b.faucet.v1.GetStatusRequest\x1a\x1c.faucet.v1.GetStatusResponse\x12@\n" +
+	"\aGetInfo\x12\x19.faucet.v1.GetInfoRequest\x1a\x1a.faucet.v1.GetInfoResponse\x12C\n" +
+	"\bGetQuota\x12\x1a.faucet.v1.GetQuotaRequest\x1a\x1b.faucet.v1.GetQuotaResponse\x12T\n" +
+	"\rFollowRequest\x12\x1f.faucet.v1.FollowRequestRequest\x1a .faucet.v1.FollowRequestResponse0\x01B>Z<github.com/Giri-Aayush/starknet-faucet/pkg/faucetpb;faucetpbb\x06proto3"
+
+var (
+	file_faucet_v1_faucet_proto_rawDescOnce sync.Once
+	file_faucet_v1_faucet_proto_rawDescData []byte
+)
+
+func file_faucet_v1_faucet_proto_rawDescGZIP() []byte {
+	file_faucet_v1_faucet_proto_rawDescOnce.Do(func() {
+		file_faucet_v1_faucet_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_faucet_v1_faucet_proto_rawDesc), len(file_faucet_v1_faucet_proto_rawDesc)))
+	})
+	return file_faucet_v1_faucet_proto_rawDescData
+}
+
+var file_faucet_v1_faucet_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_faucet_v1_faucet_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_faucet_v1_faucet_proto_goTypes = []any{
+	(FollowRequestResponse_Stage)(0), // 0: faucet.v1.FollowRequestResponse.Stage
+	(*GetChallengeRequest)(nil),      // 1: faucet.v1.GetChallengeRequest
+	(*GetChallengeResponse)(nil),     // 2: faucet.v1.GetChallengeResponse
+	(*PoWParams)(nil),                // 3: faucet.v1.PoWParams
+	(*RequestTokensRequest)(nil),     // 4: faucet.v1.RequestTokensRequest
+	(*RequestTokensResponse)(nil),    // 5: faucet.v1.RequestTokensResponse
+	(*Transaction)(nil),              // 6: faucet.v1.Transaction
+	(*GetStatusRequest)(nil),         // 7: faucet.v1.GetStatusRequest
+	(*GetStatusResponse)(nil),        // 8: faucet.v1.GetStatusResponse
+	(*GetInfoRequest)(nil),           // 9: faucet.v1.GetInfoRequest
+	(*GetInfoResponse)(nil),          // 10: faucet.v1.GetInfoResponse
+	(*PoWInfo)(nil),                  // 11: faucet.v1.PoWInfo
+	(*NetworkInfo)(nil),              // 12: faucet.v1.NetworkInfo
+	(*HumanVerificationInfo)(nil),    // 13: faucet.v1.HumanVerificationInfo
+	(*TokenInfo)(nil),                // 14: faucet.v1.TokenInfo
+	(*GetQuotaRequest)(nil),          // 15: faucet.v1.GetQuotaRequest
+	(*GetQuotaResponse)(nil),         // 16: faucet.v1.GetQuotaResponse
+	(*DailyQuota)(nil),               // 17: faucet.v1.DailyQuota
+	(*NetworkThrottle)(nil),          // 18: faucet.v1.NetworkThrottle
+	(*TokenThrottle)(nil),            // 19: faucet.v1.TokenThrottle
+	(*FollowRequestRequest)(nil),     // 20: faucet.v1.FollowRequestRequest
+	(*FollowRequestResponse)(nil),    // 21: faucet.v1.FollowRequestResponse
+	nil,                              // 22: faucet.v1.GetQuotaResponse.HourlyThrottleByNetworkEntry
+	nil,                              // 23: faucet.v1.NetworkThrottle.TokensEntry
+	(*timestamppb.Timestamp)(nil),    // 24: google.protobuf.Timestamp
+}
+var file_faucet_v1_faucet_proto_depIdxs = []int32{
+	3,  // 0: faucet.v1.GetChallengeResponse.params:type_name -> faucet.v1.PoWParams
+	6,  // 1: faucet.v1.RequestTokensResponse.transactions:type_name -> faucet.v1.Transaction
+	24, // 2: faucet.v1.GetStatusResponse.last_request:type_name -> google.protobuf.Timestamp
+	24, // 3: faucet.v1.GetStatusResponse.next_request_time:type_name -> google.protobuf.Timestamp
+	11, // 4: faucet.v1.GetInfoResponse.pow:type_name -> faucet.v1.PoWInfo
+	12, // 5: faucet.v1.GetInfoResponse.networks:type_name -> faucet.v1.NetworkInfo
+	13, // 6: faucet.v1.NetworkInfo.human_verification:type_name -> faucet.v1.HumanVerificationInfo
+	14, // 7: faucet.v1.NetworkInfo.tokens:type_name -> faucet.v1.TokenInfo
+	17, // 8: faucet.v1.GetQuotaResponse.daily_limit:type_name -> faucet.v1.DailyQuota
+	22, // 9: faucet.v1.GetQuotaResponse.hourly_throttle_by_network:type_name -> faucet.v1.GetQuotaResponse.HourlyThrottleByNetworkEntry
+	24, // 10: faucet.v1.DailyQuota.cooldown_end:type_name -> google.protobuf.Timestamp
+	23, // 11: faucet.v1.NetworkThrottle.tokens:type_name -> faucet.v1.NetworkThrottle.TokensEntry
+	24, // 12: faucet.v1.TokenThrottle.next_request_at:type_name -> google.protobuf.Timestamp
+	4,  // 13: faucet.v1.FollowRequestRequest.request:type_name -> faucet.v1.RequestTokensRequest
+	0,  // 14: faucet.v1.FollowRequestResponse.stage:type_name -> faucet.v1.FollowRequestResponse.Stage
+	5,  // 15: faucet.v1.FollowRequestResponse.result:type_name -> faucet.v1.RequestTokensResponse
+	6,  // 16: faucet.v1.FollowRequestResponse.transaction:type_name -> faucet.v1.Transaction
+	18, // 17: faucet.v1.GetQuotaResponse.HourlyThrottleByNetworkEntry.value:type_name -> faucet.v1.NetworkThrottle
+	19, // 18: faucet.v1.NetworkThrottle.TokensEntry.value:type_name -> faucet.v1.TokenThrottle
+	1,  // 19: faucet.v1.FaucetService.GetChallenge:input_type -> faucet.v1.GetChallengeRequest
+	4,  // 20: faucet.v1.FaucetService.RequestTokens:input_type -> faucet.v1.RequestTokensRequest
+	7,  // 21: faucet.v1.FaucetService.GetStatus:input_type -> faucet.v1.GetStatusRequest
+	9,  // 22: faucet.v1.FaucetService.GetInfo:input_type -> faucet.v1.GetInfoRequest
+	15, // 23: faucet.v1.FaucetService.GetQuota:input_type -> faucet.v1.GetQuotaRequest
+	20, // 24: faucet.v1.FaucetService.FollowRequest:input_type -> faucet.v1.FollowRequestRequest
+	2,  // 25: faucet.v1.FaucetService.GetChallenge:output_type -> faucet.v1.GetChallengeResponse
+	5,  // 26: faucet.v1.FaucetService.RequestTokens:output_type -> faucet.v1.RequestTokensResponse
+	8,  // 27: faucet.v1.FaucetService.GetStatus:output_type -> faucet.v1.GetStatusResponse
+	10, // 28: faucet.v1.FaucetService.GetInfo:output_type -> faucet.v1.GetInfoResponse
+	16, // 29: faucet.v1.FaucetService.GetQuota:output_type -> faucet.v1.GetQuotaResponse
+	21, // 30: faucet.v1.FaucetService.FollowRequest:output_type -> faucet.v1.FollowRequestResponse
+	25, // [25:31] is the sub-list for method output_type
+	19, // [19:25] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
+}
+
+func init() { file_faucet_v1_faucet_proto_init() }
+func file_faucet_v1_faucet_proto_init() {
+	if File_faucet_v1_faucet_proto != nil {
+		return
+	}
+	file_faucet_v1_faucet_proto_msgTypes[7].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_faucet_v1_faucet_proto_rawDesc), len(file_faucet_v1_faucet_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   23,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_faucet_v1_faucet_proto_goTypes,
+		DependencyIndexes: file_faucet_v1_faucet_proto_depIdxs,
+		EnumInfos:         file_faucet_v1_faucet_proto_enumTypes,
+		MessageInfos:      file_faucet_v1_faucet_proto_msgTypes,
+	}.Build()
+	File_faucet_v1_faucet_proto = out.File
+	file_faucet_v1_faucet_proto_goTypes = nil
+	file_faucet_v1_faucet_proto_depIdxs = nil
+}